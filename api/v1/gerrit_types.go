@@ -4,7 +4,10 @@ import (
 	"fmt"
 	"path"
 
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 
 	"github.com/epam/edp-gerrit-operator/v2/pkg/service/gerrit/spec"
 )
@@ -27,8 +30,181 @@ type GerritSpec struct {
 
 	// ExternalURL gerrit full external url for keycloak or other integrations
 	ExternalURL string `json:"externalURL,omitempty"`
+
+	// Sync configures the import of existing Gerrit entities into custom resources.
+	// +optional
+	Sync GerritSyncSpec `json:"sync,omitempty"`
+
+	// Config defines the gerrit.config and secure.config values managed by the operator.
+	// +optional
+	Config *GerritSiteConfig `json:"config,omitempty"`
+
+	// Bootstrap defines the groups and the All-Projects access rights created by the operator.
+	// If it is not set, the default EDP groups and All-Projects configuration are created once.
+	// +optional
+	Bootstrap *GerritBootstrap `json:"bootstrap,omitempty"`
+
+	// PrimaryPodSelector selects the Gerrit primary pods by labels.
+	// The files managed by the operator, e.g. the replication configuration, are written into all ready primary pods,
+	// the pods that do not match the selector, e.g. Gerrit replicas, are not changed.
+	// If it is not set, the pods with the app=<name> label are selected.
+	// +optional
+	// +kubebuilder:example:={"app": "gerrit", "role": "primary"}
+	PrimaryPodSelector map[string]string `json:"primaryPodSelector,omitempty"`
+
+	// CleanupPolicy defines the cleanup performed when the Gerrit resource is deleted.
+	// If it is not set, the generated Secrets are deleted and the dependent resources are orphaned.
+	// +optional
+	CleanupPolicy *GerritCleanupPolicy `json:"cleanupPolicy,omitempty"`
+
+	// AllowedNamespaces are the namespaces whose resources can use this Gerrit
+	// by referencing it as <namespace>/<name> in the owner name, "*" allows all namespaces.
+	// The resources in the Gerrit namespace can always use it.
+	// +optional
+	// +kubebuilder:example:={"team-a", "team-b"}
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+}
+
+// GerritCleanupPolicy defines the cleanup performed by the Gerrit resource finalizer.
+type GerritCleanupPolicy struct {
+	// Secrets defines what happens to the Secrets generated by the operator, e.g. the admin password.
+	// Delete removes the Secrets, Retain keeps them without the owner reference.
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +kubebuilder:default=Delete
+	// +optional
+	Secrets string `json:"secrets,omitempty"`
+
+	// DependentResources defines what happens to the resources that belong to the Gerrit, e.g. GerritProject,
+	// including the resources in the other namespaces that reference the Gerrit as <namespace>/<name>.
+	// Cascade deletes them and waits up to 10 minutes until their finalizers clean up Gerrit,
+	// Orphan removes the owner references, so they are adopted by a new Gerrit resource with the same name.
+	// +kubebuilder:validation:Enum=Cascade;Orphan
+	// +kubebuilder:default=Orphan
+	// +optional
+	DependentResources string `json:"dependentResources,omitempty"`
+
+	// DeactivateServiceAccounts deactivates the CI and Argo CD accounts created by the operator in Gerrit.
+	// +optional
+	DeactivateServiceAccounts bool `json:"deactivateServiceAccounts,omitempty"`
+}
+
+const (
+	CleanupSecretsDelete = "Delete"
+	CleanupSecretsRetain = "Retain"
+
+	CleanupDependentCascade = "Cascade"
+	CleanupDependentOrphan  = "Orphan"
+)
+
+// GerritBootstrap defines the groups and the All-Projects access rights applied with the REST API on every reconciliation.
+// The missing groups, members and access rules are added, the existing ones that are not declared here are kept.
+type GerritBootstrap struct {
+	// Groups are the internal groups created in Gerrit, e.g. "Developers".
+	// +optional
+	Groups []GerritBootstrapGroup `json:"groups,omitempty"`
+
+	// AllProjectsAccess are the access rights added to All-Projects.
+	// The groupName is the group name or UUID, the GLOBAL_CAPABILITIES refPattern sets the global capabilities.
+	// +optional
+	AllProjectsAccess []Reference `json:"allProjectsAccess,omitempty"`
 }
 
+// GerritBootstrapGroup defines a group created by the operator.
+type GerritBootstrapGroup struct {
+	// Name is the name of the group.
+	Name string `json:"name"`
+
+	// Description of the group.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// VisibleToAll makes the group visible to all registered users.
+	// +optional
+	// +kubebuilder:default=true
+	VisibleToAll bool `json:"visibleToAll"`
+
+	// Members are the usernames or emails of the accounts added to the group.
+	// +optional
+	Members []string `json:"members,omitempty"`
+}
+
+// GerritSiteConfig defines the values the operator renders into the site configuration.
+// The values are written into the <name>-site-config ConfigMap and Secret that are merged into
+// etc/gerrit.config and etc/secure.config when Gerrit starts, so Gerrit is restarted when they change.
+// The values that have never been set here are kept as they are in the site configuration,
+// the values removed from here are removed from the site configuration on the next start.
+type GerritSiteConfig struct {
+	// Values are the gerrit.config values. The key is the section name, e.g. "receive",
+	// or the section and the subsection separated by a dot, e.g. "commentlink.jira".
+	// The values of a section are keyed by the variable name.
+	// +optional
+	// +kubebuilder:example:={"receive": {"timeout": "4min"}, "sshd": {"threads": "8"}}
+	Values map[string]map[string]string `json:"values,omitempty"`
+
+	// ListValues are the multi-valued gerrit.config variables, e.g. download.scheme.
+	// They are keyed in the same way as Values, a variable set in both fields gets the values of ListValues.
+	// +optional
+	// +kubebuilder:example:={"download": {"scheme": {"ssh", "http"}}}
+	ListValues map[string]map[string][]string `json:"listValues,omitempty"`
+
+	// SecureValues are the secure.config values read from the Secrets in the Gerrit namespace.
+	// They are keyed in the same way as Values.
+	// +optional
+	SecureValues map[string]map[string]coreV1.SecretKeySelector `json:"secureValues,omitempty"`
+}
+
+// GerritSyncSpec defines which Gerrit entities are imported by the project syncer.
+// Projects are always imported, other entities are opt-in.
+// Imported CRs are managed as any other CR, e.g. deleting an imported GerritProjectAccess removes its rights from Gerrit.
+type GerritSyncSpec struct {
+	// ImportGroups enables the import of internal Gerrit groups into GerritGroup CRs
+	// and of their members into GerritGroupMember CRs.
+	// +optional
+	ImportGroups bool `json:"importGroups,omitempty"`
+
+	// ImportAccess enables the import of local project access sections into GerritProjectAccess CRs.
+	// +optional
+	ImportAccess bool `json:"importAccess,omitempty"`
+
+	// IncludeProjects is a regular expression, only the projects with matching names are imported.
+	// +optional
+	// +kubebuilder:example:=`^team/.*`
+	IncludeProjects string `json:"includeProjects,omitempty"`
+
+	// ExcludeProjects is a regular expression, the projects with matching names are not imported.
+	// +optional
+	ExcludeProjects string `json:"excludeProjects,omitempty"`
+
+	// ImportLabels are added to the imported CRs.
+	// +optional
+	ImportLabels map[string]string `json:"importLabels,omitempty"`
+
+	// OrphanPolicy defines what happens to a GerritProject CR when its project is deleted from Gerrit out-of-band.
+	// Ignore keeps the CR as it is, Mark sets the Orphaned condition on the CR,
+	// Delete removes the CR without touching Gerrit. Only the CRs that were applied successfully are considered.
+	// +kubebuilder:validation:Enum=Ignore;Mark;Delete
+	// +kubebuilder:default=Ignore
+	// +optional
+	OrphanPolicy string `json:"orphanPolicy,omitempty"`
+
+	// ProjectSelector limits the import and the sync to the projects with matching labels.
+	// A project that is not imported yet is matched by the labels its GerritProject CR gets: importLabels
+	// and the edp.epam.com/gerrit-parent label with the slug of the parent project, e.g. "team-a".
+	// An existing GerritProject CR is matched by its labels, the CRs that do not match are neither synced nor handled as orphans.
+	// +optional
+	// +kubebuilder:example:={"matchLabels": {"edp.epam.com/gerrit-parent": "team-a"}}
+	ProjectSelector *metav1.LabelSelector `json:"projectSelector,omitempty"`
+}
+
+const (
+	OrphanPolicyIgnore = "Ignore"
+	OrphanPolicyMark   = "Mark"
+	OrphanPolicyDelete = "Delete"
+)
+
+// ProjectParentLabel is the label of the imported GerritProject CRs with the slug of the parent project.
+const ProjectParentLabel = "edp.epam.com/gerrit-parent"
+
 // KeycloakSpec is deprecated: the operator no longer performs Keycloak integration.
 // SSO is configured via the Helm chart (KeycloakClient CR and OAUTH_* env values).
 // The field is kept for backward compatibility of existing Gerrit resources.
@@ -54,8 +230,67 @@ type GerritStatus struct {
 
 	// +optional
 	Status string `json:"status,omitempty"`
+
+	// LastProjectSyncTime is the time of the last sync of Gerrit projects into GerritProject CRs.
+	// +optional
+	LastProjectSyncTime metav1.Time `json:"lastProjectSyncTime,omitempty"`
+
+	// ProjectSyncError is the error of the last project sync. It is empty if the sync succeeded.
+	// +optional
+	ProjectSyncError string `json:"projectSyncError,omitempty"`
+
+	// ConfigHash is the hash of the site configuration Gerrit has been restarted with.
+	// +optional
+	ConfigHash string `json:"configHash,omitempty"`
+
+	// PlannedActions contains the changes in Gerrit that are planned in the dry-run mode.
+	// +nullable
+	// +optional
+	PlannedActions []string `json:"plannedActions,omitempty"`
+
+	// Version is the Gerrit version detected with the REST API.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Plugins are the IDs of the enabled Gerrit plugins.
+	// +optional
+	Plugins []string `json:"plugins,omitempty"`
+
+	// Conditions report the Gerrit features supported by the detected version and plugins,
+	// e.g. the DeleteProjectSupported condition, and the result of the health checks, e.g. the Healthy condition.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+const (
+	// ConditionSubmitRequirementsSupported reports whether Gerrit supports submit requirements (Gerrit 3.6 or newer).
+	ConditionSubmitRequirementsSupported = "SubmitRequirementsSupported"
+
+	// ConditionAuthTokensSupported reports whether Gerrit supports authentication tokens (Gerrit 3.13 or newer).
+	ConditionAuthTokensSupported = "AuthTokensSupported"
+
+	// ConditionDeleteProjectSupported reports whether the delete-project plugin is enabled,
+	// the projects are not deleted from Gerrit without it.
+	ConditionDeleteProjectSupported = "DeleteProjectSupported"
+
+	// ConditionHealthy reports whether the REST API, SSH and the change index of Gerrit are healthy,
+	// the controllers of the dependent resources back off while it is False.
+	ConditionHealthy = "Healthy"
+
+	// ConditionRESTHealthy reports whether the REST API accepts the admin credentials.
+	ConditionRESTHealthy = "RESTHealthy"
+
+	// ConditionSSHHealthy reports whether the "gerrit version" SSH command succeeds.
+	ConditionSSHHealthy = "SSHHealthy"
+
+	// ConditionIndexHealthy reports whether the change index answers the queries.
+	ConditionIndexHealthy = "IndexHealthy"
+
+	// ConditionReplicationQueueHealthy reports whether the replication queue is below the limit.
+	// It does not affect the Healthy condition.
+	ConditionReplicationQueueHealthy = "ReplicationQueueHealthy"
+)
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:storageversion
@@ -77,6 +312,59 @@ func (in *GerritSpec) GetBasePath() string {
 	return fmt.Sprintf("%s/", path.Join(in.BasePath, spec.GerritRestApiUrlPath))
 }
 
+// GetPrimaryPodSelector returns the label selector of the Gerrit primary pods.
+func (in *Gerrit) GetPrimaryPodSelector() string {
+	if len(in.Spec.PrimaryPodSelector) == 0 {
+		return labels.SelectorFromSet(labels.Set{"app": in.Name}).String()
+	}
+
+	return labels.SelectorFromSet(in.Spec.PrimaryPodSelector).String()
+}
+
+// IsFeatureUnsupported checks that the feature condition is reported as not supported.
+// The feature is considered supported until the Gerrit version and plugins are detected.
+func (in *Gerrit) IsFeatureUnsupported(conditionType string) bool {
+	return meta.IsStatusConditionFalse(in.Status.Conditions, conditionType)
+}
+
+// IsUnhealthy checks that the health monitor has reported Gerrit as unhealthy.
+func (in *Gerrit) IsUnhealthy() bool {
+	return meta.IsStatusConditionFalse(in.Status.Conditions, ConditionHealthy)
+}
+
+// IsNamespaceAllowed checks that the resources in the namespace can use the Gerrit.
+func (in *Gerrit) IsNamespaceAllowed(namespace string) bool {
+	if namespace == in.Namespace {
+		return true
+	}
+
+	for _, allowed := range in.Spec.AllowedNamespaces {
+		if allowed == "*" || allowed == namespace {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetCleanupPolicy returns the cleanup policy with the defaults applied.
+func (in *Gerrit) GetCleanupPolicy() GerritCleanupPolicy {
+	policy := GerritCleanupPolicy{}
+	if in.Spec.CleanupPolicy != nil {
+		policy = *in.Spec.CleanupPolicy
+	}
+
+	if policy.Secrets == "" {
+		policy.Secrets = CleanupSecretsDelete
+	}
+
+	if policy.DependentResources == "" {
+		policy.DependentResources = CleanupDependentOrphan
+	}
+
+	return policy
+}
+
 // +kubebuilder:object:root=true
 
 // GerritList contains a list of Gerrit.
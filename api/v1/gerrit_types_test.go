@@ -15,3 +15,16 @@ func TestGerritSpec_GetBasePath(t *testing.T) {
 	gs.BasePath = "gerrit"
 	assert.Equal(t, gs.GetBasePath(), "gerrit/a/")
 }
+
+func TestGerrit_IsNamespaceAllowed(t *testing.T) {
+	g := Gerrit{}
+	g.Namespace = "platform"
+	g.Spec.AllowedNamespaces = []string{"team-a"}
+
+	assert.True(t, g.IsNamespaceAllowed("platform"))
+	assert.True(t, g.IsNamespaceAllowed("team-a"))
+	assert.False(t, g.IsNamespaceAllowed("team-b"))
+
+	g.Spec.AllowedNamespaces = []string{"*"}
+	assert.True(t, g.IsNamespaceAllowed("team-b"))
+}
@@ -0,0 +1,93 @@
+package v1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+const (
+	// AccessAllowed and AccessDenied are the results of the access check.
+	AccessAllowed = "Allowed"
+	AccessDenied  = "Denied"
+)
+
+// AccessCheck is the effective permission of an account to check.
+type AccessCheck struct {
+	// Account is the username, email or numeric ID of the Gerrit account.
+	Account string `json:"account"`
+
+	// Ref is the ref to check, e.g. refs/heads/release/1.0. The read access to the project is checked if it is empty.
+	// +optional
+	Ref string `json:"ref,omitempty"`
+
+	// Permission is the permission on the ref to check, e.g. push or label-Code-Review. It requires the ref.
+	// The read access to the ref is checked if it is empty.
+	// +optional
+	Permission string `json:"permission,omitempty"`
+}
+
+// GerritAccessCheckSpec defines the desired state of GerritAccessCheck.
+type GerritAccessCheckSpec struct {
+	// ProjectName is the name of the Gerrit project to check.
+	ProjectName string `json:"projectName"`
+
+	AccessCheck `json:",inline"`
+
+	// OwnerName indicates which gerrit CR should be taken to initialize correct client.
+	// A Gerrit in another namespace is referenced as <namespace>/<name>.
+	// +nullable
+	// +optional
+	OwnerName string `json:"ownerName,omitempty"`
+}
+
+// GerritAccessCheckStatus defines the observed state of GerritAccessCheck.
+type GerritAccessCheckStatus struct {
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// Result is Allowed or Denied.
+	// +optional
+	Result string `json:"result,omitempty"`
+
+	// Message explains the denied access.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// DebugLogs is the trace of the permission evaluation returned by Gerrit.
+	// +nullable
+	// +optional
+	DebugLogs []string `json:"debugLogs,omitempty"`
+
+	// LastTimeChecked is the time of the last access check.
+	// +optional
+	LastTimeChecked metav1.Time `json:"lastTimeChecked,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// GerritAccessCheck is the Schema for the gerrit access check API.
+type GerritAccessCheck struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GerritAccessCheckSpec   `json:"spec,omitempty"`
+	Status GerritAccessCheckStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GerritAccessCheckList contains a list of GerritAccessCheck.
+type GerritAccessCheckList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []GerritAccessCheck `json:"items"`
+}
+
+// OwnerName returns the name of the Gerrit the resource belongs to.
+func (in *GerritAccessCheck) OwnerName() string {
+	return in.Spec.OwnerName
+}
+
+func init() {
+	SchemeBuilder.Register(&GerritAccessCheck{}, &GerritAccessCheckList{})
+}
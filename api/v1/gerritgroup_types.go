@@ -6,6 +6,9 @@ import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 type GerritGroupSpec struct {
 	Name string `json:"name"`
 
+	// OwnerName indicates which gerrit CR should be taken to initialize correct client.
+	// A Gerrit in another namespace is referenced as <namespace>/<name>.
+	// It is not related to the Gerrit group owner, use OwnerGroup for that.
 	// +optional
 	OwnerName string `json:"gerritOwner,omitempty"`
 
@@ -14,6 +17,17 @@ type GerritGroupSpec struct {
 
 	// +optional
 	VisibleToAll bool `json:"visibleToAll,omitempty"`
+
+	// OwnerGroup is the name or UUID of the Gerrit group that owns this group.
+	// Members of the owner group are allowed to administrate this group.
+	// +optional
+	// +kubebuilder:example:=`team-leads`
+	OwnerGroup string `json:"ownerGroup,omitempty"`
+
+	// IncludedGroups is a list of names or UUIDs of the Gerrit groups that are included into this group as subgroups.
+	// +nullable
+	// +optional
+	IncludedGroups []string `json:"includedGroups,omitempty"`
 }
 
 // GerritGroupStatus defines the observed state of GerritGroup.
@@ -26,6 +40,31 @@ type GerritGroupStatus struct {
 
 	// +optional
 	Value string `json:"value,omitempty"`
+
+	// Created is true if the group has been created by the operator.
+	// The description and the visibility are managed only for such groups.
+	// +optional
+	Created bool `json:"created,omitempty"`
+
+	// OwnerGroupID is the UUID of the owner group.
+	// +optional
+	OwnerGroupID string `json:"ownerGroupId,omitempty"`
+
+	// IncludedGroups contains UUIDs of the subgroups that are included into the group by the operator.
+	// +nullable
+	// +optional
+	IncludedGroups []string `json:"includedGroups,omitempty"`
+
+	// Conditions contain the Drifted condition set by the drift check.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// PlannedActions contains the changes in Gerrit that are planned in the dry-run mode.
+	// +nullable
+	// +optional
+	PlannedActions []string `json:"plannedActions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -51,6 +90,11 @@ type GerritGroupList struct {
 	Items []GerritGroup `json:"items"`
 }
 
+// OwnerName returns the name of the Gerrit the resource belongs to.
+func (in *GerritGroup) OwnerName() string {
+	return in.Spec.OwnerName
+}
+
 func init() {
 	SchemeBuilder.Register(&GerritGroup{}, &GerritGroupList{})
 }
@@ -4,10 +4,14 @@ import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 // GerritGroupMemberSpec defines the desired state of GerritGroupMember.
 type GerritGroupMemberSpec struct {
-	GroupID   string `json:"groupId"`
+	// GroupID is the name or UUID of the Gerrit group.
+	GroupID string `json:"groupId"`
+
+	// AccountID is the username, email or numeric ID of the Gerrit account.
 	AccountID string `json:"accountId"`
 
 	// OwnerName indicates which gerrit CR should be taken to initialize correct client.
+	// A Gerrit in another namespace is referenced as <namespace>/<name>.
 	// +nullable
 	// +optional
 	OwnerName string `json:"ownerName,omitempty"`
@@ -21,6 +25,25 @@ type GerritGroupMemberStatus struct {
 	// Preserves Number of Failures during reconciliation phase. Used for exponential back-off calculation
 	// +optional
 	FailureCount int64 `json:"failureCount,omitempty"`
+
+	// AccountID is the numeric ID of the resolved Gerrit account.
+	// +optional
+	AccountID int `json:"accountId,omitempty"`
+
+	// GroupUUID is the UUID of the resolved Gerrit group.
+	// +optional
+	GroupUUID string `json:"groupUuid,omitempty"`
+
+	// Conditions describe the account and group resolution and the drift of the membership.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// PlannedActions contains the changes in Gerrit that are planned in the dry-run mode.
+	// +nullable
+	// +optional
+	PlannedActions []string `json:"plannedActions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -62,6 +85,11 @@ type GerritGroupMemberList struct {
 	Items []GerritGroupMember `json:"items"`
 }
 
+// OwnerName returns the name of the Gerrit the resource belongs to.
+func (in *GerritGroupMember) OwnerName() string {
+	return in.Spec.OwnerName
+}
+
 func init() {
 	SchemeBuilder.Register(&GerritGroupMember{}, &GerritGroupMemberList{})
 }
@@ -0,0 +1,136 @@
+package v1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// GerritGroupSyncSpec defines the desired state of GerritGroupSync.
+type GerritGroupSyncSpec struct {
+	// GroupID is the name or UUID of the Gerrit group whose members are managed.
+	// Members of the group that are not present in the source are removed from the group.
+	// Deleting the GerritGroupSync leaves the group members as they are.
+	GroupID string `json:"groupId"`
+
+	// Source defines where the desired list of group members is read from.
+	Source GroupMemberSource `json:"source"`
+
+	// SyncInterval defines how often the members are re-read from the source.
+	// +kubebuilder:default="10m"
+	// +optional
+	SyncInterval string `json:"syncInterval,omitempty"`
+
+	// AllowEmpty allows the source without members to remove all members from the group.
+	// By default the empty source is reported with the SourceEmpty condition and the group is left unchanged,
+	// so a broken source does not lock the users out.
+	// +optional
+	AllowEmpty bool `json:"allowEmpty,omitempty"`
+
+	// OwnerName indicates which gerrit CR should be taken to initialize correct client.
+	// A Gerrit in another namespace is referenced as <namespace>/<name>.
+	// +nullable
+	// +optional
+	OwnerName string `json:"ownerName,omitempty"`
+}
+
+// GroupMemberSource defines a source of group members. Exactly one source should be set.
+type GroupMemberSource struct {
+	// ConfigMap reads members from a ConfigMap in the same namespace.
+	// +nullable
+	// +optional
+	ConfigMap *ConfigMapMemberSource `json:"configMap,omitempty"`
+}
+
+// ConfigMapMemberSource reads group members from a CSV document stored in a ConfigMap key.
+// The first column of each record is a Gerrit username, email or account ID.
+// Empty records and lines starting with '#' are ignored.
+type ConfigMapMemberSource struct {
+	// Name is the name of the ConfigMap.
+	Name string `json:"name"`
+
+	// Key is the ConfigMap data key that contains the members.
+	// +kubebuilder:default="members.csv"
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+// GerritGroupSyncStatus defines the observed state of GerritGroupSync.
+type GerritGroupSyncStatus struct {
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// Preserves Number of Failures during reconciliation phase. Used for exponential back-off calculation
+	// +optional
+	FailureCount int64 `json:"failureCount,omitempty"`
+
+	// Members is the number of accounts provided by the source during the last sync.
+	// +optional
+	Members int `json:"members,omitempty"`
+
+	// Added is the number of accounts added to the group during the last sync.
+	// +optional
+	Added int `json:"added,omitempty"`
+
+	// Removed is the number of accounts removed from the group during the last sync.
+	// +optional
+	Removed int `json:"removed,omitempty"`
+
+	// +optional
+	LastTimeSynced metav1.Time `json:"lastTimeSynced,omitempty"`
+
+	// Conditions contain the SourceEmpty condition set when the empty source is not applied.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// PlannedActions contains the changes in Gerrit that are planned in the dry-run mode.
+	// +nullable
+	// +optional
+	PlannedActions []string `json:"plannedActions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// GerritGroupSync is the Schema for the gerrit group sync API.
+type GerritGroupSync struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GerritGroupSyncSpec   `json:"spec,omitempty"`
+	Status GerritGroupSyncStatus `json:"status,omitempty"`
+}
+
+func (in *GerritGroupSync) GetFailureCount() int64 {
+	return in.Status.FailureCount
+}
+
+func (in *GerritGroupSync) SetFailureCount(count int64) {
+	in.Status.FailureCount = count
+}
+
+func (in *GerritGroupSync) GetStatus() string {
+	return in.Status.Value
+}
+
+func (in *GerritGroupSync) SetStatus(value string) {
+	in.Status.Value = value
+}
+
+// +kubebuilder:object:root=true
+
+// GerritGroupSyncList contains a list of GerritGroupSync.
+type GerritGroupSyncList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []GerritGroupSync `json:"items"`
+}
+
+// OwnerName returns the name of the Gerrit the resource belongs to.
+func (in *GerritGroupSync) OwnerName() string {
+	return in.Spec.OwnerName
+}
+
+func init() {
+	SchemeBuilder.Register(&GerritGroupSync{}, &GerritGroupSyncList{})
+}
@@ -10,6 +10,7 @@ import (
 type GerritMergeRequestSpec struct {
 	// OwnerName is the name of Gerrit CR, which should be used to initialize the client.
 	// If empty, the operator will get first Gerrit CR from the namespace.
+	// A Gerrit in another namespace is referenced as <namespace>/<name>.
 	// +optional
 	// +kubebuilder:example:=`gerrit`
 	OwnerName string `json:"ownerName"`
@@ -62,6 +63,40 @@ type GerritMergeRequestSpec struct {
 	// +nullable
 	// +optional
 	AdditionalArguments []string `json:"additionalArguments,omitempty"`
+
+	// Reviewers is the list of accounts, usernames or emails, added as reviewers of the change.
+	// +nullable
+	// +optional
+	Reviewers []string `json:"reviewers,omitempty"`
+
+	// CC is the list of accounts, usernames or emails, added to CC of the change.
+	// +nullable
+	// +optional
+	CC []string `json:"cc,omitempty"`
+
+	// Topic is the topic of the change.
+	// +optional
+	// +kubebuilder:example:=`dependency-update`
+	Topic string `json:"topic,omitempty"`
+
+	// Hashtags is the list of hashtags of the change.
+	// +nullable
+	// +optional
+	Hashtags []string `json:"hashtags,omitempty"`
+
+	// WorkInProgress marks the change as work in progress.
+	// +optional
+	WorkInProgress bool `json:"workInProgress,omitempty"`
+
+	// Private marks the change as private.
+	// +optional
+	Private bool `json:"private,omitempty"`
+
+	// Notify is the notification setting of the pushed change.
+	// If empty, Gerrit default is used.
+	// +optional
+	// +kubebuilder:validation:Enum=NONE;OWNER;OWNER_REVIEWERS;ALL
+	Notify string `json:"notify,omitempty"`
 }
 
 // GerritMergeRequestStatus defines the observed state of GerritMergeRequest.
@@ -74,6 +109,38 @@ type GerritMergeRequestStatus struct {
 
 	// +optional
 	ChangeID string `json:"changeId,omitempty"`
+
+	// Reviewers is the list of reviewers of the change in Gerrit, the usernames or emails of the accounts.
+	// +nullable
+	// +optional
+	Reviewers []string `json:"reviewers,omitempty"`
+
+	// CC is the list of accounts in CC of the change in Gerrit, the usernames or emails of the accounts.
+	// +nullable
+	// +optional
+	CC []string `json:"cc,omitempty"`
+
+	// Topic is the topic of the change in Gerrit.
+	// +optional
+	Topic string `json:"topic,omitempty"`
+
+	// Hashtags is the list of hashtags of the change in Gerrit.
+	// +nullable
+	// +optional
+	Hashtags []string `json:"hashtags,omitempty"`
+
+	// WorkInProgress is true if the change is work in progress in Gerrit.
+	// +optional
+	WorkInProgress bool `json:"workInProgress,omitempty"`
+
+	// Private is true if the change is private in Gerrit.
+	// +optional
+	Private bool `json:"private,omitempty"`
+
+	// PlannedActions contains the changes in Gerrit that are planned in the dry-run mode.
+	// +nullable
+	// +optional
+	PlannedActions []string `json:"plannedActions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -0,0 +1,138 @@
+package v1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// GerritPluginSpec defines the desired state of GerritPlugin.
+type GerritPluginSpec struct {
+	// Name is the plugin ID in Gerrit, e.g. "replication".
+	Name string `json:"name"`
+
+	// Source defines where the plugin jar is installed from.
+	// The plugin is re-installed when the source changes, so changing the source upgrades the plugin.
+	Source PluginSource `json:"source"`
+
+	// Disabled disables the installed plugin.
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+
+	// ConfigMapName is the name of the ConfigMap with the plugin configuration.
+	// The ConfigMap is not written to Gerrit, it is expected to be mounted into the Gerrit site.
+	// The plugin is reloaded when the data of the ConfigMap changes.
+	// +nullable
+	// +optional
+	ConfigMapName string `json:"configMapName,omitempty"`
+
+	// OwnerName indicates which gerrit CR should be taken to initialize correct client.
+	// A Gerrit in another namespace is referenced as <namespace>/<name>.
+	// +nullable
+	// +optional
+	OwnerName string `json:"ownerName,omitempty"`
+}
+
+// PluginSource defines a source of the plugin jar. Exactly one source should be set.
+type PluginSource struct {
+	// URL is the URL the jar is downloaded from by Gerrit.
+	// +nullable
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// ConfigMap reads the jar from a ConfigMap in the same namespace and uploads it to Gerrit.
+	// +nullable
+	// +optional
+	ConfigMap *ConfigMapPluginSource `json:"configMap,omitempty"`
+
+	// Path is the path of the jar on the Gerrit server, e.g. on a mounted PVC.
+	// +nullable
+	// +optional
+	Path string `json:"path,omitempty"`
+}
+
+// ConfigMapPluginSource reads the plugin jar from a ConfigMap key.
+type ConfigMapPluginSource struct {
+	// Name is the name of the ConfigMap.
+	Name string `json:"name"`
+
+	// Key is the ConfigMap binaryData key that contains the jar.
+	// +kubebuilder:default="plugin.jar"
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+// GerritPluginStatus defines the observed state of GerritPlugin.
+type GerritPluginStatus struct {
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// Preserves Number of Failures during reconciliation phase. Used for exponential back-off calculation
+	// +optional
+	FailureCount int64 `json:"failureCount,omitempty"`
+
+	// Version is the version of the plugin reported by Gerrit.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Enabled reports whether the plugin is enabled in Gerrit.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// SourceHash is the hash of the installed source, the plugin is re-installed when it changes.
+	// +optional
+	SourceHash string `json:"sourceHash,omitempty"`
+
+	// ConfigHash is the hash of the plugin configuration, the plugin is reloaded when it changes.
+	// +optional
+	ConfigHash string `json:"configHash,omitempty"`
+
+	// PlannedActions contains the changes in Gerrit that are planned in the dry-run mode.
+	// +nullable
+	// +optional
+	PlannedActions []string `json:"plannedActions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// GerritPlugin is the Schema for the gerrit plugins API.
+type GerritPlugin struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GerritPluginSpec   `json:"spec,omitempty"`
+	Status GerritPluginStatus `json:"status,omitempty"`
+}
+
+func (in *GerritPlugin) GetFailureCount() int64 {
+	return in.Status.FailureCount
+}
+
+func (in *GerritPlugin) SetFailureCount(count int64) {
+	in.Status.FailureCount = count
+}
+
+func (in *GerritPlugin) GetStatus() string {
+	return in.Status.Value
+}
+
+func (in *GerritPlugin) SetStatus(value string) {
+	in.Status.Value = value
+}
+
+// +kubebuilder:object:root=true
+
+// GerritPluginList contains a list of GerritPlugin.
+type GerritPluginList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []GerritPlugin `json:"items"`
+}
+
+// OwnerName returns the name of the Gerrit the resource belongs to.
+func (in *GerritPlugin) OwnerName() string {
+	return in.Spec.OwnerName
+}
+
+func init() {
+	SchemeBuilder.Register(&GerritPlugin{}, &GerritPluginList{})
+}
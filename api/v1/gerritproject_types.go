@@ -6,6 +6,7 @@ import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 type GerritProjectSpec struct {
 	Name string `json:"name"`
 
+	// OwnerName is the name of the Gerrit CR, a Gerrit in another namespace is referenced as <namespace>/<name>.
 	// +optional
 	OwnerName string `json:"ownerName,omitempty"`
 
@@ -42,6 +43,17 @@ type GerritProjectStatus struct {
 	// +nullable
 	// +optional
 	Branches []string `json:"branches,omitempty"`
+
+	// Conditions contain the Orphaned condition set by the project syncer and the Drifted condition set by the drift check.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// PlannedActions contains the changes in Gerrit that are planned in the dry-run mode.
+	// +nullable
+	// +optional
+	PlannedActions []string `json:"plannedActions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -67,6 +79,11 @@ type GerritProjectList struct {
 	Items []GerritProject `json:"items"`
 }
 
+// OwnerName returns the name of the Gerrit the resource belongs to.
+func (in *GerritProject) OwnerName() string {
+	return in.Spec.OwnerName
+}
+
 func init() {
 	SchemeBuilder.Register(&GerritProject{}, &GerritProjectList{})
 }
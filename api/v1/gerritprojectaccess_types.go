@@ -8,6 +8,7 @@ type GerritProjectAccessSpec struct {
 	ProjectName string `json:"projectName"`
 
 	// OwnerName indicates which gerrit CR should be taken to initialize correct client.
+	// A Gerrit in another namespace is referenced as <namespace>/<name>.
 	// +nullable
 	// +optional
 	OwnerName string `json:"ownerName,omitempty"`
@@ -20,22 +21,50 @@ type GerritProjectAccessSpec struct {
 	// +nullable
 	// +optional
 	References []Reference `json:"references,omitempty"`
+
+	// Assertions are the access checks that are run after the access rights are applied,
+	// the violated assertions make the Degraded condition true.
+	// +nullable
+	// +optional
+	Assertions []AccessAssertion `json:"assertions,omitempty"`
+
+	// Review makes the operator create a review change of refs/meta/config instead of applying
+	// the access rights and the parent directly. The resource is applied when the change is merged.
+	// +optional
+	Review bool `json:"review,omitempty"`
+}
+
+// AccessAssertion is the expected result of the access check in the project.
+type AccessAssertion struct {
+	AccessCheck `json:",inline"`
+
+	// Expect is the expected result of the check.
+	// +kubebuilder:validation:Enum=Allowed;Denied
+	Expect string `json:"expect"`
 }
 
 type Reference struct {
 	// Patter is reference pattern, example: refs/heads/*.
+	// The patterns starting with ^ are regular expressions, e.g. ^refs/heads/release/[0-9]+,
+	// GLOBAL_CAPABILITIES sets the global capabilities of All-Projects.
 	// +optional
 	Pattern string `json:"refPattern,omitempty"`
 
+	// PermissionName is the permission, e.g. push, or the label permission, e.g. label-Verified.
 	// +optional
 	PermissionName string `json:"permissionName,omitempty"`
 
+	// PermissionLabel is the label of the label permission, it is taken from the permission name if it is empty.
 	// +optional
 	PermissionLabel string `json:"permissionLabel,omitempty"`
 
+	// GroupName is the name or UUID of the group, e.g. Developers or global:Registered-Users.
+	// The names are resolved to UUIDs.
 	// +optional
 	GroupName string `json:"groupName,omitempty"`
 
+	// Action is ALLOW (default), DENY, BLOCK, INTERACTIVE or BATCH.
+	// BLOCK can not be overridden by the child projects, e.g. BLOCK push with force blocks the force push.
 	// +optional
 	Action string `json:"action,omitempty"`
 
@@ -50,6 +79,11 @@ type Reference struct {
 	// Max is the max value of the permission range.
 	// +optional
 	Max int `json:"max,omitempty"`
+
+	// Exclusive makes the permission on the ref pattern ignore the rules inherited from the parent projects.
+	// It is the flag of the permission, so it is set for all groups of the permission on the ref pattern.
+	// +optional
+	Exclusive bool `json:"exclusive,omitempty"`
 }
 
 // GerritProjectAccessStatus defines the observed state of GerritProjectAccess.
@@ -59,6 +93,34 @@ type GerritProjectAccessStatus struct {
 
 	// +optional
 	Value string `json:"value,omitempty"`
+
+	// ReviewChangeID is the ID of the last review change of the access rights.
+	// +optional
+	ReviewChangeID string `json:"reviewChangeId,omitempty"`
+
+	// ReviewChangeNumber is the number of the last review change of the access rights.
+	// +optional
+	ReviewChangeNumber int `json:"reviewChangeNumber,omitempty"`
+
+	// ReviewStatus is the status of the last review change: NEW, MERGED or ABANDONED.
+	// +optional
+	ReviewStatus string `json:"reviewStatus,omitempty"`
+
+	// ReviewGeneration is the generation of the resource the last review change has been created for.
+	// +optional
+	ReviewGeneration int64 `json:"reviewGeneration,omitempty"`
+
+	// Conditions contain the Drifted condition set by the drift check
+	// and the Degraded condition set by the assertions.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// PlannedActions contains the changes in Gerrit that are planned in the dry-run mode.
+	// +nullable
+	// +optional
+	PlannedActions []string `json:"plannedActions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -84,6 +146,11 @@ type GerritProjectAccessList struct {
 	Items []GerritProjectAccess `json:"items"`
 }
 
+// OwnerName returns the name of the Gerrit the resource belongs to.
+func (in *GerritProjectAccess) OwnerName() string {
+	return in.Spec.OwnerName
+}
+
 func init() {
 	SchemeBuilder.Register(&GerritProjectAccess{}, &GerritProjectAccessList{})
 }
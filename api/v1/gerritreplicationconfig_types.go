@@ -8,6 +8,7 @@ import (
 type GerritReplicationConfigSpec struct {
 	SSHUrl string `json:"ssh_url"`
 
+	// OwnerName is the name of the Gerrit CR, a Gerrit in another namespace is referenced as <namespace>/<name>.
 	// +optional
 	OwnerName string `json:"owner_name,omitempty"`
 }
@@ -22,6 +23,15 @@ type GerritReplicationConfigStatus struct {
 
 	// +optional
 	Status string `json:"status,omitempty"`
+
+	// ObservedGeneration is the generation of the resource the replication has been configured for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// PlannedActions contains the changes in Gerrit that are planned in the dry-run mode.
+	// +nullable
+	// +optional
+	PlannedActions []string `json:"plannedActions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -47,6 +57,11 @@ type GerritReplicationConfigList struct {
 	Items []GerritReplicationConfig `json:"items"`
 }
 
+// OwnerName returns the name of the Gerrit the resource belongs to.
+func (in *GerritReplicationConfig) OwnerName() string {
+	return in.Spec.OwnerName
+}
+
 func init() {
 	SchemeBuilder.Register(&GerritReplicationConfig{}, &GerritReplicationConfigList{})
 }
@@ -5,15 +5,78 @@
 package v1
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessAssertion) DeepCopyInto(out *AccessAssertion) {
+	*out = *in
+	out.AccessCheck = in.AccessCheck
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessAssertion.
+func (in *AccessAssertion) DeepCopy() *AccessAssertion {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessAssertion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessCheck) DeepCopyInto(out *AccessCheck) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessCheck.
+func (in *AccessCheck) DeepCopy() *AccessCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapMemberSource) DeepCopyInto(out *ConfigMapMemberSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapMemberSource.
+func (in *ConfigMapMemberSource) DeepCopy() *ConfigMapMemberSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapMemberSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapPluginSource) DeepCopyInto(out *ConfigMapPluginSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapPluginSource.
+func (in *ConfigMapPluginSource) DeepCopy() *ConfigMapPluginSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapPluginSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Gerrit) DeepCopyInto(out *Gerrit) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -36,12 +99,170 @@ func (in *Gerrit) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GerritGroup) DeepCopyInto(out *GerritGroup) {
+func (in *GerritAccessCheck) DeepCopyInto(out *GerritAccessCheck) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GerritAccessCheck.
+func (in *GerritAccessCheck) DeepCopy() *GerritAccessCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(GerritAccessCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GerritAccessCheck) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GerritAccessCheckList) DeepCopyInto(out *GerritAccessCheckList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GerritAccessCheck, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GerritAccessCheckList.
+func (in *GerritAccessCheckList) DeepCopy() *GerritAccessCheckList {
+	if in == nil {
+		return nil
+	}
+	out := new(GerritAccessCheckList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GerritAccessCheckList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GerritAccessCheckSpec) DeepCopyInto(out *GerritAccessCheckSpec) {
+	*out = *in
+	out.AccessCheck = in.AccessCheck
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GerritAccessCheckSpec.
+func (in *GerritAccessCheckSpec) DeepCopy() *GerritAccessCheckSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GerritAccessCheckSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GerritAccessCheckStatus) DeepCopyInto(out *GerritAccessCheckStatus) {
+	*out = *in
+	if in.DebugLogs != nil {
+		in, out := &in.DebugLogs, &out.DebugLogs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.LastTimeChecked.DeepCopyInto(&out.LastTimeChecked)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GerritAccessCheckStatus.
+func (in *GerritAccessCheckStatus) DeepCopy() *GerritAccessCheckStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GerritAccessCheckStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GerritBootstrap) DeepCopyInto(out *GerritBootstrap) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]GerritBootstrapGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AllProjectsAccess != nil {
+		in, out := &in.AllProjectsAccess, &out.AllProjectsAccess
+		*out = make([]Reference, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GerritBootstrap.
+func (in *GerritBootstrap) DeepCopy() *GerritBootstrap {
+	if in == nil {
+		return nil
+	}
+	out := new(GerritBootstrap)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GerritBootstrapGroup) DeepCopyInto(out *GerritBootstrapGroup) {
+	*out = *in
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GerritBootstrapGroup.
+func (in *GerritBootstrapGroup) DeepCopy() *GerritBootstrapGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(GerritBootstrapGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GerritCleanupPolicy) DeepCopyInto(out *GerritCleanupPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GerritCleanupPolicy.
+func (in *GerritCleanupPolicy) DeepCopy() *GerritCleanupPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(GerritCleanupPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GerritGroup) DeepCopyInto(out *GerritGroup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GerritGroup.
@@ -100,7 +321,7 @@ func (in *GerritGroupMember) DeepCopyInto(out *GerritGroupMember) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GerritGroupMember.
@@ -171,6 +392,18 @@ func (in *GerritGroupMemberSpec) DeepCopy() *GerritGroupMemberSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GerritGroupMemberStatus) DeepCopyInto(out *GerritGroupMemberStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PlannedActions != nil {
+		in, out := &in.PlannedActions, &out.PlannedActions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GerritGroupMemberStatus.
@@ -186,6 +419,11 @@ func (in *GerritGroupMemberStatus) DeepCopy() *GerritGroupMemberStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GerritGroupSpec) DeepCopyInto(out *GerritGroupSpec) {
 	*out = *in
+	if in.IncludedGroups != nil {
+		in, out := &in.IncludedGroups, &out.IncludedGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GerritGroupSpec.
@@ -201,6 +439,23 @@ func (in *GerritGroupSpec) DeepCopy() *GerritGroupSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GerritGroupStatus) DeepCopyInto(out *GerritGroupStatus) {
 	*out = *in
+	if in.IncludedGroups != nil {
+		in, out := &in.IncludedGroups, &out.IncludedGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PlannedActions != nil {
+		in, out := &in.PlannedActions, &out.PlannedActions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GerritGroupStatus.
@@ -213,6 +468,109 @@ func (in *GerritGroupStatus) DeepCopy() *GerritGroupStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GerritGroupSync) DeepCopyInto(out *GerritGroupSync) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GerritGroupSync.
+func (in *GerritGroupSync) DeepCopy() *GerritGroupSync {
+	if in == nil {
+		return nil
+	}
+	out := new(GerritGroupSync)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GerritGroupSync) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GerritGroupSyncList) DeepCopyInto(out *GerritGroupSyncList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GerritGroupSync, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GerritGroupSyncList.
+func (in *GerritGroupSyncList) DeepCopy() *GerritGroupSyncList {
+	if in == nil {
+		return nil
+	}
+	out := new(GerritGroupSyncList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GerritGroupSyncList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GerritGroupSyncSpec) DeepCopyInto(out *GerritGroupSyncSpec) {
+	*out = *in
+	in.Source.DeepCopyInto(&out.Source)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GerritGroupSyncSpec.
+func (in *GerritGroupSyncSpec) DeepCopy() *GerritGroupSyncSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GerritGroupSyncSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GerritGroupSyncStatus) DeepCopyInto(out *GerritGroupSyncStatus) {
+	*out = *in
+	in.LastTimeSynced.DeepCopyInto(&out.LastTimeSynced)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PlannedActions != nil {
+		in, out := &in.PlannedActions, &out.PlannedActions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GerritGroupSyncStatus.
+func (in *GerritGroupSyncStatus) DeepCopy() *GerritGroupSyncStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GerritGroupSyncStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GerritList) DeepCopyInto(out *GerritList) {
 	*out = *in
@@ -251,7 +609,7 @@ func (in *GerritMergeRequest) DeepCopyInto(out *GerritMergeRequest) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GerritMergeRequest.
@@ -312,6 +670,21 @@ func (in *GerritMergeRequestSpec) DeepCopyInto(out *GerritMergeRequestSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Reviewers != nil {
+		in, out := &in.Reviewers, &out.Reviewers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CC != nil {
+		in, out := &in.CC, &out.CC
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Hashtags != nil {
+		in, out := &in.Hashtags, &out.Hashtags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GerritMergeRequestSpec.
@@ -327,6 +700,26 @@ func (in *GerritMergeRequestSpec) DeepCopy() *GerritMergeRequestSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GerritMergeRequestStatus) DeepCopyInto(out *GerritMergeRequestStatus) {
 	*out = *in
+	if in.Reviewers != nil {
+		in, out := &in.Reviewers, &out.Reviewers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CC != nil {
+		in, out := &in.CC, &out.CC
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Hashtags != nil {
+		in, out := &in.Hashtags, &out.Hashtags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PlannedActions != nil {
+		in, out := &in.PlannedActions, &out.PlannedActions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GerritMergeRequestStatus.
@@ -339,6 +732,101 @@ func (in *GerritMergeRequestStatus) DeepCopy() *GerritMergeRequestStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GerritPlugin) DeepCopyInto(out *GerritPlugin) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GerritPlugin.
+func (in *GerritPlugin) DeepCopy() *GerritPlugin {
+	if in == nil {
+		return nil
+	}
+	out := new(GerritPlugin)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GerritPlugin) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GerritPluginList) DeepCopyInto(out *GerritPluginList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GerritPlugin, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GerritPluginList.
+func (in *GerritPluginList) DeepCopy() *GerritPluginList {
+	if in == nil {
+		return nil
+	}
+	out := new(GerritPluginList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GerritPluginList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GerritPluginSpec) DeepCopyInto(out *GerritPluginSpec) {
+	*out = *in
+	in.Source.DeepCopyInto(&out.Source)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GerritPluginSpec.
+func (in *GerritPluginSpec) DeepCopy() *GerritPluginSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GerritPluginSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GerritPluginStatus) DeepCopyInto(out *GerritPluginStatus) {
+	*out = *in
+	if in.PlannedActions != nil {
+		in, out := &in.PlannedActions, &out.PlannedActions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GerritPluginStatus.
+func (in *GerritPluginStatus) DeepCopy() *GerritPluginStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GerritPluginStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GerritProject) DeepCopyInto(out *GerritProject) {
 	*out = *in
@@ -372,7 +860,7 @@ func (in *GerritProjectAccess) DeepCopyInto(out *GerritProjectAccess) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GerritProjectAccess.
@@ -433,6 +921,11 @@ func (in *GerritProjectAccessSpec) DeepCopyInto(out *GerritProjectAccessSpec) {
 		*out = make([]Reference, len(*in))
 		copy(*out, *in)
 	}
+	if in.Assertions != nil {
+		in, out := &in.Assertions, &out.Assertions
+		*out = make([]AccessAssertion, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GerritProjectAccessSpec.
@@ -448,6 +941,18 @@ func (in *GerritProjectAccessSpec) DeepCopy() *GerritProjectAccessSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GerritProjectAccessStatus) DeepCopyInto(out *GerritProjectAccessStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PlannedActions != nil {
+		in, out := &in.PlannedActions, &out.PlannedActions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GerritProjectAccessStatus.
@@ -515,6 +1020,18 @@ func (in *GerritProjectStatus) DeepCopyInto(out *GerritProjectStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PlannedActions != nil {
+		in, out := &in.PlannedActions, &out.PlannedActions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GerritProjectStatus.
@@ -605,6 +1122,11 @@ func (in *GerritReplicationConfigSpec) DeepCopy() *GerritReplicationConfigSpec {
 func (in *GerritReplicationConfigStatus) DeepCopyInto(out *GerritReplicationConfigStatus) {
 	*out = *in
 	in.LastTimeUpdated.DeepCopyInto(&out.LastTimeUpdated)
+	if in.PlannedActions != nil {
+		in, out := &in.PlannedActions, &out.PlannedActions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GerritReplicationConfigStatus.
@@ -617,10 +1139,116 @@ func (in *GerritReplicationConfigStatus) DeepCopy() *GerritReplicationConfigStat
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GerritSiteConfig) DeepCopyInto(out *GerritSiteConfig) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make(map[string]map[string]string, len(*in))
+		for key, val := range *in {
+			var outVal map[string]string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make(map[string]string, len(*in))
+				for key, val := range *in {
+					(*out)[key] = val
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.ListValues != nil {
+		in, out := &in.ListValues, &out.ListValues
+		*out = make(map[string]map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal map[string][]string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make(map[string][]string, len(*in))
+				for key, val := range *in {
+					var outVal []string
+					if val == nil {
+						(*out)[key] = nil
+					} else {
+						inVal := (*in)[key]
+						in, out := &inVal, &outVal
+						*out = make([]string, len(*in))
+						copy(*out, *in)
+					}
+					(*out)[key] = outVal
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.SecureValues != nil {
+		in, out := &in.SecureValues, &out.SecureValues
+		*out = make(map[string]map[string]corev1.SecretKeySelector, len(*in))
+		for key, val := range *in {
+			var outVal map[string]corev1.SecretKeySelector
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make(map[string]corev1.SecretKeySelector, len(*in))
+				for key, val := range *in {
+					(*out)[key] = *val.DeepCopy()
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GerritSiteConfig.
+func (in *GerritSiteConfig) DeepCopy() *GerritSiteConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GerritSiteConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GerritSpec) DeepCopyInto(out *GerritSpec) {
 	*out = *in
 	out.KeycloakSpec = in.KeycloakSpec
+	in.Sync.DeepCopyInto(&out.Sync)
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(GerritSiteConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Bootstrap != nil {
+		in, out := &in.Bootstrap, &out.Bootstrap
+		*out = new(GerritBootstrap)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PrimaryPodSelector != nil {
+		in, out := &in.PrimaryPodSelector, &out.PrimaryPodSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.CleanupPolicy != nil {
+		in, out := &in.CleanupPolicy, &out.CleanupPolicy
+		*out = new(GerritCleanupPolicy)
+		**out = **in
+	}
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GerritSpec.
@@ -637,6 +1265,24 @@ func (in *GerritSpec) DeepCopy() *GerritSpec {
 func (in *GerritStatus) DeepCopyInto(out *GerritStatus) {
 	*out = *in
 	in.LastTimeUpdated.DeepCopyInto(&out.LastTimeUpdated)
+	in.LastProjectSyncTime.DeepCopyInto(&out.LastProjectSyncTime)
+	if in.PlannedActions != nil {
+		in, out := &in.PlannedActions, &out.PlannedActions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Plugins != nil {
+		in, out := &in.Plugins, &out.Plugins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GerritStatus.
@@ -649,6 +1295,53 @@ func (in *GerritStatus) DeepCopy() *GerritStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GerritSyncSpec) DeepCopyInto(out *GerritSyncSpec) {
+	*out = *in
+	if in.ImportLabels != nil {
+		in, out := &in.ImportLabels, &out.ImportLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ProjectSelector != nil {
+		in, out := &in.ProjectSelector, &out.ProjectSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GerritSyncSpec.
+func (in *GerritSyncSpec) DeepCopy() *GerritSyncSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GerritSyncSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroupMemberSource) DeepCopyInto(out *GroupMemberSource) {
+	*out = *in
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(ConfigMapMemberSource)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupMemberSource.
+func (in *GroupMemberSource) DeepCopy() *GroupMemberSource {
+	if in == nil {
+		return nil
+	}
+	out := new(GroupMemberSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeycloakSpec) DeepCopyInto(out *KeycloakSpec) {
 	*out = *in
@@ -664,6 +1357,26 @@ func (in *KeycloakSpec) DeepCopy() *KeycloakSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginSource) DeepCopyInto(out *PluginSource) {
+	*out = *in
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(ConfigMapPluginSource)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginSource.
+func (in *PluginSource) DeepCopy() *PluginSource {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Reference) DeepCopyInto(out *Reference) {
 	*out = *in
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	gerritApi "github.com/epam/edp-gerrit-operator/v2/api/v1"
+	gerritClient "github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit"
+	"github.com/epam/edp-gerrit-operator/v2/pkg/manifest"
+)
+
+// exporter writes the manifests of the selected Gerrit projects and groups into the output directory.
+type exporter struct {
+	client gerritClient.ClientInterface
+	gerrit *gerritApi.Gerrit
+	output string
+
+	// projects and groups select the exported projects and groups, nil skips them.
+	projects *regexp.Regexp
+	groups   *regexp.Regexp
+	access   bool
+}
+
+func (e *exporter) export() (int, error) {
+	if err := os.MkdirAll(e.output, 0o755); err != nil {
+		return 0, errors.Wrap(err, "unable to create output directory")
+	}
+
+	projectsCount, err := e.exportProjects()
+	if err != nil {
+		return 0, err
+	}
+
+	groupsCount, err := e.exportGroups()
+	if err != nil {
+		return 0, err
+	}
+
+	return projectsCount + groupsCount, nil
+}
+
+func (e *exporter) exportProjects() (int, error) {
+	if e.projects == nil {
+		return 0, nil
+	}
+
+	backendProjects, err := e.client.ListProjects("CODE")
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to list projects from gerrit")
+	}
+
+	count := 0
+
+	for i := range backendProjects {
+		backendProject := &backendProjects[i]
+		if !e.projects.MatchString(backendProject.Name) {
+			continue
+		}
+
+		if err := e.write(manifest.Project(e.gerrit, backendProject)); err != nil {
+			return 0, err
+		}
+
+		count++
+
+		if !e.access {
+			continue
+		}
+
+		access, err := e.client.GetAccessRights(backendProject.Name)
+		if err != nil {
+			return 0, errors.Wrapf(err, "unable to get access rights of project %s", backendProject.Name)
+		}
+
+		if len(access.Permissions) == 0 {
+			continue
+		}
+
+		if err := e.write(manifest.ProjectAccess(e.gerrit, backendProject, access)); err != nil {
+			return 0, err
+		}
+
+		count++
+	}
+
+	return count, nil
+}
+
+func (e *exporter) exportGroups() (int, error) {
+	if e.groups == nil {
+		return 0, nil
+	}
+
+	backendGroups, err := e.client.ListGroups()
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to list groups from gerrit")
+	}
+
+	count := 0
+
+	for i := range backendGroups {
+		backendGroup := &backendGroups[i]
+		if !backendGroup.IsInternal() || !e.groups.MatchString(backendGroup.Name) {
+			continue
+		}
+
+		if err := e.write(manifest.Group(e.gerrit, backendGroup)); err != nil {
+			return 0, err
+		}
+
+		count++
+
+		members := manifest.GroupMembers(e.gerrit, backendGroup)
+		for j := range members {
+			if err := e.write(&members[j]); err != nil {
+				return 0, err
+			}
+
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// write saves the object into the <kind>-<name>.yaml file, the status and the server fields are omitted.
+func (e *exporter) write(obj client.Object) error {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return errors.Wrapf(err, "unable to convert %s", obj.GetName())
+	}
+
+	delete(content, "status")
+	unstructured.RemoveNestedField(content, "metadata", "creationTimestamp")
+
+	data, err := yaml.Marshal(content)
+	if err != nil {
+		return errors.Wrapf(err, "unable to marshal %s", obj.GetName())
+	}
+
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	fileName := filepath.Join(e.output, fmt.Sprintf("%s-%s.yaml", strings.ToLower(kind), obj.GetName()))
+
+	if err := os.WriteFile(fileName, data, 0o600); err != nil {
+		return errors.Wrapf(err, "unable to write %s", fileName)
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	gerritApi "github.com/epam/edp-gerrit-operator/v2/api/v1"
+	gerritClient "github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit"
+	gerritClientMocks "github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit/mocks"
+)
+
+func TestExporter_Export(t *testing.T) {
+	t.Parallel()
+
+	cl := gerritClientMocks.NewClientInterface(t)
+	cl.On("ListProjects", "CODE").Return([]gerritClient.Project{
+		{Name: "team/backend", Parent: "All-Projects"},
+		{Name: "team/frontend"},
+		{Name: "legacy"},
+	}, nil)
+	cl.On("GetAccessRights", "team/backend").Return(&gerritClient.ProjectAccess{
+		Permissions: []gerritClient.AccessInfo{{RefPattern: "refs/heads/*", PermissionName: "read", GroupName: "devs-uuid", Action: "ALLOW"}},
+	}, nil)
+	cl.On("GetAccessRights", "team/frontend").Return(&gerritClient.ProjectAccess{}, nil)
+	cl.On("ListGroups").Return([]gerritClient.Group{
+		{ID: "devs-uuid", Name: "devs", Members: []gerritClient.GroupMember{{AccountID: 1000, Username: "john"}}},
+		{ID: "global:Registered-Users", Name: "Registered Users"},
+	}, nil)
+
+	output := t.TempDir()
+	e := exporter{
+		client:   cl,
+		gerrit:   &gerritApi.Gerrit{ObjectMeta: metaV1.ObjectMeta{Name: "gerrit", Namespace: "ns"}},
+		output:   output,
+		projects: regexp.MustCompile("^team/"),
+		groups:   regexp.MustCompile(".*"),
+		access:   true,
+	}
+
+	count, err := e.export()
+	require.NoError(t, err)
+	assert.Equal(t, 5, count)
+
+	files, err := filepath.Glob(filepath.Join(output, "*.yaml"))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		filepath.Join(output, "gerritproject-gerrit-team-backend.yaml"),
+		filepath.Join(output, "gerritproject-gerrit-team-frontend.yaml"),
+		filepath.Join(output, "gerritprojectaccess-gerrit-team-backend-access.yaml"),
+		filepath.Join(output, "gerritgroup-gerrit-devs.yaml"),
+		filepath.Join(output, "gerritgroupmember-gerrit-devs-1000.yaml"),
+	}, files)
+
+	data, err := os.ReadFile(filepath.Join(output, "gerritproject-gerrit-team-backend.yaml"))
+	require.NoError(t, err)
+
+	var content map[string]interface{}
+	require.NoError(t, yaml.Unmarshal(data, &content))
+	assert.Equal(t, "v2.edp.epam.com/v1", content["apiVersion"])
+	assert.Equal(t, "GerritProject", content["kind"])
+	assert.NotContains(t, content, "status")
+	assert.Equal(t, map[string]interface{}{"name": "gerrit-team-backend", "namespace": "ns"}, content["metadata"])
+
+	var prj gerritApi.GerritProject
+	require.NoError(t, yaml.Unmarshal(data, &prj))
+	assert.Equal(t, "All-Projects", prj.Spec.Parent)
+	assert.Equal(t, "gerrit", prj.Spec.OwnerName)
+}
+
+func TestExporter_ExportSkipped(t *testing.T) {
+	t.Parallel()
+
+	e := exporter{client: gerritClientMocks.NewClientInterface(t), gerrit: &gerritApi.Gerrit{}, output: t.TempDir()}
+
+	count, err := e.export()
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestCompileFilter(t *testing.T) {
+	t.Parallel()
+
+	re, err := compileFilter("")
+	require.NoError(t, err)
+	assert.Nil(t, re)
+
+	_, err = compileFilter("(")
+	assert.Error(t, err)
+}
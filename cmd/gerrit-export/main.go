@@ -0,0 +1,91 @@
+// gerrit-export connects to Gerrit and writes the GerritProject, GerritProjectAccess, GerritGroup
+// and GerritGroupMember manifests of the selected projects and groups into a directory,
+// so an existing Gerrit can be brought under the operator management with GitOps.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/pkg/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gerritApi "github.com/epam/edp-gerrit-operator/v2/api/v1"
+	gerritClient "github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit"
+)
+
+const passwordEnv = "GERRIT_PASSWORD"
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	var (
+		url, user, password   string
+		gerritName, namespace string
+		projects, groups      string
+		output                string
+		access                bool
+	)
+
+	flag.StringVar(&url, "url", "", "Gerrit REST API URL, e.g. https://gerrit.example.com/a/.")
+	flag.StringVar(&user, "user", "", "Gerrit user name.")
+	flag.StringVar(&password, "password", os.Getenv(passwordEnv), "Gerrit user password, "+passwordEnv+" is used by default.")
+	flag.StringVar(&gerritName, "gerrit", "gerrit", "Name of the Gerrit custom resource that owns the exported resources.")
+	flag.StringVar(&namespace, "namespace", "", "Namespace of the exported resources.")
+	flag.StringVar(&projects, "projects", ".*", "Regular expression of the exported projects, empty to skip the projects.")
+	flag.StringVar(&groups, "groups", ".*", "Regular expression of the exported internal groups, empty to skip the groups.")
+	flag.BoolVar(&access, "access", true, "Export the access rights of the exported projects.")
+	flag.StringVar(&output, "output", ".", "Directory the manifests are written into.")
+	flag.Parse()
+
+	if url == "" || user == "" {
+		return errors.New("url and user must be set")
+	}
+
+	gr := &gerritApi.Gerrit{ObjectMeta: metaV1.ObjectMeta{Name: gerritName, Namespace: namespace}}
+
+	cl := &gerritClient.Client{}
+	if err := cl.InitNewRestClient(gr, url, user, password); err != nil {
+		return errors.Wrap(err, "unable to init gerrit client")
+	}
+
+	e := exporter{client: cl, gerrit: gr, output: output, access: access}
+
+	var err error
+	if e.projects, err = compileFilter(projects); err != nil {
+		return errors.Wrap(err, "unable to parse projects")
+	}
+
+	if e.groups, err = compileFilter(groups); err != nil {
+		return errors.Wrap(err, "unable to parse groups")
+	}
+
+	count, err := e.export()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%d manifests have been written into %s\n", count, output)
+
+	return nil
+}
+
+func compileFilter(expr string) (*regexp.Regexp, error) {
+	if expr == "" {
+		return nil, nil
+	}
+
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid regular expression %q", expr)
+	}
+
+	return re, nil
+}
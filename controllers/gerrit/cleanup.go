@@ -0,0 +1,227 @@
+package gerrit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	coreV1Api "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gerritApi "github.com/epam/edp-gerrit-operator/v2/api/v1"
+	"github.com/epam/edp-gerrit-operator/v2/controllers/helper"
+	"github.com/epam/edp-gerrit-operator/v2/pkg/service/gerrit/spec"
+	platformHelper "github.com/epam/edp-gerrit-operator/v2/pkg/service/platform/helper"
+)
+
+const (
+	finalizerName = "gerrit.gerrit.finalizer.name"
+
+	// dependentsDeletionTimeout is the time the cascade deletion waits for the finalizers of the dependent resources,
+	// then the finalizers are removed, e.g. they cannot clean up Gerrit that is not reachable.
+	dependentsDeletionTimeout = 10 * time.Minute
+
+	eventReasonFinalizersRemoved = "FinalizersRemoved"
+)
+
+// +kubebuilder:rbac:groups="",namespace=placeholder,resources=secrets,verbs=get;list;watch;update;delete
+
+// errDependentsRemain is returned while the cascade deletion waits for the dependent resources to be deleted.
+var errDependentsRemain = errors.New("dependent resources are being deleted")
+
+// dependentLists returns the lists of the resources that can belong to a Gerrit.
+func dependentLists() []client.ObjectList {
+	return []client.ObjectList{
+		&gerritApi.GerritProjectList{},
+		&gerritApi.GerritProjectAccessList{},
+		&gerritApi.GerritAccessCheckList{},
+		&gerritApi.GerritGroupList{},
+		&gerritApi.GerritGroupMemberList{},
+		&gerritApi.GerritGroupSyncList{},
+		&gerritApi.GerritReplicationConfigList{},
+		&gerritApi.GerritMergeRequestList{},
+		&gerritApi.GerritPluginList{},
+	}
+}
+
+// cleanup applies the cleanup policy of the deleted Gerrit. The dependent resources are handled first,
+// since their finalizers and the service accounts deactivation need the admin Secret.
+func (r *ReconcileGerrit) cleanup(ctx context.Context, instance *gerritApi.Gerrit) error {
+	policy := instance.GetCleanupPolicy()
+
+	if err := r.cleanupDependents(ctx, instance, policy.DependentResources); err != nil {
+		return err
+	}
+
+	if policy.DeactivateServiceAccounts {
+		if err := r.deactivateServiceAccounts(instance); err != nil {
+			return err
+		}
+	}
+
+	return r.cleanupSecrets(ctx, instance, policy.Secrets)
+}
+
+// cleanupDependents deletes or orphans the resources that belong to the Gerrit. They are listed in all the watched namespaces,
+// the resources in the other namespaces reference the Gerrit as <namespace>/<name> in the owner name.
+func (r *ReconcileGerrit) cleanupDependents(ctx context.Context, instance *gerritApi.Gerrit, policy string) error {
+	remaining := 0
+
+	for _, list := range dependentLists() {
+		if err := r.client.List(ctx, list); err != nil {
+			return fmt.Errorf("failed to list dependent resources: %w", err)
+		}
+
+		objects, err := meta.ExtractList(list)
+		if err != nil {
+			return fmt.Errorf("failed to extract dependent resources: %w", err)
+		}
+
+		for _, o := range objects {
+			obj, ok := o.(client.Object)
+			if !ok {
+				continue
+			}
+
+			controlled := metav1.IsControlledBy(obj, instance)
+			if !controlled && !isReferencedFrom(obj, instance) {
+				continue
+			}
+
+			if policy == gerritApi.CleanupDependentCascade {
+				remaining++
+
+				if err = r.deleteDependent(ctx, instance, obj); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			// the resources in the other namespaces have no owner references, they are left as they are
+			if !controlled {
+				continue
+			}
+
+			obj.SetOwnerReferences(removeOwnerReference(obj.GetOwnerReferences(), instance))
+
+			if err = r.client.Update(ctx, obj); client.IgnoreNotFound(err) != nil {
+				return fmt.Errorf("failed to orphan dependent resource %s: %w", obj.GetName(), err)
+			}
+		}
+	}
+
+	if remaining > 0 {
+		return fmt.Errorf("%w: %d resources remain", errDependentsRemain, remaining)
+	}
+
+	return nil
+}
+
+// deleteDependent deletes the dependent resource. If its finalizers have not cleaned up Gerrit
+// within dependentsDeletionTimeout after the Gerrit deletion, they are removed, so the deletion does not hang.
+func (r *ReconcileGerrit) deleteDependent(ctx context.Context, instance *gerritApi.Gerrit, obj client.Object) error {
+	if obj.GetDeletionTimestamp().IsZero() {
+		if err := r.client.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete dependent resource %s: %w", obj.GetName(), err)
+		}
+
+		return nil
+	}
+
+	if len(obj.GetFinalizers()) == 0 || time.Since(instance.GetDeletionTimestamp().Time) < dependentsDeletionTimeout {
+		return nil
+	}
+
+	obj.SetFinalizers(nil)
+
+	if err := r.client.Update(ctx, obj); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to remove finalizers of dependent resource %s: %w", obj.GetName(), err)
+	}
+
+	helper.RecordWarning(r.recorder, instance, eventReasonFinalizersRemoved,
+		fmt.Errorf("finalizers of %s/%s have been removed after %s, the resources in Gerrit may remain",
+			obj.GetNamespace(), obj.GetName(), dependentsDeletionTimeout))
+
+	return nil
+}
+
+// isReferencedFrom checks that the resource in another namespace references the Gerrit as <namespace>/<name>.
+func isReferencedFrom(obj client.Object, instance *gerritApi.Gerrit) bool {
+	owned, ok := obj.(interface{ OwnerName() string })
+	if !ok || obj.GetNamespace() == instance.Namespace {
+		return false
+	}
+
+	ns, name, found := strings.Cut(strings.ToLower(owned.OwnerName()), "/")
+
+	return found && ns == instance.Namespace && name == instance.Name
+}
+
+func (r *ReconcileGerrit) deactivateServiceAccounts(instance *gerritApi.Gerrit) error {
+	gc, err := r.service.GetRestClient(instance)
+	if err != nil {
+		return fmt.Errorf("failed to init Gerrit REST client: %w", err)
+	}
+
+	for _, username := range []string{spec.GerritDefaultCiUserUser, spec.GerritArgoUser} {
+		if err = gc.DeactivateAccount(username); err != nil {
+			return fmt.Errorf("failed to deactivate service account: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// cleanupSecrets deletes or retains the Secrets generated for the Gerrit, the other Secrets with the same labels are not changed.
+func (r *ReconcileGerrit) cleanupSecrets(ctx context.Context, instance *gerritApi.Gerrit, policy string) error {
+	var secrets coreV1Api.SecretList
+	if err := r.client.List(ctx, &secrets, client.InNamespace(instance.Namespace),
+		client.MatchingLabels(platformHelper.GenerateLabels(instance.Name))); err != nil {
+		return fmt.Errorf("failed to list Secrets: %w", err)
+	}
+
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		if !metav1.IsControlledBy(secret, instance) {
+			continue
+		}
+
+		if policy == gerritApi.CleanupSecretsRetain {
+			secret.OwnerReferences = removeOwnerReference(secret.OwnerReferences, instance)
+
+			if err := r.client.Update(ctx, secret); client.IgnoreNotFound(err) != nil {
+				return fmt.Errorf("failed to retain Secret %s: %w", secret.Name, err)
+			}
+
+			continue
+		}
+
+		if err := r.client.Delete(ctx, secret); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete Secret %s: %w", secret.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func removeOwnerReference(refs []metav1.OwnerReference, owner *gerritApi.Gerrit) []metav1.OwnerReference {
+	result := make([]metav1.OwnerReference, 0, len(refs))
+
+	for _, ref := range refs {
+		if ref.UID != owner.UID {
+			result = append(result, ref)
+		}
+	}
+
+	return result
+}
+
+// isDependentsRemain checks that the cleanup waits for the dependent resources.
+func isDependentsRemain(err error) bool {
+	return errors.Is(err, errDependentsRemain)
+}
@@ -0,0 +1,172 @@
+package gerrit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	coreV1Api "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	gerritApi "github.com/epam/edp-gerrit-operator/v2/api/v1"
+	"github.com/epam/edp-gerrit-operator/v2/controllers/helper"
+	gmock "github.com/epam/edp-gerrit-operator/v2/mock/gerrit"
+	gerritClientMocks "github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit/mocks"
+	"github.com/epam/edp-gerrit-operator/v2/pkg/service/gerrit/spec"
+)
+
+func createDeletedGerrit(policy *gerritApi.GerritCleanupPolicy) *gerritApi.Gerrit {
+	instance := createGerritByStatus(StatusReady)
+	instance.UID = "gerrit-uid"
+	instance.DeletionTimestamp = &metaV1.Time{Time: time.Now()}
+	instance.Spec.CleanupPolicy = policy
+
+	return instance
+}
+
+func createOwnedObjects(instance *gerritApi.Gerrit) []client.Object {
+	ownerMeta := func(objectName string) metaV1.ObjectMeta {
+		om := metaV1.ObjectMeta{Name: objectName, Namespace: instance.Namespace, Labels: map[string]string{"app": instance.Name}}
+		helper.SetOwnerReference(&om, metaV1.TypeMeta{Kind: "Gerrit", APIVersion: gerritApi.GroupVersion.String()}, &instance.ObjectMeta)
+
+		return om
+	}
+
+	return []client.Object{
+		&coreV1Api.Secret{ObjectMeta: ownerMeta(instance.Name + "-admin-password")},
+		&coreV1Api.Secret{ObjectMeta: metaV1.ObjectMeta{Name: "chart-secret", Namespace: instance.Namespace, Labels: map[string]string{"app": instance.Name}}},
+		&gerritApi.GerritProject{ObjectMeta: ownerMeta("project")},
+		&gerritApi.GerritGroup{ObjectMeta: ownerMeta("group")},
+	}
+}
+
+func createCleanupClient(t *testing.T, instance *gerritApi.Gerrit) client.Client {
+	t.Helper()
+
+	s := runtime.NewScheme()
+	require.NoError(t, gerritApi.AddToScheme(s))
+	require.NoError(t, coreV1Api.AddToScheme(s))
+
+	return fake.NewClientBuilder().WithScheme(s).WithStatusSubresource(&gerritApi.Gerrit{}).
+		WithObjects(append(createOwnedObjects(instance), instance)...).Build()
+}
+
+func reconcileDeleted(t *testing.T, r *ReconcileGerrit) reconcile.Result {
+	t.Helper()
+
+	res, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: name}})
+	require.NoError(t, err)
+
+	return res
+}
+
+func assertNotFound(t *testing.T, cl client.Client, obj client.Object, objectName string) {
+	t.Helper()
+
+	err := cl.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: objectName}, obj)
+	assert.True(t, k8sErrors.IsNotFound(err), objectName)
+}
+
+func TestReconcileGerrit_Reconcile_CleanupDefault(t *testing.T) {
+	instance := createDeletedGerrit(nil)
+	cl := createCleanupClient(t, instance)
+	r := &ReconcileGerrit{client: cl, service: &gmock.Interface{}}
+
+	assert.Equal(t, reconcile.Result{}, reconcileDeleted(t, r))
+
+	assertNotFound(t, cl, &gerritApi.Gerrit{}, name)
+	assertNotFound(t, cl, &coreV1Api.Secret{}, name+"-admin-password")
+
+	require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: "chart-secret"}, &coreV1Api.Secret{}))
+
+	project := &gerritApi.GerritProject{}
+	require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: "project"}, project))
+	assert.Empty(t, project.OwnerReferences)
+}
+
+func TestReconcileGerrit_Reconcile_CleanupCascade(t *testing.T) {
+	instance := createDeletedGerrit(&gerritApi.GerritCleanupPolicy{
+		DependentResources:        gerritApi.CleanupDependentCascade,
+		Secrets:                   gerritApi.CleanupSecretsRetain,
+		DeactivateServiceAccounts: true,
+	})
+	cl := createCleanupClient(t, instance)
+
+	gc := gerritClientMocks.NewClientInterface(t)
+	gc.On("DeactivateAccount", spec.GerritDefaultCiUserUser).Return(nil)
+	gc.On("DeactivateAccount", spec.GerritArgoUser).Return(nil)
+
+	serviceMock := gmock.Interface{}
+	serviceMock.On("GetRestClient", mock.AnythingOfType("*v1.Gerrit")).Return(gc, nil).Once()
+
+	r := &ReconcileGerrit{client: cl, service: &serviceMock}
+
+	// the dependent resources are deleted, the Gerrit is kept until they are gone
+	assert.Equal(t, reconcile.Result{RequeueAfter: RequeueTime10}, reconcileDeleted(t, r))
+	assertNotFound(t, cl, &gerritApi.GerritProject{}, "project")
+	assertNotFound(t, cl, &gerritApi.GerritGroup{}, "group")
+	require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: name}, &gerritApi.Gerrit{}))
+
+	assert.Equal(t, reconcile.Result{}, reconcileDeleted(t, r))
+	assertNotFound(t, cl, &gerritApi.Gerrit{}, name)
+
+	secret := &coreV1Api.Secret{}
+	require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: name + "-admin-password"}, secret))
+	assert.Empty(t, secret.OwnerReferences)
+	serviceMock.AssertExpectations(t)
+}
+
+func TestReconcileGerrit_Reconcile_CleanupCascadeReferenced(t *testing.T) {
+	instance := createDeletedGerrit(&gerritApi.GerritCleanupPolicy{DependentResources: gerritApi.CleanupDependentCascade})
+	cl := createCleanupClient(t, instance)
+
+	referenced := &gerritApi.GerritProject{
+		ObjectMeta: metaV1.ObjectMeta{Name: "tenant-project", Namespace: "team-a"},
+		Spec:       gerritApi.GerritProjectSpec{OwnerName: namespace + "/" + name},
+	}
+	other := &gerritApi.GerritProject{
+		ObjectMeta: metaV1.ObjectMeta{Name: "other-project", Namespace: "team-a"},
+		Spec:       gerritApi.GerritProjectSpec{OwnerName: "other/" + name},
+	}
+
+	require.NoError(t, cl.Create(context.Background(), referenced))
+	require.NoError(t, cl.Create(context.Background(), other))
+
+	r := &ReconcileGerrit{client: cl, service: &gmock.Interface{}}
+
+	assert.Equal(t, reconcile.Result{RequeueAfter: RequeueTime10}, reconcileDeleted(t, r))
+
+	err := cl.Get(context.Background(), client.ObjectKeyFromObject(referenced), &gerritApi.GerritProject{})
+	assert.True(t, k8sErrors.IsNotFound(err))
+	require.NoError(t, cl.Get(context.Background(), client.ObjectKeyFromObject(other), &gerritApi.GerritProject{}))
+}
+
+func TestReconcileGerrit_Reconcile_CleanupCascadeTimeout(t *testing.T) {
+	instance := createDeletedGerrit(&gerritApi.GerritCleanupPolicy{DependentResources: gerritApi.CleanupDependentCascade})
+	instance.DeletionTimestamp = &metaV1.Time{Time: time.Now().Add(-dependentsDeletionTimeout - time.Minute)}
+	cl := createCleanupClient(t, instance)
+
+	// the finalizer of the project cannot clean up Gerrit, so the project is kept after the deletion
+	project := &gerritApi.GerritProject{}
+	require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: "project"}, project))
+	project.Finalizers = []string{"gerritproject.gerrit.finalizer.name"}
+	require.NoError(t, cl.Update(context.Background(), project))
+	require.NoError(t, cl.Delete(context.Background(), project))
+
+	r := &ReconcileGerrit{client: cl, service: &gmock.Interface{}}
+
+	assert.Equal(t, reconcile.Result{RequeueAfter: RequeueTime10}, reconcileDeleted(t, r))
+	assertNotFound(t, cl, &gerritApi.GerritProject{}, "project")
+
+	assert.Equal(t, reconcile.Result{}, reconcileDeleted(t, r))
+	assertNotFound(t, cl, &gerritApi.Gerrit{}, name)
+}
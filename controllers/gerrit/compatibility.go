@@ -0,0 +1,148 @@
+package gerrit
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gerritApi "github.com/epam/edp-gerrit-operator/v2/api/v1"
+)
+
+const (
+	reasonSupported          = "Supported"
+	reasonUnsupportedVersion = "UnsupportedVersion"
+	reasonUnknownVersion     = "UnknownVersion"
+	reasonPluginNotEnabled   = "PluginNotEnabled"
+
+	deleteProjectPlugin = "delete-project"
+)
+
+var gerritVersionRe = regexp.MustCompile(`^v?(\d+)\.(\d+)`)
+
+// versionFeature is a Gerrit feature that is available since the given version.
+type versionFeature struct {
+	conditionType string
+	name          string
+	major         int
+	minor         int
+}
+
+var versionFeatures = []versionFeature{
+	{conditionType: gerritApi.ConditionSubmitRequirementsSupported, name: "submit requirements", major: 3, minor: 6},
+	{conditionType: gerritApi.ConditionAuthTokensSupported, name: "authentication tokens", major: 3, minor: 13},
+}
+
+// updateCompatibility records the Gerrit version and the enabled plugins in the status
+// and sets the conditions of the features that depend on them.
+func (r *ReconcileGerrit) updateCompatibility(ctx context.Context, instance *gerritApi.Gerrit) error {
+	gc, err := r.service.GetRestClient(instance)
+	if err != nil {
+		return fmt.Errorf("failed to get Gerrit REST client: %w", err)
+	}
+
+	version, err := gc.GetVersion()
+	if err != nil {
+		return fmt.Errorf("failed to get Gerrit version: %w", err)
+	}
+
+	plugins, err := gc.ListPlugins()
+	if err != nil {
+		return fmt.Errorf("failed to list Gerrit plugins: %w", err)
+	}
+
+	pluginIDs := make([]string, 0, len(plugins))
+	for i := range plugins {
+		pluginIDs = append(pluginIDs, plugins[i].ID)
+	}
+
+	conditions := compatibilityConditions(version, pluginIDs, instance.Generation)
+
+	current := append([]metav1.Condition(nil), instance.Status.Conditions...)
+	changed := version != instance.Status.Version || !reflect.DeepEqual(pluginIDs, instance.Status.Plugins)
+
+	for _, c := range conditions {
+		if meta.SetStatusCondition(&current, c) {
+			changed = true
+		}
+	}
+
+	if !changed {
+		return nil
+	}
+
+	return r.updateStatusWithRetry(ctx, instance, func() {
+		instance.Status.Version = version
+		instance.Status.Plugins = pluginIDs
+
+		for _, c := range conditions {
+			meta.SetStatusCondition(&instance.Status.Conditions, c)
+		}
+	})
+}
+
+// compatibilityConditions returns the feature conditions for the Gerrit version and the enabled plugins.
+func compatibilityConditions(version string, plugins []string, generation int64) []metav1.Condition {
+	conditions := make([]metav1.Condition, 0, len(versionFeatures)+1)
+	major, minor, parsed := parseGerritVersion(version)
+
+	for _, f := range versionFeatures {
+		c := metav1.Condition{
+			Type:               f.conditionType,
+			Status:             metav1.ConditionTrue,
+			Reason:             reasonSupported,
+			Message:            fmt.Sprintf("Gerrit %s supports %s", version, f.name),
+			ObservedGeneration: generation,
+		}
+
+		switch {
+		case !parsed:
+			c.Status = metav1.ConditionUnknown
+			c.Reason = reasonUnknownVersion
+			c.Message = fmt.Sprintf("unable to parse Gerrit version %q", version)
+		case major < f.major || (major == f.major && minor < f.minor):
+			c.Status = metav1.ConditionFalse
+			c.Reason = reasonUnsupportedVersion
+			c.Message = fmt.Sprintf("%s require Gerrit %d.%d or newer, the version is %s", f.name, f.major, f.minor, version)
+		}
+
+		conditions = append(conditions, c)
+	}
+
+	deleteProject := metav1.Condition{
+		Type:               gerritApi.ConditionDeleteProjectSupported,
+		Status:             metav1.ConditionFalse,
+		Reason:             reasonPluginNotEnabled,
+		Message:            fmt.Sprintf("the %s plugin is not enabled, the projects are not deleted from Gerrit", deleteProjectPlugin),
+		ObservedGeneration: generation,
+	}
+
+	for _, p := range plugins {
+		if p == deleteProjectPlugin {
+			deleteProject.Status = metav1.ConditionTrue
+			deleteProject.Reason = reasonSupported
+			deleteProject.Message = fmt.Sprintf("the %s plugin is enabled", deleteProjectPlugin)
+
+			break
+		}
+	}
+
+	return append(conditions, deleteProject)
+}
+
+// parseGerritVersion returns the major and minor numbers of a version, e.g. "3.9.1" or "3.10.0-rc1".
+func parseGerritVersion(version string) (major, minor int, ok bool) {
+	m := gerritVersionRe.FindStringSubmatch(version)
+	if m == nil {
+		return 0, 0, false
+	}
+
+	major, _ = strconv.Atoi(m[1])
+	minor, _ = strconv.Atoi(m[2])
+
+	return major, minor, true
+}
@@ -0,0 +1,110 @@
+package gerrit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gerritApi "github.com/epam/edp-gerrit-operator/v2/api/v1"
+	gmock "github.com/epam/edp-gerrit-operator/v2/mock/gerrit"
+	gerritClient "github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit"
+	gerritClientMocks "github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit/mocks"
+)
+
+func TestCompatibilityConditions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		version  string
+		plugins  []string
+		expected map[string]metaV1.ConditionStatus
+	}{
+		{
+			name:    "all features are supported",
+			version: "3.13.1",
+			plugins: []string{"delete-project", "replication"},
+			expected: map[string]metaV1.ConditionStatus{
+				gerritApi.ConditionSubmitRequirementsSupported: metaV1.ConditionTrue,
+				gerritApi.ConditionAuthTokensSupported:         metaV1.ConditionTrue,
+				gerritApi.ConditionDeleteProjectSupported:      metaV1.ConditionTrue,
+			},
+		},
+		{
+			name:    "old version without delete-project",
+			version: "3.5.6",
+			plugins: []string{"replication"},
+			expected: map[string]metaV1.ConditionStatus{
+				gerritApi.ConditionSubmitRequirementsSupported: metaV1.ConditionFalse,
+				gerritApi.ConditionAuthTokensSupported:         metaV1.ConditionFalse,
+				gerritApi.ConditionDeleteProjectSupported:      metaV1.ConditionFalse,
+			},
+		},
+		{
+			name:    "release candidate",
+			version: "3.10.0-rc1",
+			expected: map[string]metaV1.ConditionStatus{
+				gerritApi.ConditionSubmitRequirementsSupported: metaV1.ConditionTrue,
+				gerritApi.ConditionAuthTokensSupported:         metaV1.ConditionFalse,
+				gerritApi.ConditionDeleteProjectSupported:      metaV1.ConditionFalse,
+			},
+		},
+		{
+			name:    "unknown version",
+			version: "dev",
+			expected: map[string]metaV1.ConditionStatus{
+				gerritApi.ConditionSubmitRequirementsSupported: metaV1.ConditionUnknown,
+				gerritApi.ConditionAuthTokensSupported:         metaV1.ConditionUnknown,
+				gerritApi.ConditionDeleteProjectSupported:      metaV1.ConditionFalse,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			conditions := compatibilityConditions(tt.version, tt.plugins, 1)
+			require.Len(t, conditions, len(tt.expected))
+
+			for conditionType, status := range tt.expected {
+				c := meta.FindStatusCondition(conditions, conditionType)
+				require.NotNil(t, c, conditionType)
+				assert.Equal(t, status, c.Status, conditionType)
+			}
+		})
+	}
+}
+
+func TestReconcileGerrit_updateCompatibility(t *testing.T) {
+	instance := createGerritByStatus(StatusReady)
+	cl := createClient(instance)
+
+	gc := gerritClientMocks.NewClientInterface(t)
+	gc.On("GetVersion").Return("3.5.6", nil)
+	gc.On("ListPlugins").Return([]gerritClient.Plugin{{ID: "replication"}}, nil)
+
+	serviceMock := gmock.Interface{}
+	serviceMock.On("GetRestClient", instance).Return(gc, nil)
+
+	r := ReconcileGerrit{client: cl, service: &serviceMock}
+
+	require.NoError(t, r.updateCompatibility(context.Background(), instance))
+
+	updated := &gerritApi.Gerrit{}
+	require.NoError(t, cl.Get(context.Background(), client.ObjectKeyFromObject(instance), updated))
+
+	assert.Equal(t, "3.5.6", updated.Status.Version)
+	assert.Equal(t, []string{"replication"}, updated.Status.Plugins)
+	assert.True(t, updated.IsFeatureUnsupported(gerritApi.ConditionDeleteProjectSupported))
+	assert.True(t, updated.IsFeatureUnsupported(gerritApi.ConditionSubmitRequirementsSupported))
+
+	c := meta.FindStatusCondition(updated.Status.Conditions, gerritApi.ConditionSubmitRequirementsSupported)
+	require.NotNil(t, c)
+	assert.Equal(t, "submit requirements require Gerrit 3.6 or newer, the version is 3.5.6", c.Message)
+}
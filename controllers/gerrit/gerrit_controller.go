@@ -10,6 +10,7 @@ import (
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -19,6 +20,7 @@ import (
 	"github.com/epam/edp-gerrit-operator/v2/controllers/helper"
 	"github.com/epam/edp-gerrit-operator/v2/pkg/service/gerrit"
 	"github.com/epam/edp-gerrit-operator/v2/pkg/service/platform"
+	"github.com/epam/edp-gerrit-operator/v2/pkg/tracing"
 )
 
 const (
@@ -49,6 +51,9 @@ const (
 	// StatusReady = ready.
 	StatusReady = "ready"
 
+	// StatusUnavailable = unavailable, the health monitor has found the ready Gerrit unhealthy.
+	StatusUnavailable = "unavailable"
+
 	// RequeueTime10 = 10.
 	RequeueTime10 = 10 * time.Second
 
@@ -61,6 +66,10 @@ const (
 	status = "status"
 
 	updatingStatusErr = "error while updating status"
+
+	eventReasonConfigured = "Configured"
+	eventReasonReady      = "Ready"
+	eventReasonRestarted  = "Restarted"
 )
 
 func NewReconcileGerrit(k8sClient client.Client, scheme *runtime.Scheme, _ logr.Logger) (helper.Controller, error) {
@@ -76,18 +85,27 @@ func NewReconcileGerrit(k8sClient client.Client, scheme *runtime.Scheme, _ logr.
 }
 
 type ReconcileGerrit struct {
-	client  client.Client
-	service gerrit.Interface
+	client   client.Client
+	service  gerrit.Interface
+	recorder record.EventRecorder
 }
 
 func (r *ReconcileGerrit) SetupWithManager(mgr ctrl.Manager) error {
+	r.recorder = mgr.GetEventRecorderFor("gerrit")
+
 	err := ctrl.NewControllerManagedBy(mgr).
 		For(&gerritApi.Gerrit{}).
-		Complete(r)
+		Complete(tracing.NewReconciler("Gerrit", r))
 	if err != nil {
 		return fmt.Errorf("failed to setup Gerrit controller: %w", err)
 	}
 
+	if interval := HealthCheckInterval(); interval > 0 {
+		if err = mgr.Add(&healthMonitor{reconciler: r, interval: interval}); err != nil {
+			return fmt.Errorf("failed to add Gerrit health monitor: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -114,6 +132,38 @@ func (r *ReconcileGerrit) Reconcile(ctx context.Context, request reconcile.Reque
 		return reconcile.Result{}, fmt.Errorf("failed Get Gerrit CR %q: %w", request.NamespacedName, err)
 	}
 
+	if helper.IsReconcilePaused(instance) {
+		log.Info("Reconciliation of Gerrit is paused")
+		return reconcile.Result{}, nil
+	}
+
+	if helper.IsDryRun(instance) {
+		return reconcile.Result{}, r.planConfiguration(ctx, instance)
+	}
+
+	if err = helper.TryToDelete(ctx, r.client, instance, finalizerName, func() error {
+		return r.cleanup(ctx, instance)
+	}); err != nil {
+		if isDependentsRemain(err) {
+			log.Info("Waiting for the dependent resources to be deleted")
+			return reconcile.Result{RequeueAfter: RequeueTime10}, nil
+		}
+
+		return reconcile.Result{}, fmt.Errorf("failed to clean up Gerrit: %w", err)
+	}
+
+	if !instance.GetDeletionTimestamp().IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	if len(instance.Status.PlannedActions) > 0 {
+		if err = r.updateStatusWithRetry(ctx, instance, func() {
+			instance.Status.PlannedActions = nil
+		}); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
 	if instance.Status.Status == "" || instance.Status.Status == StatusFailed {
 		log.Info(fmt.Sprintf("%s/%s Gerrit installation started", instance.Namespace, instance.Name))
 
@@ -162,8 +212,14 @@ func (r *ReconcileGerrit) Reconcile(ctx context.Context, request reconcile.Reque
 	var finalRequeueAfterTimeout time.Duration
 
 	instance, dPatched, err := r.service.Configure(instance)
+	if gerrit.IsErrUnavailable(err) {
+		log.Info("Gerrit is not available for configuration yet", "reason", err.Error())
+		return reconcile.Result{RequeueAfter: RequeueTime10}, nil
+	}
+
 	if err != nil {
 		log.Error(err, "Gerrit configuration has been failed.")
+		helper.RecordWarning(r.recorder, instance, helper.EventReasonFailed, err)
 
 		if !gerrit.IsErrUserNotFound(err) {
 			return reconcile.Result{}, fmt.Errorf("failed to configure Gerrit: %w", err)
@@ -191,6 +247,29 @@ func (r *ReconcileGerrit) Reconcile(ctx context.Context, request reconcile.Reque
 		return reconcile.Result{RequeueAfter: RequeueTime10}, nil
 	}
 
+	restarted, err := r.service.ApplySiteConfig(ctx, instance)
+	if err != nil {
+		log.Error(err, "error while applying site configuration")
+		helper.RecordWarning(r.recorder, instance, helper.EventReasonFailed, err)
+
+		return reconcile.Result{RequeueAfter: RequeueTime10}, nil
+	}
+
+	if restarted {
+		configHash := instance.Status.ConfigHash
+
+		if err = r.updateStatusWithRetry(ctx, instance, func() {
+			instance.Status.ConfigHash = configHash
+		}); err != nil {
+			return reconcile.Result{}, err
+		}
+
+		log.Info("Restarting Gerrit after site configuration change")
+		helper.RecordEvent(r.recorder, instance, eventReasonRestarted, "Gerrit has been restarted with the changed site configuration")
+
+		return reconcile.Result{RequeueAfter: RequeueTime10}, nil
+	}
+
 	if instance.Status.Status == StatusConfiguring {
 		msg := fmt.Sprintf("%s/%s Gerrit configuration has finished", instance.Namespace, instance.Name)
 		log.Info(msg)
@@ -200,6 +279,8 @@ func (r *ReconcileGerrit) Reconcile(ctx context.Context, request reconcile.Reque
 			log.Error(err, updatingStatusErr, status, instance.Status.Status)
 			return reconcile.Result{RequeueAfter: RequeueTime10}, nil
 		}
+
+		helper.RecordEvent(r.recorder, instance, eventReasonConfigured, "Gerrit configuration has finished")
 	}
 
 	if instance.Status.Status == StatusConfigured {
@@ -216,9 +297,15 @@ func (r *ReconcileGerrit) Reconcile(ctx context.Context, request reconcile.Reque
 	exposedInstance, err := r.service.ExposeConfiguration(ctx, instance)
 	if err != nil {
 		log.Error(err, "error while exposing configuration", "name", instance.Name)
+		helper.RecordWarning(r.recorder, instance, helper.EventReasonFailed, err)
 		return reconcile.Result{RequeueAfter: RequeueTime10}, nil
 	}
 
+	// the version detection does not block the configuration, the features are considered supported until it succeeds
+	if err = r.updateCompatibility(ctx, exposedInstance); err != nil {
+		log.Error(err, "error while detecting Gerrit version and plugins", "name", exposedInstance.Name)
+	}
+
 	if exposedInstance.Status.Status == StatusExposeStart {
 		log.Info("Exposing configuration has finished")
 
@@ -240,9 +327,17 @@ func (r *ReconcileGerrit) Reconcile(ctx context.Context, request reconcile.Reque
 			log.Error(err, updatingStatusErr, status, exposedInstance.Status.Status)
 			return reconcile.Result{RequeueAfter: RequeueTime10}, nil
 		}
+
+		helper.RecordEvent(r.recorder, exposedInstance, eventReasonReady, "Gerrit is ready")
 	}
 
-	err = r.updateAvailableStatus(ctx, exposedInstance, true)
+	if err = r.clearHealthConditions(ctx, exposedInstance); err != nil {
+		log.Error(err, "error while clearing health conditions", "name", exposedInstance.Name)
+
+		return reconcile.Result{RequeueAfter: requeueTime30}, nil
+	}
+
+	err = r.updateAvailableStatus(ctx, exposedInstance, !exposedInstance.IsUnhealthy())
 	if err != nil {
 		msg := fmt.Sprintf("Failed update availability status for Gerrit object with name %s", exposedInstance.Name)
 		log.Info(msg)
@@ -255,6 +350,29 @@ func (r *ReconcileGerrit) Reconcile(ctx context.Context, request reconcile.Reque
 	return reconcile.Result{RequeueAfter: finalRequeueAfterTimeout}, nil
 }
 
+// planConfiguration writes the configuration steps into the status without configuring Gerrit.
+// The configuration is applied on every reconciliation, so all the steps are planned.
+func (r *ReconcileGerrit) planConfiguration(ctx context.Context, instance *gerritApi.Gerrit) error {
+	return r.updateStatusWithRetry(ctx, instance, func() {
+		instance.Status.PlannedActions = []string{
+			fmt.Sprintf("configure admin user, groups and All-Projects access of Gerrit %s", instance.Name),
+		}
+
+		if instance.Spec.Config != nil {
+			instance.Status.PlannedActions = append(instance.Status.PlannedActions,
+				fmt.Sprintf("apply site configuration of Gerrit %s, restart Gerrit if it has changed", instance.Name))
+		}
+
+		instance.Status.PlannedActions = append(instance.Status.PlannedActions,
+			fmt.Sprintf("expose configuration of Gerrit %s", instance.Name))
+
+		if instance.Spec.Bootstrap != nil {
+			instance.Status.PlannedActions = append(instance.Status.PlannedActions,
+				fmt.Sprintf("apply bootstrap groups and All-Projects access of Gerrit %s", instance.Name))
+		}
+	})
+}
+
 func (r *ReconcileGerrit) updateStatus(ctx context.Context, instance *gerritApi.Gerrit, status string) error {
 	err := r.updateStatusWithRetry(ctx, instance, func() {
 		instance.Status.Status = status
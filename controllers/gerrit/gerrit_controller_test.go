@@ -55,8 +55,9 @@ func createGerritByStatus(status string) *gerritApi.Gerrit {
 	return &gerritApi.Gerrit{
 		Spec: gerritApi.GerritSpec{},
 		ObjectMeta: metaV1.ObjectMeta{
-			Name:      name,
-			Namespace: namespace,
+			Name:       name,
+			Namespace:  namespace,
+			Finalizers: []string{finalizerName},
 		},
 		Status: gerritApi.GerritStatus{
 			Status: status,
@@ -448,6 +449,112 @@ func TestReconcileGerrit_Reconcile_ExposeConfigurationErr(t *testing.T) {
 	serviceMock.On("Configure", instance).Return(instance, false, nil)
 	serviceMock.On("IsDeploymentReady", instance).Return(true, nil)
 	serviceMock.On("ExposeConfiguration", mock.Anything, instance).Return(instance, errTest)
+	serviceMock.On("ApplySiteConfig", mock.Anything, instance).Return(false, nil)
+
+	log := commonmock.NewLogr()
+	rg := ReconcileGerrit{
+		client:  &mc,
+		service: &serviceMock,
+	}
+	req := reconcile.Request{
+		NamespacedName: nsn,
+	}
+	rs, err := rg.Reconcile(ctrl.LoggerInto(ctx, log), req)
+
+	loggerSink, ok := log.GetSink().(*commonmock.Logger)
+	assert.True(t, ok)
+
+	assert.ErrorIs(t, loggerSink.LastError(), errTest)
+	assert.NoError(t, err)
+	assert.Equal(t, reconcile.Result{RequeueAfter: 10 * time.Second}, rs)
+}
+
+func TestReconcileGerrit_Reconcile_SiteConfigRestart(t *testing.T) {
+	sw := &mocks.StatusWriter{}
+	mc := mocks.Client{}
+	ctx := context.Background()
+
+	instance := createGerritByStatus(StatusConfiguring)
+	cl := createClient(instance)
+
+	sw.On("Update").Return(nil)
+	mc.On("Get", nsn, &gerritApi.Gerrit{}).Return(cl)
+	mc.On("Status").Return(sw)
+	mc.On("Update").Return(nil)
+
+	serviceMock := gmock.Interface{}
+	serviceMock.On("IsDeploymentReady", instance).Return(true, nil)
+	serviceMock.On("Configure", instance).Return(instance, false, nil)
+	serviceMock.On("ApplySiteConfig", mock.Anything, instance).Return(true, nil).Run(func(args mock.Arguments) {
+		args.Get(1).(*gerritApi.Gerrit).Status.ConfigHash = "hash"
+	})
+
+	rg := ReconcileGerrit{
+		client:  &mc,
+		service: &serviceMock,
+	}
+	req := reconcile.Request{
+		NamespacedName: nsn,
+	}
+	rs, err := rg.Reconcile(ctrl.LoggerInto(ctx, commonmock.NewLogr()), req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, reconcile.Result{RequeueAfter: 10 * time.Second}, rs)
+	assert.Equal(t, "hash", instance.Status.ConfigHash)
+	serviceMock.AssertNotCalled(t, "ExposeConfiguration", mock.Anything, instance)
+}
+
+func TestReconcileGerrit_Reconcile_GerritUnavailable(t *testing.T) {
+	sw := &mocks.StatusWriter{}
+	mc := mocks.Client{}
+	ctx := context.Background()
+
+	instance := createGerritByStatus(StatusConfiguring)
+	cl := createClient(instance)
+
+	sw.On("Update").Return(nil)
+	mc.On("Get", nsn, &gerritApi.Gerrit{}).Return(cl)
+	mc.On("Status").Return(sw)
+	mc.On("Update").Return(nil)
+
+	serviceMock := gmock.Interface{}
+	serviceMock.On("IsDeploymentReady", instance).Return(true, nil)
+	serviceMock.On("Configure", instance).Return(instance, false, gerritService.UnavailableError("Gerrit is unavailable: status code 503"))
+
+	rg := ReconcileGerrit{
+		client:  &mc,
+		service: &serviceMock,
+	}
+	req := reconcile.Request{
+		NamespacedName: nsn,
+	}
+	rs, err := rg.Reconcile(ctrl.LoggerInto(ctx, commonmock.NewLogr()), req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, reconcile.Result{RequeueAfter: 10 * time.Second}, rs)
+	assert.Equal(t, StatusConfiguring, instance.Status.Status)
+	serviceMock.AssertNotCalled(t, "ApplySiteConfig", mock.Anything, instance)
+}
+
+func TestReconcileGerrit_Reconcile_SiteConfigErr(t *testing.T) {
+	sw := &mocks.StatusWriter{}
+	mc := mocks.Client{}
+	ctx := context.Background()
+
+	instance := createGerritByStatus(StatusConfiguring)
+	cl := createClient(instance)
+
+	errTest := errors.New("test")
+
+	sw.On("Update").Return(nil)
+	mc.On("Get", nsn, &gerritApi.Gerrit{}).Return(cl)
+	mc.On("Status").Return(sw)
+	mc.On("Update").Return(nil)
+
+	serviceMock := gmock.Interface{}
+	serviceMock.On("IsDeploymentReady", instance).Return(true, nil)
+	serviceMock.On("Configure", instance).Return(instance, false, nil)
+	serviceMock.On("ApplySiteConfig", mock.Anything, instance).Return(false, errTest)
 
 	log := commonmock.NewLogr()
 	rg := ReconcileGerrit{
@@ -486,6 +593,8 @@ func TestReconcileGerrit_Reconcile_UpdateStatusExposeStartErr(t *testing.T) {
 	serviceMock.On("IsDeploymentReady", instance).Return(true, nil)
 	serviceMock.On("Configure", instance).Return(instance, false, nil)
 	serviceMock.On("ExposeConfiguration", mock.Anything, instance).Return(instance, nil)
+	serviceMock.On("GetRestClient", instance).Return(nil, errors.New("version is not detected"))
+	serviceMock.On("ApplySiteConfig", mock.Anything, instance).Return(false, nil)
 
 	log := commonmock.NewLogr()
 	rg := ReconcileGerrit{
@@ -525,6 +634,8 @@ func TestReconcileGerrit_Reconcile_UpdateStatusExposeFinishErr(t *testing.T) {
 	serviceMock.On("IsDeploymentReady", instance).Return(true, nil)
 	serviceMock.On("Configure", instance).Return(instance, false, nil)
 	serviceMock.On("ExposeConfiguration", mock.Anything, instance).Return(instance, nil)
+	serviceMock.On("GetRestClient", instance).Return(nil, errors.New("version is not detected"))
+	serviceMock.On("ApplySiteConfig", mock.Anything, instance).Return(false, nil)
 
 	log := commonmock.NewLogr()
 	rg := ReconcileGerrit{
@@ -564,6 +675,8 @@ func TestReconcileGerrit_Reconcile_UpdateStatusIntegrationStartErr(t *testing.T)
 	serviceMock.On("IsDeploymentReady", instance).Return(true, nil)
 	serviceMock.On("Configure", instance).Return(instance, false, nil)
 	serviceMock.On("ExposeConfiguration", mock.Anything, instance).Return(instance, nil)
+	serviceMock.On("GetRestClient", instance).Return(nil, errors.New("version is not detected"))
+	serviceMock.On("ApplySiteConfig", mock.Anything, instance).Return(false, nil)
 
 	log := commonmock.NewLogr()
 	rg := ReconcileGerrit{
@@ -607,6 +720,8 @@ func TestReconcileGerrit_Reconcile_UpdateAvailableStatusErr(t *testing.T) {
 	serviceMock.On("IsDeploymentReady", instance).Return(true, nil)
 	serviceMock.On("Configure", instance).Return(instance, false, nil)
 	serviceMock.On("ExposeConfiguration", mock.Anything, instance).Return(instance, nil)
+	serviceMock.On("GetRestClient", instance).Return(nil, errors.New("version is not detected"))
+	serviceMock.On("ApplySiteConfig", mock.Anything, instance).Return(false, nil)
 
 	log := commonmock.NewLogr()
 	rg := ReconcileGerrit{
@@ -645,6 +760,8 @@ func TestReconcileGerrit_Reconcile_Valid(t *testing.T) {
 	serviceMock.On("IsDeploymentReady", instance).Return(true, nil)
 	serviceMock.On("Configure", instance).Return(instance, false, nil)
 	serviceMock.On("ExposeConfiguration", mock.Anything, instance).Return(instance, nil)
+	serviceMock.On("GetRestClient", instance).Return(nil, errors.New("version is not detected"))
+	serviceMock.On("ApplySiteConfig", mock.Anything, instance).Return(false, nil)
 
 	rg := ReconcileGerrit{
 		client:  &mc,
@@ -740,6 +857,8 @@ func TestReconcileGerrit_Reconcile_ConfigureUserNotFound(t *testing.T) {
 	serviceMock.On("Configure", instance).
 		Return(instance, false, gerritService.UserNotFoundError("user not found"))
 	serviceMock.On("ExposeConfiguration", mock.Anything, instance).Return(instance, nil)
+	serviceMock.On("GetRestClient", instance).Return(nil, errors.New("version is not detected"))
+	serviceMock.On("ApplySiteConfig", mock.Anything, instance).Return(false, nil)
 
 	rg := ReconcileGerrit{
 		client:  &mc,
@@ -768,6 +887,7 @@ func TestReconcileGerrit_Reconcile_UpdateStatusConfiguredErr(t *testing.T) {
 	serviceMock := gmock.Interface{}
 	serviceMock.On("IsDeploymentReady", instance).Return(true, nil)
 	serviceMock.On("Configure", instance).Return(instance, false, nil)
+	serviceMock.On("ApplySiteConfig", mock.Anything, instance).Return(false, nil)
 
 	log := commonmock.NewLogr()
 	rg := ReconcileGerrit{
@@ -802,6 +922,7 @@ func TestReconcileGerrit_Reconcile_UpdateStatusExposeStartFromConfiguredErr(t *t
 	serviceMock := gmock.Interface{}
 	serviceMock.On("IsDeploymentReady", instance).Return(true, nil)
 	serviceMock.On("Configure", instance).Return(instance, false, nil)
+	serviceMock.On("ApplySiteConfig", mock.Anything, instance).Return(false, nil)
 
 	log := commonmock.NewLogr()
 	rg := ReconcileGerrit{
@@ -0,0 +1,308 @@
+package gerrit
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	gerritApi "github.com/epam/edp-gerrit-operator/v2/api/v1"
+	"github.com/epam/edp-gerrit-operator/v2/controllers/helper"
+	gerritClient "github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit"
+)
+
+const (
+	healthCheckIntervalEnv     = "GERRIT_HEALTH_CHECK_INTERVAL"
+	defaultHealthCheckInterval = time.Minute
+
+	// maxReplicationQueueSize is the number of the pending replication tasks the queue is reported as unhealthy from.
+	maxReplicationQueueSize = 1000
+
+	reasonProbeSucceeded = "ProbeSucceeded"
+	reasonProbeFailed    = "ProbeFailed"
+	reasonQueueTooLong   = "QueueTooLong"
+
+	eventReasonUnhealthy = "Unhealthy"
+	eventReasonHealthy   = "Healthy"
+)
+
+// HealthCheckInterval returns the interval of the Gerrit health checks, 0 disables the checks.
+func HealthCheckInterval() time.Duration {
+	value, ok := os.LookupEnv(healthCheckIntervalEnv)
+	if !ok {
+		return defaultHealthCheckInterval
+	}
+
+	interval, err := time.ParseDuration(value)
+	if err != nil {
+		return defaultHealthCheckInterval
+	}
+
+	return interval
+}
+
+// healthMonitor periodically probes the ready Gerrit instances and reports the result in their status.
+// It runs only on the leader, so operator replicas do not probe Gerrit concurrently.
+type healthMonitor struct {
+	reconciler *ReconcileGerrit
+	interval   time.Duration
+}
+
+var _ manager.LeaderElectionRunnable = (*healthMonitor)(nil)
+
+func (*healthMonitor) NeedLeaderElection() bool {
+	return true
+}
+
+func (m *healthMonitor) Start(ctx context.Context) error {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			m.reconciler.checkHealthTick(ctx)
+		}
+	}
+}
+
+// checkHealthTick probes all Gerrit instances that have been configured concurrently.
+func (r *ReconcileGerrit) checkHealthTick(ctx context.Context) {
+	log := ctrl.LoggerFrom(ctx).WithName("health")
+
+	var gerritList gerritApi.GerritList
+	if err := r.client.List(ctx, &gerritList); err != nil {
+		log.Error(err, "failed to list Gerrit resources")
+		return
+	}
+
+	var wg sync.WaitGroup
+
+	for i := range gerritList.Items {
+		gr := &gerritList.Items[i]
+
+		if helper.IsReconcilePaused(gr) || (gr.Status.Status != StatusReady && gr.Status.Status != StatusUnavailable) {
+			continue
+		}
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if err := r.checkHealth(ctx, gr); err != nil && ctx.Err() == nil {
+				log.Error(err, "failed to check Gerrit health", "gerrit", gr.Name)
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+// checkHealth probes the Gerrit instance and updates its Available status, status and health conditions.
+func (r *ReconcileGerrit) checkHealth(ctx context.Context, instance *gerritApi.Gerrit) error {
+	var conditions []metav1.Condition
+
+	gc, err := r.service.NewAdminClient(instance)
+	if err != nil {
+		conditions = []metav1.Condition{
+			healthCondition(gerritApi.ConditionHealthy, fmt.Errorf("failed to init Gerrit client: %w", err), instance.Generation),
+		}
+	} else {
+		if ctxCl, ok := gc.(gerritClient.ContextClient); ok {
+			gc = ctxCl.WithContext(ctx)
+		}
+
+		conditions = probeHealth(gc, instance.Generation)
+	}
+
+	healthy := meta.IsStatusConditionTrue(conditions, gerritApi.ConditionHealthy)
+	newStatus := instance.Status.Status
+
+	if healthy && newStatus == StatusUnavailable {
+		newStatus = StatusReady
+	}
+
+	if !healthy && newStatus == StatusReady {
+		newStatus = StatusUnavailable
+	}
+
+	current := append([]metav1.Condition(nil), instance.Status.Conditions...)
+	changed := newStatus != instance.Status.Status || healthy != instance.Status.Available
+
+	for _, c := range conditions {
+		if meta.SetStatusCondition(&current, c) {
+			changed = true
+		}
+	}
+
+	if !changed {
+		return nil
+	}
+
+	wasHealthy := !instance.IsUnhealthy()
+
+	if err := r.updateStatusWithRetry(ctx, instance, func() {
+		instance.Status.Available = healthy
+		instance.Status.LastTimeUpdated = metav1.Now()
+		instance.Status.Status = newStatus
+
+		for _, c := range conditions {
+			meta.SetStatusCondition(&instance.Status.Conditions, c)
+		}
+	}); err != nil {
+		return err
+	}
+
+	if wasHealthy && !healthy {
+		healthyCondition := meta.FindStatusCondition(conditions, gerritApi.ConditionHealthy)
+		r.recordHealthEvent(instance, false, healthyCondition.Message)
+	}
+
+	if !wasHealthy && healthy {
+		r.recordHealthEvent(instance, true, "Gerrit is healthy")
+	}
+
+	return nil
+}
+
+// clearHealthConditions removes the conditions left by the health monitor once it is disabled,
+// so the stale Healthy=False condition does not block the resources that use the Gerrit.
+func (r *ReconcileGerrit) clearHealthConditions(ctx context.Context, instance *gerritApi.Gerrit) error {
+	if HealthCheckInterval() > 0 {
+		return nil
+	}
+
+	conditionTypes := []string{
+		gerritApi.ConditionHealthy,
+		gerritApi.ConditionRESTHealthy,
+		gerritApi.ConditionSSHHealthy,
+		gerritApi.ConditionIndexHealthy,
+		gerritApi.ConditionReplicationQueueHealthy,
+	}
+
+	stale := instance.Status.Status == StatusUnavailable
+
+	for _, t := range conditionTypes {
+		if meta.FindStatusCondition(instance.Status.Conditions, t) != nil {
+			stale = true
+		}
+	}
+
+	if !stale {
+		return nil
+	}
+
+	return r.updateStatusWithRetry(ctx, instance, func() {
+		for _, t := range conditionTypes {
+			meta.RemoveStatusCondition(&instance.Status.Conditions, t)
+		}
+
+		if instance.Status.Status == StatusUnavailable {
+			instance.Status.Status = StatusReady
+		}
+	})
+}
+
+func (r *ReconcileGerrit) recordHealthEvent(instance *gerritApi.Gerrit, healthy bool, message string) {
+	if healthy {
+		helper.RecordEvent(r.recorder, instance, eventReasonHealthy, message)
+		return
+	}
+
+	helper.RecordWarning(r.recorder, instance, eventReasonUnhealthy, fmt.Errorf("gerrit is unhealthy: %s", message))
+}
+
+// probeHealth runs the REST, SSH, change index and replication queue probes and returns their conditions.
+// The Healthy condition aggregates the REST, SSH and change index probes.
+func probeHealth(gc gerritClient.ClientInterface, generation int64) []metav1.Condition {
+	restErr := probeREST(gc)
+
+	_, sshErr := gc.SSHVersion()
+
+	indexErr := gc.CheckChangeIndex()
+
+	failed := make([]string, 0, 3)
+
+	for _, probe := range []struct {
+		name string
+		err  error
+	}{{"REST", restErr}, {"SSH", sshErr}, {"index", indexErr}} {
+		if probe.err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", probe.name, probe.err))
+		}
+	}
+
+	var healthyErr error
+	if len(failed) > 0 {
+		healthyErr = fmt.Errorf("%s", strings.Join(failed, "; "))
+	}
+
+	return []metav1.Condition{
+		healthCondition(gerritApi.ConditionHealthy, healthyErr, generation),
+		healthCondition(gerritApi.ConditionRESTHealthy, restErr, generation),
+		healthCondition(gerritApi.ConditionSSHHealthy, sshErr, generation),
+		healthCondition(gerritApi.ConditionIndexHealthy, indexErr, generation),
+		replicationQueueCondition(gc, generation),
+	}
+}
+
+func probeREST(gc gerritClient.ClientInterface) error {
+	code, err := gc.CheckCredentials()
+	if err != nil {
+		return err
+	}
+
+	if code >= 400 {
+		return fmt.Errorf("credentials check returned status code %d", code)
+	}
+
+	return nil
+}
+
+func replicationQueueCondition(gc gerritClient.ClientInterface, generation int64) metav1.Condition {
+	size, err := gc.ReplicationQueueSize()
+	if err != nil {
+		return healthCondition(gerritApi.ConditionReplicationQueueHealthy, err, generation)
+	}
+
+	c := healthCondition(gerritApi.ConditionReplicationQueueHealthy, nil, generation)
+	c.Message = fmt.Sprintf("%d replication tasks are pending", size)
+
+	if size >= maxReplicationQueueSize {
+		c.Status = metav1.ConditionFalse
+		c.Reason = reasonQueueTooLong
+		c.Message = fmt.Sprintf("%d replication tasks are pending, the limit is %d", size, maxReplicationQueueSize)
+	}
+
+	return c
+}
+
+func healthCondition(conditionType string, err error, generation int64) metav1.Condition {
+	if err != nil {
+		return metav1.Condition{
+			Type:               conditionType,
+			Status:             metav1.ConditionFalse,
+			Reason:             reasonProbeFailed,
+			Message:            err.Error(),
+			ObservedGeneration: generation,
+		}
+	}
+
+	return metav1.Condition{
+		Type:               conditionType,
+		Status:             metav1.ConditionTrue,
+		Reason:             reasonProbeSucceeded,
+		Message:            "the probe has succeeded",
+		ObservedGeneration: generation,
+	}
+}
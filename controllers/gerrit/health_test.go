@@ -0,0 +1,156 @@
+package gerrit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gerritApi "github.com/epam/edp-gerrit-operator/v2/api/v1"
+	gmock "github.com/epam/edp-gerrit-operator/v2/mock/gerrit"
+	gerritClientMocks "github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit/mocks"
+)
+
+func TestHealthCheckInterval(t *testing.T) {
+	t.Setenv(healthCheckIntervalEnv, "30s")
+	assert.Equal(t, 30*time.Second, HealthCheckInterval())
+
+	t.Setenv(healthCheckIntervalEnv, "0")
+	assert.Equal(t, time.Duration(0), HealthCheckInterval())
+
+	t.Setenv(healthCheckIntervalEnv, "wrong")
+	assert.Equal(t, defaultHealthCheckInterval, HealthCheckInterval())
+}
+
+func TestReconcileGerrit_checkHealth_Unhealthy(t *testing.T) {
+	instance := createGerritByStatus(StatusReady)
+	instance.Status.Available = true
+	cl := createClient(instance)
+
+	gc := gerritClientMocks.NewClientInterface(t)
+	gc.On("CheckCredentials").Return(http.StatusUnauthorized, nil)
+	gc.On("SSHVersion").Return("3.10.1", nil)
+	gc.On("CheckChangeIndex").Return(errors.New("index is not ready"))
+	gc.On("ReplicationQueueSize").Return(maxReplicationQueueSize, nil)
+
+	serviceMock := gmock.Interface{}
+	serviceMock.On("NewAdminClient", instance).Return(gc, nil)
+
+	r := ReconcileGerrit{client: cl, service: &serviceMock}
+
+	require.NoError(t, r.checkHealth(context.Background(), instance))
+
+	updated := &gerritApi.Gerrit{}
+	require.NoError(t, cl.Get(context.Background(), client.ObjectKeyFromObject(instance), updated))
+
+	assert.Equal(t, StatusUnavailable, updated.Status.Status)
+	assert.False(t, updated.Status.Available)
+	assert.True(t, updated.IsUnhealthy())
+	assert.True(t, meta.IsStatusConditionTrue(updated.Status.Conditions, gerritApi.ConditionSSHHealthy))
+	assert.True(t, meta.IsStatusConditionFalse(updated.Status.Conditions, gerritApi.ConditionRESTHealthy))
+	assert.True(t, meta.IsStatusConditionFalse(updated.Status.Conditions, gerritApi.ConditionIndexHealthy))
+
+	c := meta.FindStatusCondition(updated.Status.Conditions, gerritApi.ConditionHealthy)
+	require.NotNil(t, c)
+	assert.Equal(t, "REST: credentials check returned status code 401; index: index is not ready", c.Message)
+
+	c = meta.FindStatusCondition(updated.Status.Conditions, gerritApi.ConditionReplicationQueueHealthy)
+	require.NotNil(t, c)
+	assert.Equal(t, reasonQueueTooLong, c.Reason)
+}
+
+func TestReconcileGerrit_checkHealth_Recovered(t *testing.T) {
+	instance := createGerritByStatus(StatusUnavailable)
+	instance.Status.Conditions = []metaV1.Condition{
+		{Type: gerritApi.ConditionHealthy, Status: metaV1.ConditionFalse, Reason: reasonProbeFailed},
+	}
+	cl := createClient(instance)
+
+	gc := gerritClientMocks.NewClientInterface(t)
+	gc.On("CheckCredentials").Return(http.StatusOK, nil)
+	gc.On("SSHVersion").Return("3.10.1", nil)
+	gc.On("CheckChangeIndex").Return(nil)
+	gc.On("ReplicationQueueSize").Return(3, nil)
+
+	serviceMock := gmock.Interface{}
+	serviceMock.On("NewAdminClient", instance).Return(gc, nil)
+
+	r := ReconcileGerrit{client: cl, service: &serviceMock}
+
+	require.NoError(t, r.checkHealth(context.Background(), instance))
+
+	updated := &gerritApi.Gerrit{}
+	require.NoError(t, cl.Get(context.Background(), client.ObjectKeyFromObject(instance), updated))
+
+	assert.Equal(t, StatusReady, updated.Status.Status)
+	assert.True(t, updated.Status.Available)
+	assert.False(t, updated.IsUnhealthy())
+	assert.True(t, meta.IsStatusConditionTrue(updated.Status.Conditions, gerritApi.ConditionReplicationQueueHealthy))
+}
+
+func TestReconcileGerrit_checkHealthTick_ClientErr(t *testing.T) {
+	instance := createGerritByStatus(StatusReady)
+	instance.Status.Available = true
+	skipped := createGerritByStatus(StatusConfiguring)
+	skipped.Name = "configuring"
+
+	s := runtime.NewScheme()
+	require.NoError(t, gerritApi.AddToScheme(s))
+
+	cl := fake.NewClientBuilder().WithStatusSubresource(&gerritApi.Gerrit{}).WithObjects(instance, skipped).WithScheme(s).Build()
+
+	serviceMock := gmock.Interface{}
+	serviceMock.On("NewAdminClient", mock.MatchedBy(func(gr *gerritApi.Gerrit) bool {
+		return gr.Name == instance.Name
+	})).Return(nil, errors.New("ssh is not available"))
+
+	r := ReconcileGerrit{client: cl, service: &serviceMock}
+
+	r.checkHealthTick(context.Background())
+
+	updated := &gerritApi.Gerrit{}
+	require.NoError(t, cl.Get(context.Background(), client.ObjectKeyFromObject(instance), updated))
+
+	assert.Equal(t, StatusUnavailable, updated.Status.Status)
+	assert.False(t, updated.Status.Available)
+	assert.True(t, updated.IsUnhealthy())
+
+	require.NoError(t, cl.Get(context.Background(), client.ObjectKeyFromObject(skipped), updated))
+	assert.Empty(t, updated.Status.Conditions)
+	serviceMock.AssertExpectations(t)
+}
+
+func TestReconcileGerrit_clearHealthConditions(t *testing.T) {
+	instance := createGerritByStatus(StatusUnavailable)
+	instance.Status.Conditions = []metaV1.Condition{
+		{Type: gerritApi.ConditionHealthy, Status: metaV1.ConditionFalse, Reason: reasonProbeFailed},
+		{Type: gerritApi.ConditionSSHHealthy, Status: metaV1.ConditionFalse, Reason: reasonProbeFailed},
+	}
+	cl := createClient(instance)
+
+	r := ReconcileGerrit{client: cl}
+
+	t.Setenv(healthCheckIntervalEnv, "1m")
+	require.NoError(t, r.clearHealthConditions(context.Background(), instance))
+	assert.True(t, instance.IsUnhealthy())
+
+	t.Setenv(healthCheckIntervalEnv, "0")
+	require.NoError(t, r.clearHealthConditions(context.Background(), instance))
+
+	updated := &gerritApi.Gerrit{}
+	require.NoError(t, cl.Get(context.Background(), client.ObjectKeyFromObject(instance), updated))
+
+	assert.Equal(t, StatusReady, updated.Status.Status)
+	assert.False(t, updated.IsUnhealthy())
+	assert.Empty(t, updated.Status.Conditions)
+}
@@ -0,0 +1,149 @@
+package gerritaccesscheck
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	gerritApi "github.com/epam/edp-gerrit-operator/v2/api/v1"
+	"github.com/epam/edp-gerrit-operator/v2/controllers/helper"
+	"github.com/epam/edp-gerrit-operator/v2/pkg/service/gerrit"
+	"github.com/epam/edp-gerrit-operator/v2/pkg/service/platform"
+	"github.com/epam/edp-gerrit-operator/v2/pkg/tracing"
+)
+
+const requeueTime = 10 * time.Second
+
+// Reconcile checks the effective permission of an account with the check.access endpoint of Gerrit.
+// Nothing is changed in Gerrit, so the resource has no finalizer.
+type Reconcile struct {
+	client   client.Client
+	service  gerrit.Interface
+	log      logr.Logger
+	recorder record.EventRecorder
+}
+
+func NewReconcile(k8sClient client.Client, scheme *runtime.Scheme, log logr.Logger) (helper.Controller, error) {
+	ps, err := platform.NewService(helper.GetPlatformTypeEnv(), scheme)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create platform service")
+	}
+
+	return &Reconcile{
+		client:  k8sClient,
+		service: gerrit.NewComponentService(ps, k8sClient, scheme),
+		log:     log.WithName("gerrit"),
+	}, nil
+}
+
+func (r *Reconcile) SetupWithManager(mgr ctrl.Manager) error {
+	pred := predicate.Funcs{
+		UpdateFunc: isSpecUpdated,
+	}
+
+	r.recorder = mgr.GetEventRecorderFor("gerrit-access-check")
+
+	err := ctrl.NewControllerManagedBy(mgr).
+		For(&gerritApi.GerritAccessCheck{}, builder.WithPredicates(pred)).
+		Complete(tracing.NewReconciler("GerritAccessCheck", r))
+	if err != nil {
+		return fmt.Errorf("failed to setup GerritAccessCheck controller: %w", err)
+	}
+
+	return nil
+}
+
+func isSpecUpdated(e event.UpdateEvent) bool {
+	oo, ok := e.ObjectOld.(*gerritApi.GerritAccessCheck)
+	if !ok {
+		return false
+	}
+
+	no, ok := e.ObjectNew.(*gerritApi.GerritAccessCheck)
+	if !ok {
+		return false
+	}
+
+	return !reflect.DeepEqual(oo.Spec, no.Spec) || helper.IsReconcileModeUpdated(e)
+}
+
+// +kubebuilder:rbac:groups=v2.edp.epam.com,namespace=placeholder,resources=gerritaccesschecks,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=v2.edp.epam.com,namespace=placeholder,resources=gerritaccesschecks/status,verbs=get;update;patch
+
+func (r *Reconcile) Reconcile(ctx context.Context, request reconcile.Request) (result reconcile.Result, resError error) {
+	reqLogger := r.log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger.V(2).Info("Reconciling GerritAccessCheck has been started")
+
+	var instance gerritApi.GerritAccessCheck
+	if err := r.client.Get(context.TODO(), request.NamespacedName, &instance); err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return
+		}
+
+		return reconcile.Result{}, errors.Wrap(err, "unable to get GerritAccessCheck instance")
+	}
+
+	tracing.SetAttributes(ctx, tracing.GerritInstance.String(instance.Spec.OwnerName),
+		tracing.GerritProject.String(instance.Spec.ProjectName))
+
+	if helper.IsReconcilePaused(&instance) {
+		reqLogger.Info("Reconciliation of GerritAccessCheck is paused")
+		return
+	}
+
+	defer func() {
+		if err := r.client.Status().Update(context.Background(), &instance); err != nil {
+			reqLogger.Error(err, "unable to update instance status")
+		}
+	}()
+
+	if err := r.tryToReconcile(ctx, &instance); err != nil {
+		reqLogger.Error(err, "unable to reconcile GerritAccessCheck")
+		instance.Status.Value = err.Error()
+		helper.RecordWarning(r.recorder, &instance, helper.EventReasonFailed, err)
+
+		return reconcile.Result{RequeueAfter: requeueTime}, nil
+	}
+
+	instance.Status.Value = helper.StatusOK
+
+	return reconcile.Result{RequeueAfter: helper.DriftCheckInterval()}, nil
+}
+
+func (r *Reconcile) tryToReconcile(ctx context.Context, instance *gerritApi.GerritAccessCheck) error {
+	cl, err := helper.GetGerritClient(ctx, r.client, instance, instance.Spec.OwnerName, r.service)
+	if err != nil {
+		return errors.Wrap(err, "unable to init gerrit client")
+	}
+
+	res, info, err := helper.CheckAccess(cl, instance.Spec.ProjectName, &instance.Spec.AccessCheck)
+	if err != nil {
+		return err
+	}
+
+	if res != instance.Status.Result {
+		helper.RecordEvent(r.recorder, instance, res, "Access %s in project %s is %s",
+			helper.DescribeAccessCheck(&instance.Spec.AccessCheck), instance.Spec.ProjectName, res)
+	}
+
+	instance.Status.Result = res
+	instance.Status.Message = info.Message
+	instance.Status.DebugLogs = info.DebugLogs
+	instance.Status.LastTimeChecked = metaV1.Now()
+
+	return nil
+}
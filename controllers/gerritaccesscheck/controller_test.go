@@ -0,0 +1,150 @@
+package gerritaccesscheck
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilRuntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	commonmock "github.com/epam/edp-common/pkg/mock"
+
+	gerritApi "github.com/epam/edp-gerrit-operator/v2/api/v1"
+	"github.com/epam/edp-gerrit-operator/v2/controllers/helper"
+	gmock "github.com/epam/edp-gerrit-operator/v2/mock/gerrit"
+	gerritClient "github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit"
+	gerritClientMocks "github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit/mocks"
+)
+
+func newCheck() *gerritApi.GerritAccessCheck {
+	return &gerritApi.GerritAccessCheck{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      "check1",
+			Namespace: "ns1",
+		},
+		Spec: gerritApi.GerritAccessCheckSpec{
+			ProjectName: "backend",
+			AccessCheck: gerritApi.AccessCheck{
+				Account:    "developer",
+				Ref:        "refs/heads/release/1.0",
+				Permission: "push",
+			},
+		},
+	}
+}
+
+func TestReconcile_Reconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilRuntime.Must(gerritApi.AddToScheme(scheme))
+	utilRuntime.Must(coreV1.AddToScheme(scheme))
+
+	check := newCheck()
+	g := gerritApi.Gerrit{
+		ObjectMeta: metaV1.ObjectMeta{
+			Namespace: check.Namespace,
+			Name:      "ger1",
+		},
+	}
+
+	client := fake.NewClientBuilder().WithStatusSubresource(&gerritApi.GerritAccessCheck{}).WithScheme(scheme).
+		WithRuntimeObjects(check, &g).Build()
+
+	serviceMock := gmock.Interface{}
+	clientMock := gerritClientMocks.ClientInterface{}
+
+	serviceMock.On("GetRestClient", &g).Return(&clientMock, nil)
+	clientMock.On("CheckAccess", "backend", gerritClient.AccessCheck{
+		Account:    "developer",
+		Ref:        "refs/heads/release/1.0",
+		Permission: "push",
+	}).Return(&gerritClient.AccessCheckInfo{
+		Status:    403,
+		Message:   "developer (1000001) cannot perform push on refs/heads/release/1.0",
+		DebugLogs: []string{"'developer' can perform 'read' with force=false on project 'backend' for ref 'refs/heads/*'"},
+	}, nil)
+
+	recorder := record.NewFakeRecorder(1)
+
+	rcn := Reconcile{
+		client:   client,
+		log:      commonmock.NewLogr(),
+		service:  &serviceMock,
+		recorder: recorder,
+	}
+
+	nn := types.NamespacedName{Name: check.Name, Namespace: check.Namespace}
+
+	res, err := rcn.Reconcile(context.Background(), reconcile.Request{NamespacedName: nn})
+	require.NoError(t, err)
+	assert.Equal(t, helper.DriftCheckInterval(), res.RequeueAfter)
+
+	var updated gerritApi.GerritAccessCheck
+	require.NoError(t, client.Get(context.Background(), nn, &updated))
+
+	assert.Equal(t, helper.StatusOK, updated.Status.Value)
+	assert.Equal(t, gerritApi.AccessDenied, updated.Status.Result)
+	assert.Equal(t, "developer (1000001) cannot perform push on refs/heads/release/1.0", updated.Status.Message)
+	assert.Len(t, updated.Status.DebugLogs, 1)
+	assert.False(t, updated.Status.LastTimeChecked.IsZero())
+
+	require.Len(t, recorder.Events, 1)
+	assert.Equal(t, "Normal Denied Access developer push refs/heads/release/1.0 in project backend is Denied", <-recorder.Events)
+
+	serviceMock.AssertExpectations(t)
+	clientMock.AssertExpectations(t)
+}
+
+func TestReconcile_Reconcile_Failure(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilRuntime.Must(gerritApi.AddToScheme(scheme))
+	utilRuntime.Must(coreV1.AddToScheme(scheme))
+
+	check := newCheck()
+	g := gerritApi.Gerrit{
+		ObjectMeta: metaV1.ObjectMeta{
+			Namespace: check.Namespace,
+			Name:      "ger1",
+		},
+	}
+
+	client := fake.NewClientBuilder().WithStatusSubresource(&gerritApi.GerritAccessCheck{}).WithScheme(scheme).
+		WithRuntimeObjects(check, &g).Build()
+
+	serviceMock := gmock.Interface{}
+	clientMock := gerritClientMocks.ClientInterface{}
+
+	serviceMock.On("GetRestClient", &g).Return(&clientMock, nil)
+	clientMock.On("CheckAccess", "backend", gerritClient.AccessCheck{
+		Account:    "developer",
+		Ref:        "refs/heads/release/1.0",
+		Permission: "push",
+	}).Return(nil, errors.New("status: 404 Not Found, body: Not found: backend"))
+
+	rcn := Reconcile{
+		client:  client,
+		log:     commonmock.NewLogr(),
+		service: &serviceMock,
+	}
+
+	nn := types.NamespacedName{Name: check.Name, Namespace: check.Namespace}
+
+	res, err := rcn.Reconcile(context.Background(), reconcile.Request{NamespacedName: nn})
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Second, res.RequeueAfter)
+
+	var updated gerritApi.GerritAccessCheck
+	require.NoError(t, client.Get(context.Background(), nn, &updated))
+
+	assert.Contains(t, updated.Status.Value, "unable to check access of developer in project backend")
+	assert.Empty(t, updated.Status.Result)
+}
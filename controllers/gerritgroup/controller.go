@@ -4,14 +4,16 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"slices"
 	"strconv"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
-	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -24,14 +26,16 @@ import (
 	gerritClient "github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit"
 	"github.com/epam/edp-gerrit-operator/v2/pkg/service/gerrit"
 	"github.com/epam/edp-gerrit-operator/v2/pkg/service/platform"
+	"github.com/epam/edp-gerrit-operator/v2/pkg/tracing"
 )
 
 const requeueTime = 10 * time.Second
 
 type Reconcile struct {
-	client  client.Client
-	service gerrit.Interface
-	log     logr.Logger
+	client   client.Client
+	service  gerrit.Interface
+	log      logr.Logger
+	recorder record.EventRecorder
 }
 
 func NewReconcile(k8sClient client.Client, scheme *runtime.Scheme, log logr.Logger) (helper.Controller, error) {
@@ -52,9 +56,11 @@ func (r *Reconcile) SetupWithManager(mgr ctrl.Manager) error {
 		UpdateFunc: isSpecUpdated,
 	}
 
+	r.recorder = mgr.GetEventRecorderFor("gerrit-group")
+
 	err := ctrl.NewControllerManagedBy(mgr).
 		For(&gerritApi.GerritGroup{}, builder.WithPredicates(pred)).
-		Complete(r)
+		Complete(tracing.NewReconciler("GerritGroup", r))
 	if err != nil {
 		return fmt.Errorf("failed to setup GerritGroup controller: %w", err)
 	}
@@ -73,7 +79,7 @@ func isSpecUpdated(e event.UpdateEvent) bool {
 		return false
 	}
 
-	return !reflect.DeepEqual(oo.Spec, no.Spec) ||
+	return !reflect.DeepEqual(oo.Spec, no.Spec) || helper.IsReconcileModeUpdated(e) ||
 		(oo.GetDeletionTimestamp().IsZero() && !no.GetDeletionTimestamp().IsZero())
 }
 
@@ -98,77 +104,293 @@ func (r *Reconcile) Reconcile(ctx context.Context, request reconcile.Request) (r
 		return reconcile.Result{}, errors.Wrap(err, "unable to get gerrit group")
 	}
 
+	if helper.IsReconcilePaused(&instance) {
+		log.Info("Reconciliation of GerritGroup is paused")
+		return reconcile.Result{}, nil
+	}
+
 	defer func() {
 		if err := r.client.Status().Update(context.Background(), &instance); err != nil {
 			log.Error(err, "unable to update instance status")
 		}
 	}()
 
+	if helper.IsDryRun(&instance) {
+		defer setDryRunStatus(&instance, instance.Status.DeepCopy())
+	}
+
 	if err := r.tryToReconcile(ctx, &instance); err != nil {
 		log.Error(err, "unable to reconcile gerrit group")
 		instance.Status.Value = err.Error()
+		helper.RecordWarning(r.recorder, &instance, helper.EventReasonFailed, err)
 
 		return reconcile.Result{RequeueAfter: requeueTime}, nil
 	}
 
 	instance.Status.Value = helper.StatusOK
 
-	return reconcile.Result{}, nil
+	return reconcile.Result{RequeueAfter: helper.DriftCheckInterval()}, nil
 }
 
 func (r *Reconcile) tryToReconcile(ctx context.Context, instance *gerritApi.GerritGroup) error {
-	if !helper.IsInstanceOwnerSet(instance) {
-		ownerReference := helper.FindCROwnerName(instance.Spec.OwnerName)
+	cl, err := helper.GetGerritClient(ctx, r.client, instance, instance.Spec.OwnerName, r.service)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		instance.Status.PlannedActions = gerritClient.PlannedActions(cl)
+	}()
+
+	var drift helper.Drift
+
+	group := instance.Status.ID
+	if group == "" {
+		group = instance.Spec.Name
+	}
 
-		gerritInstance, err := helper.GetGerritInstance(ctx, r.client, ownerReference, instance.Namespace)
+	live, err := cl.GetGroup(group)
+
+	var groupID string
+
+	switch {
+	case gerritClient.IsErrDoesNotExist(err):
+		// the group deleted in Gerrit after the spec has been applied is a drift,
+		// so it is recreated only if the drift correction is enabled
+		drift.Compare("exists", true, false)
+
+		if !helper.NeedsApply(instance, instance.Status.Conditions, drift) {
+			helper.SetDriftCondition(r.recorder, instance, &instance.Status.Conditions, drift, false)
+
+			return nil
+		}
+
+		gr, err := cl.CreateGroup(instance.Spec.Name, instance.Spec.Description, instance.Spec.VisibleToAll)
 		if err != nil {
-			return errors.Wrap(err, "unable to get gerrit instance")
+			return errors.Wrap(err, "unable to create group")
 		}
 
-		helper.SetOwnerReference(instance, metaV1.TypeMeta{Kind: "Gerrit", APIVersion: gerritApi.GroupVersion.String()}, &gerritInstance.ObjectMeta)
+		groupID = gr.ID
+		instance.Status.ID = gr.ID
+		instance.Status.GroupID = strconv.Itoa(gr.GroupID)
+		instance.Status.Created = true
 
-		err = r.client.Update(ctx, instance)
-		if err != nil {
-			return errors.Wrap(err, "unable to update instance owner refs")
+		helper.RecordEvent(r.recorder, instance, helper.EventReasonCreated, "Group %s has been created", instance.Spec.Name)
+	case err != nil:
+		return errors.Wrap(err, "unable to get gerrit group")
+	default:
+		groupID = live.ID
+		migrateCreated(instance, live)
+
+		if drift, err = groupDrift(cl, instance, live); err != nil {
+			return err
+		}
+
+		if !helper.NeedsApply(instance, instance.Status.Conditions, drift) {
+			helper.SetDriftCondition(r.recorder, instance, &instance.Status.Conditions, drift, false)
+			setGroupID(instance, live)
+
+			return nil
 		}
+
+		// the description and the visibility are kept in sync with the spec only for the group created by the operator
+		if instance.Status.Created {
+			if err = cl.UpdateGroup(instance.Status.ID, instance.Spec.Description, instance.Spec.VisibleToAll); err != nil {
+				return errors.Wrap(err, "unable to update gerrit group")
+			}
+
+			helper.RecordEvent(r.recorder, instance, helper.EventReasonUpdated, "Group %s has been updated", instance.Spec.Name)
+		}
+	}
+
+	if err := syncOwnerGroup(cl, instance, groupID); err != nil {
+		return err
+	}
+
+	includedGroups := instance.Status.IncludedGroups
+
+	if err := syncIncludedGroups(cl, instance, groupID); err != nil {
+		return err
+	}
+
+	if !slices.Equal(includedGroups, instance.Status.IncludedGroups) {
+		helper.RecordEvent(r.recorder, instance, helper.EventReasonUpdated, "Included groups of group %s have been updated: %v",
+			instance.Spec.Name, instance.Status.IncludedGroups)
+	}
+
+	helper.SetDriftCondition(r.recorder, instance, &instance.Status.Conditions, drift, true)
+
+	if live != nil {
+		setGroupID(instance, live)
 	}
 
-	gerritInstance, err := helper.GetInstanceOwner(ctx, r.client, instance)
+	return nil
+}
+
+// migrateCreated marks the group created by the previous versions of the operator as created.
+// They recorded the UUID in the status only for the created groups and had no Drifted condition,
+// so a group with the same UUID and without the condition has not been reconciled by this version yet.
+func migrateCreated(instance *gerritApi.GerritGroup, live *gerritClient.Group) {
+	if instance.Status.Created || instance.Status.ID == "" || instance.Status.ID != live.ID {
+		return
+	}
+
+	if meta.FindStatusCondition(instance.Status.Conditions, helper.ConditionDrifted) == nil {
+		instance.Status.Created = true
+	}
+}
+
+// setGroupID records the UUID of the group in the status. The UUID of the group that is not created by the operator
+// is recorded together with the Drifted condition, so migrateCreated never takes such a group for a created one.
+func setGroupID(instance *gerritApi.GerritGroup, live *gerritClient.Group) {
+	instance.Status.ID = live.ID
+	instance.Status.GroupID = strconv.Itoa(live.GroupID)
+}
+
+// groupDrift compares the group settings that are managed by the operator with the group in Gerrit.
+// The description and the visibility are compared only for the groups created by the operator, as they are updated only for them.
+func groupDrift(cl gerritClient.ClientInterface, instance *gerritApi.GerritGroup, live *gerritClient.Group) (helper.Drift, error) {
+	var drift helper.Drift
+
+	groupID := live.ID
+
+	if instance.Status.Created {
+		setGroupID(instance, live)
+		drift.Compare("description", instance.Spec.Description, live.Description)
+		drift.Compare("visibleToAll", instance.Spec.VisibleToAll, live.Options.VisibleToAll)
+	}
+
+	if instance.Status.OwnerGroupID != "" {
+		drift.Compare("ownerGroup", instance.Status.OwnerGroupID, live.OwnerID)
+	}
+
+	if len(instance.Status.IncludedGroups) == 0 {
+		return drift, nil
+	}
+
+	current, err := cl.ListIncludedGroups(groupID)
 	if err != nil {
-		return errors.Wrap(err, "unable to get instance owner")
+		return nil, errors.Wrap(err, "unable to get included groups")
+	}
+
+	currentIDs := make(map[string]bool, len(current))
+	for i := range current {
+		currentIDs[current[i].ID] = true
+	}
+
+	for _, id := range instance.Status.IncludedGroups {
+		if !currentIDs[id] {
+			drift.Addf("includedGroups: %s is not included", id)
+		}
+	}
+
+	return drift, nil
+}
+
+// setDryRunStatus restores the status changed by the dry-run reconciliation, only the planned actions and the result are kept.
+func setDryRunStatus(instance *gerritApi.GerritGroup, status *gerritApi.GerritGroupStatus) {
+	status.PlannedActions = instance.Status.PlannedActions
+	status.Value = instance.Status.Value
+
+	if status.Value == helper.StatusOK {
+		status.Value = helper.StatusDryRun
 	}
 
-	cl, err := r.service.GetRestClient(gerritInstance)
+	instance.Status = *status
+}
+
+// resolveGroupUUID converts a group name or UUID to the group UUID.
+func resolveGroupUUID(cl gerritClient.ClientInterface, group string) (string, error) {
+	gr, err := cl.GetGroup(group)
 	if err != nil {
-		return errors.Wrap(err, "unable to get rest client")
+		return "", errors.Wrapf(err, "unable to resolve group %s", group)
 	}
 
-	gr, err := cl.CreateGroup(instance.Spec.Name, instance.Spec.Description, instance.Spec.VisibleToAll)
-	if err == nil {
-		instance.Status.ID = gr.ID
-		instance.Status.GroupID = strconv.Itoa(gr.GroupID)
+	return gr.ID, nil
+}
+
+func syncOwnerGroup(cl gerritClient.ClientInterface, instance *gerritApi.GerritGroup, groupID string) error {
+	if instance.Spec.OwnerGroup == "" {
+		instance.Status.OwnerGroupID = ""
 
-		// group is created, job done, we can exit
 		return nil
 	}
 
-	if !gerritClient.IsErrAlreadyExists(err) {
-		// unexpected error
-		return errors.Wrap(err, "unable to create group")
+	ownerID, err := resolveGroupUUID(cl, instance.Spec.OwnerGroup)
+	if err != nil {
+		return errors.Wrap(err, "unable to resolve owner group")
+	}
+
+	if err := cl.SetGroupOwner(groupID, ownerID); err != nil {
+		return errors.Wrap(err, "unable to set gerrit group owner")
 	}
 
-	// in case group already exists,
-	// we want to make sure that CRs spec is in sync with group
+	instance.Status.OwnerGroupID = ownerID
 
-	// if ID is not set yet, we can move on
-	if instance.Status.ID == "" {
+	return nil
+}
+
+// syncIncludedGroups makes the subgroups of the group match spec.includedGroups.
+// Only the subgroups that were added by the operator are removed,
+// so the subgroups that were included manually are kept untouched.
+func syncIncludedGroups(cl gerritClient.ClientInterface, instance *gerritApi.GerritGroup, groupID string) error {
+	if len(instance.Spec.IncludedGroups) == 0 && len(instance.Status.IncludedGroups) == 0 {
 		return nil
 	}
 
-	err = cl.UpdateGroup(instance.Status.ID, instance.Spec.Description, instance.Spec.VisibleToAll)
+	desired := make([]string, 0, len(instance.Spec.IncludedGroups))
+
+	for _, g := range instance.Spec.IncludedGroups {
+		id, err := resolveGroupUUID(cl, g)
+		if err != nil {
+			return errors.Wrap(err, "unable to resolve included group")
+		}
+
+		desired = append(desired, id)
+	}
+
+	current, err := cl.ListIncludedGroups(groupID)
 	if err != nil {
-		return errors.Wrap(err, "unable to update gerrit group")
+		return errors.Wrap(err, "unable to get included groups")
 	}
 
+	currentIDs := make(map[string]bool, len(current))
+	for i := range current {
+		currentIDs[current[i].ID] = true
+	}
+
+	desiredIDs := make(map[string]bool, len(desired))
+	toAdd := make([]string, 0, len(desired))
+
+	for _, id := range desired {
+		desiredIDs[id] = true
+
+		if !currentIDs[id] {
+			toAdd = append(toAdd, id)
+		}
+	}
+
+	toDelete := make([]string, 0, len(instance.Status.IncludedGroups))
+
+	for _, id := range instance.Status.IncludedGroups {
+		if !desiredIDs[id] && currentIDs[id] {
+			toDelete = append(toDelete, id)
+		}
+	}
+
+	if len(toAdd) > 0 {
+		if err := cl.AddIncludedGroups(groupID, toAdd); err != nil {
+			return errors.Wrap(err, "unable to include groups")
+		}
+	}
+
+	if len(toDelete) > 0 {
+		if err := cl.DeleteIncludedGroups(groupID, toDelete); err != nil {
+			return errors.Wrap(err, "unable to exclude groups")
+		}
+	}
+
+	instance.Status.IncludedGroups = desired
+
 	return nil
 }
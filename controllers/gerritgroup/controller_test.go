@@ -9,6 +9,7 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -22,6 +23,7 @@ import (
 	commonmock "github.com/epam/edp-common/pkg/mock"
 
 	gerritApi "github.com/epam/edp-gerrit-operator/v2/api/v1"
+	"github.com/epam/edp-gerrit-operator/v2/controllers/helper"
 	mocks "github.com/epam/edp-gerrit-operator/v2/mock"
 	gmock "github.com/epam/edp-gerrit-operator/v2/mock/gerrit"
 	"github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit"
@@ -302,6 +304,7 @@ func TestReconcileGerrit_Reconcile_CreateGroupErr(t *testing.T) {
 
 	errTest := errors.New("test")
 
+	gClientMock.On("GetGroup", instance.Spec.Name).Return(nil, gerrit.DoesNotExistError("group does not exist"))
 	gClientMock.On("CreateGroup", instance.Spec.Name, instance.Spec.Description,
 		instance.Spec.VisibleToAll).Return(group, errTest)
 	gServiceMock.On("GetRestClient", gerritInstance).Return(&gClientMock, nil)
@@ -353,6 +356,7 @@ func TestReconcileGerrit_Reconcile_CreateGroup(t *testing.T) {
 	cl := fake.NewClientBuilder().WithStatusSubresource(&gerritApi.Gerrit{}, &gerritApi.GerritGroup{}).WithObjects(instance, gerritInstance).WithScheme(s).Build()
 
 	Group := gerrit.Group{}
+	gClientMock.On("GetGroup", instance.Spec.Name).Return(nil, gerrit.DoesNotExistError("group does not exist"))
 	gClientMock.On("CreateGroup", instance.Spec.Name, instance.Spec.Description,
 		instance.Spec.VisibleToAll).Return(&Group, nil)
 	gServiceMock.On("GetRestClient", gerritInstance).Return(&gClientMock, nil)
@@ -381,7 +385,291 @@ func TestReconcileGerrit_Reconcile_CreateGroup(t *testing.T) {
 	assert.True(t, ok)
 
 	assert.NoError(t, loggerSink.LastError())
-	assert.Equal(t, reconcile.Result{}, rs)
+	assert.Equal(t, reconcile.Result{RequeueAfter: helper.DriftCheckInterval()}, rs)
+}
+
+func TestReconcileGerrit_Reconcile_OwnerAndIncludedGroups(t *testing.T) {
+	sw := &mocks.StatusWriter{}
+	mc := mocks.Client{}
+	ctx := context.Background()
+	list := gerritApi.GerritList{}
+	gServiceMock := gmock.Interface{}
+	gClientMock := gerritClientMocks.ClientInterface{}
+
+	instance := createGerritGroupByOwner(nil)
+	instance.Spec.Name = "developers"
+	instance.Spec.OwnerGroup = "team-leads"
+	instance.Spec.IncludedGroups = []string{"backend"}
+
+	gerritInstance := createGerrit()
+
+	s := runtime.NewScheme()
+	s.AddKnownTypes(appsv1.SchemeGroupVersion, &gerritApi.Gerrit{}, &gerritApi.GerritList{}, &gerritApi.GerritGroup{}, &gerritApi.GerritGroupMember{})
+	cl := fake.NewClientBuilder().WithStatusSubresource(&gerritApi.Gerrit{}, &gerritApi.GerritGroup{}).WithObjects(instance, gerritInstance).WithScheme(s).Build()
+
+	gClientMock.On("GetGroup", "developers").Return(&gerrit.Group{ID: "dev-uuid", GroupID: 5}, nil)
+	gClientMock.On("GetGroup", "team-leads").Return(&gerrit.Group{ID: "leads-uuid"}, nil)
+	gClientMock.On("GetGroup", "backend").Return(&gerrit.Group{ID: "backend-uuid"}, nil)
+	gClientMock.On("SetGroupOwner", "dev-uuid", "leads-uuid").Return(nil)
+	gClientMock.On("ListIncludedGroups", "dev-uuid").Return([]gerrit.Group{{ID: "manual-uuid"}}, nil)
+	gClientMock.On("AddIncludedGroups", "dev-uuid", []string{"backend-uuid"}).Return(nil)
+	gServiceMock.On("GetRestClient", gerritInstance).Return(&gClientMock, nil)
+	mc.On("Get", nsn, &gerritApi.GerritGroup{}).Return(cl)
+	sw.On("Update").Return(nil)
+	mc.On("Get", nsn, &gerritApi.Gerrit{}).Return(cl)
+	mc.On("Status").Return(sw)
+	mc.On("Update").Return(nil)
+	mc.On("List", &list).Return(cl)
+
+	log := commonmock.NewLogr()
+	rg := Reconcile{
+		client:  &mc,
+		service: &gServiceMock,
+		log:     log,
+	}
+
+	rs, err := rg.Reconcile(ctx, reconcile.Request{NamespacedName: nsn})
+	require.NoError(t, err)
+
+	loggerSink, ok := log.GetSink().(*commonmock.Logger)
+	assert.True(t, ok)
+
+	assert.NoError(t, loggerSink.LastError())
+	assert.Equal(t, reconcile.Result{RequeueAfter: helper.DriftCheckInterval()}, rs)
+	gClientMock.AssertExpectations(t)
+}
+
+func TestReconcileGerrit_Reconcile_DeletedInGerrit(t *testing.T) {
+	instance := createGerritGroupByOwner(nil)
+	instance.Spec.Name = "developers"
+	instance.Status = gerritApi.GerritGroupStatus{
+		ID: "dev-uuid",
+		Conditions: []metav1.Condition{{
+			Type:               helper.ConditionDrifted,
+			Status:             metav1.ConditionFalse,
+			Reason:             helper.ReasonInSync,
+			LastTransitionTime: metav1.Now(),
+		}},
+	}
+
+	gerritInstance := createGerrit()
+
+	s := runtime.NewScheme()
+	require.NoError(t, gerritApi.AddToScheme(s))
+
+	cl := fake.NewClientBuilder().WithStatusSubresource(&gerritApi.GerritGroup{}).WithObjects(instance, gerritInstance).
+		WithScheme(s).Build()
+
+	gServiceMock := gmock.Interface{}
+	gClientMock := gerritClientMocks.ClientInterface{}
+
+	gServiceMock.On("GetRestClient", gerritInstance).Return(&gClientMock, nil)
+	gClientMock.On("GetGroup", "dev-uuid").Return(nil, gerrit.DoesNotExistError("group does not exist"))
+
+	rg := Reconcile{
+		client:  cl,
+		service: &gServiceMock,
+		log:     commonmock.NewLogr(),
+	}
+
+	rs, err := rg.Reconcile(context.Background(), reconcile.Request{NamespacedName: nsn})
+	require.NoError(t, err)
+	assert.Equal(t, reconcile.Result{RequeueAfter: helper.DriftCheckInterval()}, rs)
+
+	var updated gerritApi.GerritGroup
+	require.NoError(t, cl.Get(context.Background(), nsn, &updated))
+
+	require.Len(t, updated.Status.Conditions, 1)
+	assert.Equal(t, metav1.ConditionTrue, updated.Status.Conditions[0].Status)
+	assert.Equal(t, "exists: false, expected true", updated.Status.Conditions[0].Message)
+	gClientMock.AssertNotCalled(t, "CreateGroup", "developers", "", false)
+	gClientMock.AssertExpectations(t)
+}
+
+func TestReconcileGerrit_Reconcile_ExistingGroupIsNotUpdated(t *testing.T) {
+	instance := createGerritGroupByOwner(nil)
+	instance.Spec.Name = "developers"
+	instance.Spec.Description = "managed by the operator"
+	instance.Spec.VisibleToAll = true
+	instance.Annotations = map[string]string{helper.DriftCorrectionAnnotation: "true"}
+
+	gerritInstance := createGerrit()
+
+	s := runtime.NewScheme()
+	require.NoError(t, gerritApi.AddToScheme(s))
+
+	cl := fake.NewClientBuilder().WithStatusSubresource(&gerritApi.GerritGroup{}).WithObjects(instance, gerritInstance).
+		WithScheme(s).Build()
+
+	gServiceMock := gmock.Interface{}
+	gClientMock := gerritClientMocks.ClientInterface{}
+
+	// the group has been created in Gerrit before the resource
+	live := &gerrit.Group{ID: "dev-uuid", GroupID: 5, Description: "created in UI"}
+
+	gServiceMock.On("GetRestClient", gerritInstance).Return(&gClientMock, nil)
+	gClientMock.On("GetGroup", "developers").Return(live, nil).Once()
+	gClientMock.On("GetGroup", "dev-uuid").Return(live, nil).Once()
+
+	rg := Reconcile{
+		client:  cl,
+		service: &gServiceMock,
+		log:     commonmock.NewLogr(),
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err := rg.Reconcile(context.Background(), reconcile.Request{NamespacedName: nsn})
+		require.NoError(t, err)
+	}
+
+	var updated gerritApi.GerritGroup
+	require.NoError(t, cl.Get(context.Background(), nsn, &updated))
+
+	assert.Equal(t, helper.StatusOK, updated.Status.Value)
+	assert.Equal(t, "dev-uuid", updated.Status.ID)
+	assert.False(t, updated.Status.Created)
+	gClientMock.AssertNotCalled(t, "UpdateGroup", "dev-uuid", "managed by the operator", true)
+	gClientMock.AssertNotCalled(t, "CreateGroup", "developers", "managed by the operator", true)
+	gClientMock.AssertExpectations(t)
+}
+
+func TestReconcileGerrit_Reconcile_UpgradedGroupIsUpdated(t *testing.T) {
+	instance := createGerritGroupByOwner(nil)
+	instance.Spec.Name = "developers"
+	instance.Spec.Description = "changed after the upgrade"
+	// the status of the group created by the previous version of the operator
+	instance.Status = gerritApi.GerritGroupStatus{ID: "dev-uuid", GroupID: "5", Value: helper.StatusOK}
+
+	gerritInstance := createGerrit()
+
+	s := runtime.NewScheme()
+	require.NoError(t, gerritApi.AddToScheme(s))
+
+	cl := fake.NewClientBuilder().WithStatusSubresource(&gerritApi.GerritGroup{}).WithObjects(instance, gerritInstance).
+		WithScheme(s).Build()
+
+	gServiceMock := gmock.Interface{}
+	gClientMock := gerritClientMocks.ClientInterface{}
+
+	gServiceMock.On("GetRestClient", gerritInstance).Return(&gClientMock, nil)
+	gClientMock.On("GetGroup", "dev-uuid").Return(&gerrit.Group{ID: "dev-uuid", GroupID: 5, Description: "created"}, nil)
+	gClientMock.On("UpdateGroup", "dev-uuid", "changed after the upgrade", false).Return(nil)
+
+	rg := Reconcile{
+		client:  cl,
+		service: &gServiceMock,
+		log:     commonmock.NewLogr(),
+	}
+
+	_, err := rg.Reconcile(context.Background(), reconcile.Request{NamespacedName: nsn})
+	require.NoError(t, err)
+
+	var updated gerritApi.GerritGroup
+	require.NoError(t, cl.Get(context.Background(), nsn, &updated))
+
+	assert.Equal(t, helper.StatusOK, updated.Status.Value)
+	assert.True(t, updated.Status.Created)
+	gClientMock.AssertExpectations(t)
+}
+
+func TestReconcileGerrit_Reconcile_UnhealthyGerrit(t *testing.T) {
+	instance := createGerritGroupByOwner(nil)
+	instance.Spec.Name = "developers"
+
+	gerritInstance := createGerrit()
+	gerritInstance.Status.Conditions = []metav1.Condition{
+		{Type: gerritApi.ConditionHealthy, Status: metav1.ConditionFalse, Reason: "ProbeFailed", LastTransitionTime: metav1.Now()},
+	}
+
+	s := runtime.NewScheme()
+	require.NoError(t, gerritApi.AddToScheme(s))
+
+	cl := fake.NewClientBuilder().WithStatusSubresource(&gerritApi.GerritGroup{}).WithObjects(instance, gerritInstance).
+		WithScheme(s).Build()
+
+	gServiceMock := gmock.Interface{}
+
+	rg := Reconcile{
+		client:  cl,
+		service: &gServiceMock,
+		log:     commonmock.NewLogr(),
+	}
+
+	_, err := rg.Reconcile(context.Background(), reconcile.Request{NamespacedName: nsn})
+	require.NoError(t, err)
+
+	var updated gerritApi.GerritGroup
+	require.NoError(t, cl.Get(context.Background(), nsn, &updated))
+
+	assert.Contains(t, updated.Status.Value, "is unhealthy")
+	gServiceMock.AssertNotCalled(t, "GetRestClient", mock.Anything)
+}
+
+func Test_syncIncludedGroups(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		instance   *gerritApi.GerritGroup
+		prepare    func(cl *gerritClientMocks.ClientInterface)
+		wantStatus []string
+		wantErr    require.ErrorAssertionFunc
+	}{
+		{
+			name:     "nothing to sync",
+			instance: &gerritApi.GerritGroup{},
+			prepare:  func(cl *gerritClientMocks.ClientInterface) {},
+			wantErr:  require.NoError,
+		},
+		{
+			name: "removes only groups added by the operator",
+			instance: &gerritApi.GerritGroup{
+				Spec:   gerritApi.GerritGroupSpec{Name: "gr", IncludedGroups: []string{"keep"}},
+				Status: gerritApi.GerritGroupStatus{ID: "gr-uuid", IncludedGroups: []string{"keep-uuid", "old-uuid"}},
+			},
+			prepare: func(cl *gerritClientMocks.ClientInterface) {
+				cl.On("GetGroup", "keep").Return(&gerrit.Group{ID: "keep-uuid"}, nil)
+				cl.On("ListIncludedGroups", "gr-uuid").Return([]gerrit.Group{
+					{ID: "keep-uuid"}, {ID: "old-uuid"}, {ID: "manual-uuid"},
+				}, nil)
+				cl.On("DeleteIncludedGroups", "gr-uuid", []string{"old-uuid"}).Return(nil)
+			},
+			wantStatus: []string{"keep-uuid"},
+			wantErr:    require.NoError,
+		},
+		{
+			name: "included group not found",
+			instance: &gerritApi.GerritGroup{
+				Spec:   gerritApi.GerritGroupSpec{Name: "gr", IncludedGroups: []string{"missing"}},
+				Status: gerritApi.GerritGroupStatus{ID: "gr-uuid"},
+			},
+			prepare: func(cl *gerritClientMocks.ClientInterface) {
+				cl.On("GetGroup", "missing").Return(nil, gerrit.DoesNotExistError("group does not exist"))
+			},
+			wantErr: func(t require.TestingT, err error, _ ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "unable to resolve group missing")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cl := gerritClientMocks.NewClientInterface(t)
+			tt.prepare(cl)
+
+			err := syncIncludedGroups(cl, tt.instance, tt.instance.Status.ID)
+
+			tt.wantErr(t, err)
+
+			if tt.wantStatus != nil {
+				assert.Equal(t, tt.wantStatus, tt.instance.Status.IncludedGroups)
+			}
+		})
+	}
 }
 
 func Test_isSpecUpdatedFalse(t *testing.T) {
@@ -5,12 +5,16 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"strconv"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -23,17 +27,39 @@ import (
 	gerritClient "github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit"
 	"github.com/epam/edp-gerrit-operator/v2/pkg/service/gerrit"
 	"github.com/epam/edp-gerrit-operator/v2/pkg/service/platform"
+	"github.com/epam/edp-gerrit-operator/v2/pkg/tracing"
 )
 
 const (
 	finalizerName   = "gerritgroupmember.gerrit.finalizer.name"
 	syncIntervalEnv = "GERRIT_GROUP_MEMBER_SYNC_INTERVAL"
+
+	conditionAccountResolved = "AccountResolved"
+	conditionGroupResolved   = "GroupResolved"
+
+	reasonResolved         = "Resolved"
+	reasonAccountNotFound  = "AccountNotFound"
+	reasonAccountAmbiguous = "AccountAmbiguous"
+	reasonGroupNotFound    = "GroupNotFound"
 )
 
+// resolutionError means that the spec refers to a group or an account that cannot be resolved.
+// It is reported as a condition and is not retried until the spec is changed.
+type resolutionError struct {
+	conditionType string
+	reason        string
+	err           error
+}
+
+func (e *resolutionError) Error() string {
+	return e.err.Error()
+}
+
 type Reconcile struct {
-	client  client.Client
-	service gerrit.Interface
-	log     logr.Logger
+	client   client.Client
+	service  gerrit.Interface
+	log      logr.Logger
+	recorder record.EventRecorder
 }
 
 func NewReconcile(k8sClient client.Client, scheme *runtime.Scheme, log logr.Logger) (helper.Controller, error) {
@@ -54,9 +80,11 @@ func (r *Reconcile) SetupWithManager(mgr ctrl.Manager) error {
 		UpdateFunc: isSpecUpdated,
 	}
 
+	r.recorder = mgr.GetEventRecorderFor("gerrit-group-member")
+
 	err := ctrl.NewControllerManagedBy(mgr).
 		For(&gerritApi.GerritGroupMember{}, builder.WithPredicates(pred)).
-		Complete(r)
+		Complete(tracing.NewReconciler("GerritGroupMember", r))
 	if err != nil {
 		return fmt.Errorf("failed to setup GerritGroupMember controller: %w", err)
 	}
@@ -75,7 +103,7 @@ func isSpecUpdated(e event.UpdateEvent) bool {
 		return false
 	}
 
-	return !reflect.DeepEqual(oo.Spec, no.Spec) ||
+	return !reflect.DeepEqual(oo.Spec, no.Spec) || helper.IsReconcileModeUpdated(e) ||
 		(oo.GetDeletionTimestamp().IsZero() && !no.GetDeletionTimestamp().IsZero())
 }
 
@@ -96,15 +124,35 @@ func (r *Reconcile) Reconcile(ctx context.Context, request reconcile.Request) (r
 		return reconcile.Result{}, errors.Wrap(err, "unable to get GerritGroupMember instance")
 	}
 
+	if helper.IsReconcilePaused(&instance) {
+		reqLogger.Info("Reconciliation of GerritGroupMember is paused")
+		return
+	}
+
 	defer func() {
 		if err := r.client.Status().Update(context.Background(), &instance); err != nil {
 			reqLogger.Error(err, "unable to update instance status")
 		}
 	}()
 
+	if helper.IsDryRun(&instance) {
+		defer setDryRunStatus(&instance, instance.Status.DeepCopy())
+	}
+
 	if err := r.tryToReconcile(ctx, &instance); err != nil {
+		var resErr *resolutionError
+		if errors.As(err, &resErr) {
+			reqLogger.Info("Unable to resolve GerritGroupMember", "reason", resErr.reason, "error", resErr.Error())
+			instance.Status.Value = resErr.Error()
+			setCondition(&instance, resErr.conditionType, metaV1.ConditionFalse, resErr.reason, resErr.Error())
+			helper.RecordWarning(r.recorder, &instance, resErr.reason, resErr)
+
+			return reconcile.Result{}, nil
+		}
+
 		reqLogger.Error(err, "unable to reconcile GerritGroupMember")
 		instance.Status.Value = err.Error()
+		helper.RecordWarning(r.recorder, &instance, helper.EventReasonFailed, err)
 
 		expRequeueTime := helper.SetFailureCount(&instance)
 
@@ -123,7 +171,7 @@ func (r *Reconcile) Reconcile(ctx context.Context, request reconcile.Request) (r
 
 	helper.SetSuccessStatus(&instance)
 
-	return
+	return reconcile.Result{RequeueAfter: helper.DriftCheckInterval()}, nil
 }
 
 func (r *Reconcile) tryToReconcile(ctx context.Context, instance *gerritApi.GerritGroupMember) error {
@@ -132,27 +180,154 @@ func (r *Reconcile) tryToReconcile(ctx context.Context, instance *gerritApi.Gerr
 		return errors.Wrap(err, "unable to init gerrit client")
 	}
 
-	if err := cl.AddUserToGroup(instance.Spec.GroupID, instance.Spec.AccountID); err != nil {
-		return errors.Wrap(err, "unable to add user to group")
-	}
+	defer func() {
+		instance.Status.PlannedActions = gerritClient.PlannedActions(cl)
+	}()
 
+	// TryToDelete updates the instance, so it goes before the status is filled in.
 	if err := helper.TryToDelete(ctx, r.client, instance, finalizerName, r.makeDeletionFunc(cl, instance)); err != nil {
 		return errors.Wrap(err, "unable to delete CR")
 	}
 
+	if !instance.GetDeletionTimestamp().IsZero() {
+		return nil
+	}
+
+	if err := resolveMember(cl, instance); err != nil {
+		return err
+	}
+
+	drift, err := memberDrift(cl, instance)
+	if err != nil {
+		return err
+	}
+
+	applied := helper.NeedsApply(instance, instance.Status.Conditions, drift)
+
+	if applied {
+		if err := cl.AddUserToGroup(instance.Status.GroupUUID, strconv.Itoa(instance.Status.AccountID)); err != nil {
+			return errors.Wrap(err, "unable to add user to group")
+		}
+
+		if instance.Status.Value != helper.StatusOK {
+			helper.RecordEvent(r.recorder, instance, helper.EventReasonCreated, "Account %d has been added to group %s",
+				instance.Status.AccountID, instance.Status.GroupUUID)
+		}
+	}
+
+	helper.SetDriftCondition(r.recorder, instance, &instance.Status.Conditions, drift, applied)
+
 	return nil
 }
 
-func (*Reconcile) makeDeletionFunc(cl gerritClient.ClientInterface, instance *gerritApi.GerritGroupMember) func() error {
+// memberDrift checks that the resolved account is a direct member of the group in Gerrit.
+func memberDrift(cl gerritClient.ClientInterface, instance *gerritApi.GerritGroupMember) (helper.Drift, error) {
+	var drift helper.Drift
+
+	members, err := cl.ListGroupMembers(instance.Status.GroupUUID)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list group members")
+	}
+
+	for i := range members {
+		if members[i].AccountID == instance.Status.AccountID {
+			return drift, nil
+		}
+	}
+
+	drift.Addf("account %d is not a member of group %s", instance.Status.AccountID, instance.Status.GroupUUID)
+
+	return drift, nil
+}
+
+func (r *Reconcile) makeDeletionFunc(cl gerritClient.ClientInterface, instance *gerritApi.GerritGroupMember) func() error {
 	return func() error {
-		if err := cl.DeleteUserFromGroup(instance.Spec.GroupID, instance.Spec.AccountID); err != nil {
+		if instance.Status.AccountID == 0 || instance.Status.GroupUUID == "" {
+			// the resource created before the IDs were kept in the status is resolved from the spec
+			var resErr *resolutionError
+
+			err := resolveMember(cl, instance)
+			if errors.As(err, &resErr) {
+				// the member of the missing group or account has never been added
+				return nil
+			}
+
+			if err != nil {
+				return errors.Wrap(err, "unable to resolve member")
+			}
+		}
+
+		if err := cl.DeleteUserFromGroup(instance.Status.GroupUUID, strconv.Itoa(instance.Status.AccountID)); err != nil {
 			return errors.Wrap(err, "unable to delete user from group")
 		}
 
+		helper.RecordEvent(r.recorder, instance, helper.EventReasonDeleted, "Account %d has been deleted from group %s",
+			instance.Status.AccountID, instance.Status.GroupUUID)
+
 		return nil
 	}
 }
 
+// resolveMember resolves the group and the account from the spec and stores their canonical IDs in the status.
+func resolveMember(cl gerritClient.ClientInterface, instance *gerritApi.GerritGroupMember) error {
+	group, err := cl.GetGroup(instance.Spec.GroupID)
+	if err != nil {
+		if gerritClient.IsErrDoesNotExist(err) {
+			return &resolutionError{
+				conditionType: conditionGroupResolved,
+				reason:        reasonGroupNotFound,
+				err:           errors.Errorf("group %s does not exist", instance.Spec.GroupID),
+			}
+		}
+
+		return errors.Wrap(err, "unable to get group")
+	}
+
+	instance.Status.GroupUUID = group.ID
+	setCondition(instance, conditionGroupResolved, metaV1.ConditionTrue, reasonResolved,
+		fmt.Sprintf("Group resolved to %s", group.ID))
+
+	account, err := cl.ResolveAccount(instance.Spec.AccountID)
+	if err != nil {
+		switch {
+		case gerritClient.IsErrDoesNotExist(err):
+			return &resolutionError{conditionType: conditionAccountResolved, reason: reasonAccountNotFound, err: err}
+		case gerritClient.IsErrAmbiguous(err):
+			return &resolutionError{conditionType: conditionAccountResolved, reason: reasonAccountAmbiguous, err: err}
+		default:
+			return errors.Wrap(err, "unable to resolve account")
+		}
+	}
+
+	instance.Status.AccountID = account.AccountID
+	setCondition(instance, conditionAccountResolved, metaV1.ConditionTrue, reasonResolved,
+		fmt.Sprintf("Account resolved to %d", account.AccountID))
+
+	return nil
+}
+
+func setCondition(instance *gerritApi.GerritGroupMember, conditionType string, status metaV1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&instance.Status.Conditions, metaV1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: instance.Generation,
+	})
+}
+
+// setDryRunStatus restores the status changed by the dry-run reconciliation, only the planned actions and the result are kept.
+func setDryRunStatus(instance *gerritApi.GerritGroupMember, status *gerritApi.GerritGroupMemberStatus) {
+	status.PlannedActions = instance.Status.PlannedActions
+	status.Value = instance.Status.Value
+
+	if status.Value == helper.StatusOK {
+		status.Value = helper.StatusDryRun
+	}
+
+	instance.Status = *status
+}
+
 func getSyncInterval(envVarName string) (time.Duration, bool) {
 	value, ok := os.LookupEnv(envVarName)
 	if !ok {
@@ -10,9 +10,11 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	appsV1 "k8s.io/api/apps/v1"
 	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -27,6 +29,7 @@ import (
 	"github.com/epam/edp-gerrit-operator/v2/controllers/helper"
 	mocks "github.com/epam/edp-gerrit-operator/v2/mock"
 	gmock "github.com/epam/edp-gerrit-operator/v2/mock/gerrit"
+	gerritClient "github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit"
 	gerritClientMocks "github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit/mocks"
 	"github.com/epam/edp-gerrit-operator/v2/pkg/service/platform"
 )
@@ -65,8 +68,11 @@ func TestReconcile_Reconcile(t *testing.T) {
 	clientMock := gerritClientMocks.ClientInterface{}
 
 	serviceMock.On("GetRestClient", &g).Return(&clientMock, nil)
-	clientMock.On("AddUserToGroup", groupMember.Spec.GroupID, groupMember.Spec.AccountID).Return(nil)
-	clientMock.On("DeleteUserFromGroup", groupMember.Spec.GroupID, groupMember.Spec.AccountID).Return(nil)
+	clientMock.On("GetGroup", groupMember.Spec.GroupID).Return(&gerritClient.Group{ID: "uuid1"}, nil)
+	clientMock.On("ResolveAccount", groupMember.Spec.AccountID).Return(&gerritClient.Account{AccountID: 1000001}, nil)
+	clientMock.On("ListGroupMembers", "uuid1").Return([]gerritClient.GroupMember{}, nil)
+	clientMock.On("AddUserToGroup", "uuid1", "1000001").Return(nil)
+	clientMock.On("DeleteUserFromGroup", "uuid1", "1000001").Return(nil)
 
 	rcn := Reconcile{
 		client:  client,
@@ -95,6 +101,11 @@ func TestReconcile_Reconcile(t *testing.T) {
 		t.Fatal(updateInstance.Status.Value)
 	}
 
+	assert.Equal(t, 1000001, updateInstance.Status.AccountID)
+	assert.Equal(t, "uuid1", updateInstance.Status.GroupUUID)
+	assert.True(t, meta.IsStatusConditionTrue(updateInstance.Status.Conditions, conditionAccountResolved))
+	assert.True(t, meta.IsStatusConditionTrue(updateInstance.Status.Conditions, conditionGroupResolved))
+
 	// deletionTimestamp is immutable through Update; add a finalizer and issue
 	// a real Delete so the API sets the timestamp while the object persists.
 	updateInstance.Finalizers = []string{"test_fake_finalizer"}
@@ -225,7 +236,10 @@ func TestReconcile_ReconcileFailure2(t *testing.T) {
 			clientMock := gerritClientMocks.ClientInterface{}
 
 			serviceMock.On("GetRestClient", &g).Return(&clientMock, nil)
-			clientMock.On("AddUserToGroup", groupMember.Spec.GroupID, groupMember.Spec.AccountID).Return(errors.New("AddUserToGroup fatal"))
+			clientMock.On("GetGroup", groupMember.Spec.GroupID).Return(&gerritClient.Group{ID: "uuid1"}, nil)
+			clientMock.On("ResolveAccount", groupMember.Spec.AccountID).Return(&gerritClient.Account{AccountID: 1000001}, nil)
+			clientMock.On("ListGroupMembers", "uuid1").Return([]gerritClient.GroupMember{}, nil)
+			clientMock.On("AddUserToGroup", "uuid1", "1000001").Return(errors.New("AddUserToGroup fatal"))
 
 			rcn := Reconcile{
 				client:  client,
@@ -250,6 +264,102 @@ func TestReconcile_ReconcileFailure2(t *testing.T) {
 	}
 }
 
+func TestReconcile_ReconcileUnresolved(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilRuntime.Must(gerritApi.AddToScheme(scheme))
+	utilRuntime.Must(coreV1.AddToScheme(scheme))
+
+	tests := []struct {
+		name          string
+		prepare       func(cl *gerritClientMocks.ClientInterface)
+		conditionType string
+		wantReason    string
+	}{
+		{
+			name: "group does not exist",
+			prepare: func(cl *gerritClientMocks.ClientInterface) {
+				cl.On("GetGroup", "gr1").Return(nil, gerritClient.DoesNotExistError("group does not exist"))
+			},
+			conditionType: conditionGroupResolved,
+			wantReason:    reasonGroupNotFound,
+		},
+		{
+			name: "account does not exist",
+			prepare: func(cl *gerritClientMocks.ClientInterface) {
+				cl.On("GetGroup", "gr1").Return(&gerritClient.Group{ID: "uuid1"}, nil)
+				cl.On("ResolveAccount", "acc1").Return(nil, gerritClient.DoesNotExistError("account acc1 does not exist"))
+			},
+			conditionType: conditionAccountResolved,
+			wantReason:    reasonAccountNotFound,
+		},
+		{
+			name: "account is ambiguous",
+			prepare: func(cl *gerritClientMocks.ClientInterface) {
+				cl.On("GetGroup", "gr1").Return(&gerritClient.Group{ID: "uuid1"}, nil)
+				cl.On("ResolveAccount", "acc1").Return(nil, gerritClient.AmbiguousError("account acc1 matches 2 accounts"))
+			},
+			conditionType: conditionAccountResolved,
+			wantReason:    reasonAccountAmbiguous,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			groupMember := gerritApi.GerritGroupMember{
+				ObjectMeta: metaV1.ObjectMeta{
+					Name:      "mem1",
+					Namespace: "ns1",
+				},
+				Spec: gerritApi.GerritGroupMemberSpec{
+					AccountID: "acc1",
+					GroupID:   "gr1",
+				},
+			}
+
+			g := gerritApi.Gerrit{
+				ObjectMeta: metaV1.ObjectMeta{
+					Namespace: groupMember.Namespace,
+					Name:      "ger1",
+				},
+			}
+
+			client := fake.NewClientBuilder().WithStatusSubresource(&gerritApi.GerritGroupMember{}).WithScheme(scheme).WithRuntimeObjects(&groupMember, &g).Build()
+
+			serviceMock := gmock.Interface{}
+			clientMock := gerritClientMocks.ClientInterface{}
+
+			serviceMock.On("GetRestClient", &g).Return(&clientMock, nil)
+			tt.prepare(&clientMock)
+
+			rcn := Reconcile{
+				client:  client,
+				log:     commonmock.NewLogr(),
+				service: &serviceMock,
+			}
+
+			nn := types.NamespacedName{
+				Name:      groupMember.Name,
+				Namespace: groupMember.Namespace,
+			}
+
+			result, err := rcn.Reconcile(context.Background(), reconcile.Request{NamespacedName: nn})
+			require.NoError(t, err)
+			assert.Equal(t, reconcile.Result{}, result)
+
+			var updateInstance gerritApi.GerritGroupMember
+			require.NoError(t, client.Get(context.Background(), nn, &updateInstance))
+
+			cond := meta.FindStatusCondition(updateInstance.Status.Conditions, tt.conditionType)
+			require.NotNil(t, cond)
+			assert.Equal(t, metaV1.ConditionFalse, cond.Status)
+			assert.Equal(t, tt.wantReason, cond.Reason)
+			assert.Equal(t, int64(0), updateInstance.Status.FailureCount)
+
+			clientMock.AssertNotCalled(t, "AddUserToGroup", mock.Anything, mock.Anything)
+		})
+	}
+}
+
 func TestReconcile_IsSpecUpdated(t *testing.T) {
 	groupMember := gerritApi.GerritGroupMember{
 		ObjectMeta: metaV1.ObjectMeta{
@@ -333,3 +443,35 @@ func TestReconcile_UpdateErr(t *testing.T) {
 	assert.Equal(t, nil, err)
 	assert.Equal(t, reconcile.Result{RequeueAfter: requeueTime}, rs)
 }
+
+func TestReconcile_makeDeletionFunc_ResolvesMember(t *testing.T) {
+	clientMock := gerritClientMocks.ClientInterface{}
+
+	instance := &gerritApi.GerritGroupMember{
+		Spec: gerritApi.GerritGroupMemberSpec{AccountID: "john", GroupID: "devs"},
+	}
+
+	clientMock.On("GetGroup", "devs").Return(&gerritClient.Group{ID: "uuid1"}, nil)
+	clientMock.On("ResolveAccount", "john").Return(&gerritClient.Account{AccountID: 1000001}, nil)
+	clientMock.On("DeleteUserFromGroup", "uuid1", "1000001").Return(nil)
+
+	rcn := Reconcile{log: logr.Discard()}
+
+	require.NoError(t, rcn.makeDeletionFunc(&clientMock, instance)())
+	clientMock.AssertExpectations(t)
+}
+
+func TestReconcile_makeDeletionFunc_MissingGroup(t *testing.T) {
+	clientMock := gerritClientMocks.ClientInterface{}
+
+	instance := &gerritApi.GerritGroupMember{
+		Spec: gerritApi.GerritGroupMemberSpec{AccountID: "john", GroupID: "devs"},
+	}
+
+	clientMock.On("GetGroup", "devs").Return(nil, gerritClient.DoesNotExistError("not found"))
+
+	rcn := Reconcile{log: logr.Discard()}
+
+	require.NoError(t, rcn.makeDeletionFunc(&clientMock, instance)())
+	clientMock.AssertNotCalled(t, "DeleteUserFromGroup", mock.Anything, mock.Anything)
+}
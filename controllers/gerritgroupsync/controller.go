@@ -0,0 +1,310 @@
+package gerritgroupsync
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	gerritApi "github.com/epam/edp-gerrit-operator/v2/api/v1"
+	"github.com/epam/edp-gerrit-operator/v2/controllers/helper"
+	gerritClient "github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit"
+	"github.com/epam/edp-gerrit-operator/v2/pkg/service/gerrit"
+	"github.com/epam/edp-gerrit-operator/v2/pkg/service/platform"
+	"github.com/epam/edp-gerrit-operator/v2/pkg/tracing"
+)
+
+const (
+	defaultSyncInterval = 10 * time.Minute
+
+	// conditionSourceEmpty reports that the source has no members and the group is left unchanged.
+	conditionSourceEmpty = "SourceEmpty"
+	reasonNoMembers      = "NoMembers"
+
+	eventReasonMembersSynced = "MembersSynced"
+)
+
+type Reconcile struct {
+	client   client.Client
+	service  gerrit.Interface
+	log      logr.Logger
+	sources  []SourceFactory
+	recorder record.EventRecorder
+}
+
+func NewReconcile(k8sClient client.Client, scheme *runtime.Scheme, log logr.Logger) (helper.Controller, error) {
+	ps, err := platform.NewService(helper.GetPlatformTypeEnv(), scheme)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create platform service")
+	}
+
+	return &Reconcile{
+		client:  k8sClient,
+		service: gerrit.NewComponentService(ps, k8sClient, scheme),
+		log:     log.WithName("gerrit-group-sync"),
+		sources: []SourceFactory{newConfigMapSource},
+	}, nil
+}
+
+func (r *Reconcile) SetupWithManager(mgr ctrl.Manager) error {
+	pred := predicate.Funcs{
+		UpdateFunc: isSpecUpdated,
+	}
+
+	r.recorder = mgr.GetEventRecorderFor("gerrit-group-sync")
+
+	err := ctrl.NewControllerManagedBy(mgr).
+		For(&gerritApi.GerritGroupSync{}, builder.WithPredicates(pred)).
+		Complete(tracing.NewReconciler("GerritGroupSync", r))
+	if err != nil {
+		return fmt.Errorf("failed to setup GerritGroupSync controller: %w", err)
+	}
+
+	return nil
+}
+
+func isSpecUpdated(e event.UpdateEvent) bool {
+	oo, ok := e.ObjectOld.(*gerritApi.GerritGroupSync)
+	if !ok {
+		return false
+	}
+
+	no, ok := e.ObjectNew.(*gerritApi.GerritGroupSync)
+	if !ok {
+		return false
+	}
+
+	return !reflect.DeepEqual(oo.Spec, no.Spec) || helper.IsReconcileModeUpdated(e)
+}
+
+// +kubebuilder:rbac:groups=v2.edp.epam.com,namespace=placeholder,resources=gerritgroupsyncs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=v2.edp.epam.com,namespace=placeholder,resources=gerritgroupsyncs/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=v2.edp.epam.com,namespace=placeholder,resources=gerritgroupsyncs/finalizers,verbs=update
+
+func (r *Reconcile) Reconcile(ctx context.Context, request reconcile.Request) (result reconcile.Result, resError error) {
+	reqLogger := r.log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger.V(2).Info("Reconciling GerritGroupSync has been started")
+
+	var instance gerritApi.GerritGroupSync
+	if err := r.client.Get(ctx, request.NamespacedName, &instance); err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return
+		}
+
+		return reconcile.Result{}, errors.Wrap(err, "unable to get GerritGroupSync instance")
+	}
+
+	if helper.IsReconcilePaused(&instance) {
+		reqLogger.Info("Reconciliation of GerritGroupSync is paused")
+		return
+	}
+
+	defer func() {
+		if err := r.client.Status().Update(context.Background(), &instance); err != nil {
+			reqLogger.Error(err, "unable to update instance status")
+		}
+	}()
+
+	if helper.IsDryRun(&instance) {
+		defer setDryRunStatus(&instance, instance.Status.DeepCopy())
+	}
+
+	if err := r.tryToReconcile(ctx, &instance); err != nil {
+		reqLogger.Error(err, "unable to reconcile GerritGroupSync")
+		instance.Status.Value = err.Error()
+		helper.RecordWarning(r.recorder, &instance, helper.EventReasonFailed, err)
+
+		requeueTime := helper.SetFailureCount(&instance)
+		reqLogger.Info("Requeue time", "time", requeueTime.String())
+
+		return reconcile.Result{RequeueAfter: requeueTime}, nil
+	}
+
+	helper.SetSuccessStatus(&instance)
+
+	interval := syncInterval(&instance)
+	reqLogger.Info("Group members have been synced", "added", instance.Status.Added,
+		"removed", instance.Status.Removed, "next sync", interval.String())
+
+	return reconcile.Result{RequeueAfter: interval}, nil
+}
+
+func (r *Reconcile) tryToReconcile(ctx context.Context, instance *gerritApi.GerritGroupSync) error {
+	source, err := r.memberSource(instance)
+	if err != nil {
+		return err
+	}
+
+	desired, err := source.Members(ctx)
+	if err != nil {
+		return errors.Wrap(err, "unable to get members from source")
+	}
+
+	if len(desired) == 0 && !instance.Spec.AllowEmpty {
+		r.refuseEmptySource(instance)
+
+		return nil
+	}
+
+	meta.RemoveStatusCondition(&instance.Status.Conditions, conditionSourceEmpty)
+
+	cl, err := helper.GetGerritClient(ctx, r.client, instance, instance.Spec.OwnerName, r.service)
+	if err != nil {
+		return errors.Wrap(err, "unable to init gerrit client")
+	}
+
+	defer func() {
+		instance.Status.PlannedActions = gerritClient.PlannedActions(cl)
+	}()
+
+	current, err := cl.ListGroupMembers(instance.Spec.GroupID)
+	if err != nil {
+		return errors.Wrap(err, "unable to list group members")
+	}
+
+	toAdd, toRemove := diffMembers(desired, current)
+
+	for _, account := range toAdd {
+		if err := cl.AddUserToGroup(instance.Spec.GroupID, account); err != nil {
+			return errors.Wrapf(err, "unable to add %s to group", account)
+		}
+	}
+
+	for _, account := range toRemove {
+		if err := cl.DeleteUserFromGroup(instance.Spec.GroupID, account); err != nil {
+			return errors.Wrapf(err, "unable to delete %s from group", account)
+		}
+	}
+
+	if len(toAdd) > 0 || len(toRemove) > 0 {
+		helper.RecordEvent(r.recorder, instance, eventReasonMembersSynced,
+			"Group %s members have been synced: added %v, removed %v", instance.Spec.GroupID, toAdd, toRemove)
+	}
+
+	instance.Status.Members = len(desired)
+	instance.Status.Added = len(toAdd)
+	instance.Status.Removed = len(toRemove)
+	instance.Status.LastTimeSynced = metaV1.Now()
+
+	return nil
+}
+
+// refuseEmptySource leaves the group unchanged for the source without members and sets the SourceEmpty condition,
+// the members are removed only if the empty source is allowed explicitly.
+func (r *Reconcile) refuseEmptySource(instance *gerritApi.GerritGroupSync) {
+	condition := metaV1.Condition{
+		Type:   conditionSourceEmpty,
+		Status: metaV1.ConditionTrue,
+		Reason: reasonNoMembers,
+		Message: fmt.Sprintf("Source has no members, members of group %s are not removed unless allowEmpty is set",
+			instance.Spec.GroupID),
+		ObservedGeneration: instance.Generation,
+	}
+
+	if meta.SetStatusCondition(&instance.Status.Conditions, condition) {
+		helper.RecordWarning(r.recorder, instance, reasonNoMembers, errors.New(condition.Message))
+	}
+
+	instance.Status.Members = 0
+	instance.Status.Added = 0
+	instance.Status.Removed = 0
+}
+
+func (r *Reconcile) memberSource(instance *gerritApi.GerritGroupSync) (MemberSource, error) {
+	for _, factory := range r.sources {
+		source, err := factory(r.client, instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to init member source")
+		}
+
+		if source != nil {
+			return source, nil
+		}
+	}
+
+	return nil, errors.New("member source is not configured")
+}
+
+// diffMembers returns the accounts that must be added to the group and the accounts that must be removed from it.
+// A desired account matches a current member by username, email or account ID.
+func diffMembers(desired []string, current []gerritClient.GroupMember) (toAdd, toRemove []string) {
+	matched := make([]bool, len(current))
+
+	for _, account := range desired {
+		found := false
+
+		for i := range current {
+			if memberMatches(&current[i], account) {
+				matched[i] = true
+				found = true
+			}
+		}
+
+		if !found {
+			toAdd = append(toAdd, account)
+		}
+	}
+
+	for i := range current {
+		if !matched[i] {
+			toRemove = append(toRemove, memberID(&current[i]))
+		}
+	}
+
+	return toAdd, toRemove
+}
+
+func memberMatches(member *gerritClient.GroupMember, account string) bool {
+	return (member.Username != "" && strings.EqualFold(member.Username, account)) ||
+		(member.Email != "" && strings.EqualFold(member.Email, account)) ||
+		(member.AccountID != 0 && strconv.Itoa(member.AccountID) == account)
+}
+
+func memberID(member *gerritClient.GroupMember) string {
+	if member.AccountID != 0 {
+		return strconv.Itoa(member.AccountID)
+	}
+
+	if member.Username != "" {
+		return member.Username
+	}
+
+	return member.Email
+}
+
+// setDryRunStatus restores the status changed by the dry-run reconciliation, only the planned actions and the result are kept.
+func setDryRunStatus(instance *gerritApi.GerritGroupSync, status *gerritApi.GerritGroupSyncStatus) {
+	status.PlannedActions = instance.Status.PlannedActions
+	status.Value = instance.Status.Value
+
+	if status.Value == helper.StatusOK {
+		status.Value = helper.StatusDryRun
+	}
+
+	instance.Status = *status
+}
+
+func syncInterval(instance *gerritApi.GerritGroupSync) time.Duration {
+	interval, err := time.ParseDuration(instance.Spec.SyncInterval)
+	if err != nil || interval <= 0 {
+		return defaultSyncInterval
+	}
+
+	return interval
+}
@@ -0,0 +1,366 @@
+package gerritgroupsync
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilRuntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	commonmock "github.com/epam/edp-common/pkg/mock"
+
+	gerritApi "github.com/epam/edp-gerrit-operator/v2/api/v1"
+	"github.com/epam/edp-gerrit-operator/v2/controllers/helper"
+	gmock "github.com/epam/edp-gerrit-operator/v2/mock/gerrit"
+	gerritClient "github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit"
+	gerritClientMocks "github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit/mocks"
+)
+
+func TestReconcile_Reconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilRuntime.Must(gerritApi.AddToScheme(scheme))
+	utilRuntime.Must(coreV1.AddToScheme(scheme))
+
+	groupSync := gerritApi.GerritGroupSync{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      "sync1",
+			Namespace: "ns1",
+		},
+		Spec: gerritApi.GerritGroupSyncSpec{
+			GroupID:      "developers",
+			SyncInterval: "30m",
+			Source: gerritApi.GroupMemberSource{
+				ConfigMap: &gerritApi.ConfigMapMemberSource{Name: "members"},
+			},
+		},
+	}
+
+	cm := coreV1.ConfigMap{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      "members",
+			Namespace: groupSync.Namespace,
+		},
+		Data: map[string]string{
+			defaultConfigMapKey: "# members\njohn,John Doe\njane@example.com\n",
+		},
+	}
+
+	g := gerritApi.Gerrit{
+		ObjectMeta: metaV1.ObjectMeta{
+			Namespace: groupSync.Namespace,
+			Name:      "ger1",
+		},
+	}
+
+	client := fake.NewClientBuilder().WithStatusSubresource(&gerritApi.GerritGroupSync{}).WithScheme(scheme).
+		WithRuntimeObjects(&groupSync, &cm, &g).Build()
+
+	serviceMock := gmock.Interface{}
+	clientMock := gerritClientMocks.ClientInterface{}
+
+	serviceMock.On("GetRestClient", &g).Return(&clientMock, nil)
+	clientMock.On("ListGroupMembers", "developers").Return([]gerritClient.GroupMember{
+		{AccountID: 1000001, Username: "john"},
+		{AccountID: 1000002, Username: "bob"},
+	}, nil)
+	clientMock.On("AddUserToGroup", "developers", "jane@example.com").Return(nil)
+	clientMock.On("DeleteUserFromGroup", "developers", "1000002").Return(nil)
+
+	recorder := record.NewFakeRecorder(1)
+
+	rcn := Reconcile{
+		client:   client,
+		log:      commonmock.NewLogr(),
+		service:  &serviceMock,
+		sources:  []SourceFactory{newConfigMapSource},
+		recorder: recorder,
+	}
+
+	nn := types.NamespacedName{
+		Name:      groupSync.Name,
+		Namespace: groupSync.Namespace,
+	}
+
+	res, err := rcn.Reconcile(context.Background(), reconcile.Request{NamespacedName: nn})
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Minute, res.RequeueAfter)
+
+	var updateInstance gerritApi.GerritGroupSync
+	require.NoError(t, client.Get(context.Background(), nn, &updateInstance))
+
+	assert.Equal(t, helper.StatusOK, updateInstance.Status.Value)
+	assert.Equal(t, 2, updateInstance.Status.Members)
+	assert.Equal(t, 1, updateInstance.Status.Added)
+	assert.Equal(t, 1, updateInstance.Status.Removed)
+	assert.False(t, updateInstance.Status.LastTimeSynced.IsZero())
+
+	require.Len(t, recorder.Events, 1)
+	assert.Equal(t, "Normal MembersSynced Group developers members have been synced: added [jane@example.com], removed [1000002]",
+		<-recorder.Events)
+
+	serviceMock.AssertExpectations(t)
+	clientMock.AssertExpectations(t)
+}
+
+func TestReconcile_Reconcile_Failure(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilRuntime.Must(gerritApi.AddToScheme(scheme))
+	utilRuntime.Must(coreV1.AddToScheme(scheme))
+
+	groupSync := gerritApi.GerritGroupSync{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      "sync1",
+			Namespace: "ns1",
+		},
+		Spec: gerritApi.GerritGroupSyncSpec{
+			GroupID: "developers",
+			Source: gerritApi.GroupMemberSource{
+				ConfigMap: &gerritApi.ConfigMapMemberSource{Name: "members"},
+			},
+		},
+	}
+
+	g := gerritApi.Gerrit{
+		ObjectMeta: metaV1.ObjectMeta{
+			Namespace: groupSync.Namespace,
+			Name:      "ger1",
+		},
+	}
+
+	tests := []struct {
+		name      string
+		objects   []runtime.Object
+		prepare   func(cl *gerritClientMocks.ClientInterface)
+		wantError string
+	}{
+		{
+			name:      "config map does not exist",
+			objects:   []runtime.Object{&groupSync, &g},
+			prepare:   func(cl *gerritClientMocks.ClientInterface) {},
+			wantError: "unable to get ConfigMap members",
+		},
+		{
+			name: "unable to list group members",
+			objects: []runtime.Object{&groupSync, &g, &coreV1.ConfigMap{
+				ObjectMeta: metaV1.ObjectMeta{Name: "members", Namespace: groupSync.Namespace},
+				Data:       map[string]string{defaultConfigMapKey: "john"},
+			}},
+			prepare: func(cl *gerritClientMocks.ClientInterface) {
+				cl.On("ListGroupMembers", "developers").Return(nil, errors.New("fatal"))
+			},
+			wantError: "unable to list group members",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewClientBuilder().WithStatusSubresource(&gerritApi.GerritGroupSync{}).WithScheme(scheme).
+				WithRuntimeObjects(tt.objects...).Build()
+
+			serviceMock := gmock.Interface{}
+			clientMock := gerritClientMocks.ClientInterface{}
+
+			serviceMock.On("GetRestClient", &g).Return(&clientMock, nil).Maybe()
+			tt.prepare(&clientMock)
+
+			recorder := record.NewFakeRecorder(1)
+
+			rcn := Reconcile{
+				client:   client,
+				log:      commonmock.NewLogr(),
+				service:  &serviceMock,
+				sources:  []SourceFactory{newConfigMapSource},
+				recorder: recorder,
+			}
+
+			nn := types.NamespacedName{Name: groupSync.Name, Namespace: groupSync.Namespace}
+
+			res, err := rcn.Reconcile(context.Background(), reconcile.Request{NamespacedName: nn})
+			require.NoError(t, err)
+			assert.Greater(t, res.RequeueAfter, time.Duration(0))
+
+			var updateInstance gerritApi.GerritGroupSync
+			require.NoError(t, client.Get(context.Background(), nn, &updateInstance))
+
+			assert.Contains(t, updateInstance.Status.Value, tt.wantError)
+			assert.Equal(t, int64(1), updateInstance.Status.FailureCount)
+
+			require.Len(t, recorder.Events, 1)
+
+			event := <-recorder.Events
+			assert.True(t, strings.HasPrefix(event, "Warning Failed "))
+			assert.Contains(t, event, tt.wantError)
+		})
+	}
+}
+
+func TestReconcile_Reconcile_EmptySource(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilRuntime.Must(gerritApi.AddToScheme(scheme))
+	utilRuntime.Must(coreV1.AddToScheme(scheme))
+
+	g := gerritApi.Gerrit{
+		ObjectMeta: metaV1.ObjectMeta{
+			Namespace: "ns1",
+			Name:      "ger1",
+		},
+	}
+
+	// the members have been removed from the ConfigMap by mistake
+	cm := coreV1.ConfigMap{
+		ObjectMeta: metaV1.ObjectMeta{Name: "members", Namespace: g.Namespace},
+		Data:       map[string]string{defaultConfigMapKey: "# members\n"},
+	}
+
+	tests := []struct {
+		name          string
+		allowEmpty    bool
+		prepare       func(cl *gerritClientMocks.ClientInterface)
+		wantCondition bool
+		wantEvent     string
+	}{
+		{
+			name:          "empty source is refused",
+			prepare:       func(cl *gerritClientMocks.ClientInterface) {},
+			wantCondition: true,
+			wantEvent:     "Warning NoMembers Source has no members, members of group developers are not removed unless allowEmpty is set",
+		},
+		{
+			name:       "empty source is allowed",
+			allowEmpty: true,
+			prepare: func(cl *gerritClientMocks.ClientInterface) {
+				cl.On("ListGroupMembers", "developers").Return([]gerritClient.GroupMember{{AccountID: 1000001}}, nil)
+				cl.On("DeleteUserFromGroup", "developers", "1000001").Return(nil)
+			},
+			wantEvent: "Normal MembersSynced Group developers members have been synced: added [], removed [1000001]",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			groupSync := gerritApi.GerritGroupSync{
+				ObjectMeta: metaV1.ObjectMeta{
+					Name:      "sync1",
+					Namespace: g.Namespace,
+				},
+				Spec: gerritApi.GerritGroupSyncSpec{
+					GroupID:    "developers",
+					AllowEmpty: tt.allowEmpty,
+					Source: gerritApi.GroupMemberSource{
+						ConfigMap: &gerritApi.ConfigMapMemberSource{Name: "members"},
+					},
+				},
+			}
+
+			client := fake.NewClientBuilder().WithStatusSubresource(&gerritApi.GerritGroupSync{}).WithScheme(scheme).
+				WithRuntimeObjects(&groupSync, &cm, &g).Build()
+
+			serviceMock := gmock.Interface{}
+			clientMock := gerritClientMocks.ClientInterface{}
+
+			serviceMock.On("GetRestClient", &g).Return(&clientMock, nil).Maybe()
+			tt.prepare(&clientMock)
+
+			recorder := record.NewFakeRecorder(1)
+
+			rcn := Reconcile{
+				client:   client,
+				log:      commonmock.NewLogr(),
+				service:  &serviceMock,
+				sources:  []SourceFactory{newConfigMapSource},
+				recorder: recorder,
+			}
+
+			nn := types.NamespacedName{Name: groupSync.Name, Namespace: groupSync.Namespace}
+
+			_, err := rcn.Reconcile(context.Background(), reconcile.Request{NamespacedName: nn})
+			require.NoError(t, err)
+
+			var updateInstance gerritApi.GerritGroupSync
+			require.NoError(t, client.Get(context.Background(), nn, &updateInstance))
+
+			assert.Equal(t, helper.StatusOK, updateInstance.Status.Value)
+			assert.Equal(t, tt.wantCondition, meta.IsStatusConditionTrue(updateInstance.Status.Conditions, conditionSourceEmpty))
+
+			require.Len(t, recorder.Events, 1)
+			assert.Equal(t, tt.wantEvent, <-recorder.Events)
+
+			clientMock.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_diffMembers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		desired    []string
+		current    []gerritClient.GroupMember
+		wantAdd    []string
+		wantRemove []string
+	}{
+		{
+			name:    "empty group",
+			desired: []string{"john", "jane@example.com"},
+			wantAdd: []string{"john", "jane@example.com"},
+		},
+		{
+			name:    "match by username, email and account id",
+			desired: []string{"John", "jane@example.com", "1000003"},
+			current: []gerritClient.GroupMember{
+				{AccountID: 1000001, Username: "john"},
+				{AccountID: 1000002, Email: "Jane@example.com"},
+				{AccountID: 1000003, Username: "bob"},
+			},
+		},
+		{
+			name:    "remove members missing in source",
+			desired: []string{"john"},
+			current: []gerritClient.GroupMember{
+				{AccountID: 1000001, Username: "john"},
+				{AccountID: 1000002, Username: "bob"},
+				{Username: "alice"},
+			},
+			wantRemove: []string{"1000002", "alice"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			toAdd, toRemove := diffMembers(tt.desired, tt.current)
+			assert.Equal(t, tt.wantAdd, toAdd)
+			assert.Equal(t, tt.wantRemove, toRemove)
+		})
+	}
+}
+
+func Test_syncInterval(t *testing.T) {
+	t.Parallel()
+
+	instance := gerritApi.GerritGroupSync{}
+	assert.Equal(t, defaultSyncInterval, syncInterval(&instance))
+
+	instance.Spec.SyncInterval = "wrong"
+	assert.Equal(t, defaultSyncInterval, syncInterval(&instance))
+
+	instance.Spec.SyncInterval = "1h"
+	assert.Equal(t, time.Hour, syncInterval(&instance))
+}
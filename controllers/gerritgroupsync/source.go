@@ -0,0 +1,102 @@
+package gerritgroupsync
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gerritApi "github.com/epam/edp-gerrit-operator/v2/api/v1"
+)
+
+const defaultConfigMapKey = "members.csv"
+
+// MemberSource provides the desired members of a Gerrit group.
+// Each member is a Gerrit username, email or account ID.
+type MemberSource interface {
+	Members(ctx context.Context) ([]string, error)
+}
+
+// SourceFactory builds a MemberSource for the given GerritGroupSync.
+// It returns a nil source when the GerritGroupSync does not use the source kind handled by the factory.
+// New adapters (e.g. Keycloak or LDAP) are plugged in by adding a factory to Reconcile.sources.
+type SourceFactory func(k8sClient client.Client, instance *gerritApi.GerritGroupSync) (MemberSource, error)
+
+func newConfigMapSource(k8sClient client.Client, instance *gerritApi.GerritGroupSync) (MemberSource, error) {
+	cmSource := instance.Spec.Source.ConfigMap
+	if cmSource == nil {
+		return nil, nil
+	}
+
+	key := cmSource.Key
+	if key == "" {
+		key = defaultConfigMapKey
+	}
+
+	return &configMapSource{
+		client: k8sClient,
+		name:   types.NamespacedName{Namespace: instance.Namespace, Name: cmSource.Name},
+		key:    key,
+	}, nil
+}
+
+type configMapSource struct {
+	client client.Client
+	name   types.NamespacedName
+	key    string
+}
+
+func (s *configMapSource) Members(ctx context.Context) ([]string, error) {
+	var cm coreV1.ConfigMap
+	if err := s.client.Get(ctx, s.name, &cm); err != nil {
+		return nil, errors.Wrapf(err, "unable to get ConfigMap %s", s.name.Name)
+	}
+
+	data, ok := cm.Data[s.key]
+	if !ok {
+		return nil, errors.Errorf("ConfigMap %s has no key %s", s.name.Name, s.key)
+	}
+
+	members, err := parseCSVMembers(strings.NewReader(data))
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to parse key %s of ConfigMap %s", s.key, s.name.Name)
+	}
+
+	return members, nil
+}
+
+// parseCSVMembers returns the unique values of the first column of CSV records.
+func parseCSVMembers(r io.Reader) ([]string, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	seen := make(map[string]bool)
+
+	var members []string
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return members, nil
+		}
+
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to read csv record")
+		}
+
+		member := strings.TrimSpace(record[0])
+		if member == "" || seen[member] {
+			continue
+		}
+
+		seen[member] = true
+		members = append(members, member)
+	}
+}
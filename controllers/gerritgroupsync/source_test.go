@@ -0,0 +1,54 @@
+package gerritgroupsync
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseCSVMembers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		data    string
+		want    []string
+		wantErr require.ErrorAssertionFunc
+	}{
+		{
+			name:    "one member per line",
+			data:    "john\njane@example.com\n",
+			want:    []string{"john", "jane@example.com"},
+			wantErr: require.NoError,
+		},
+		{
+			name:    "comments, extra columns, blank lines and duplicates",
+			data:    "# username,name\n john , John Doe\n\njane,Jane Doe\njohn\n",
+			want:    []string{"john", "jane"},
+			wantErr: require.NoError,
+		},
+		{
+			name:    "empty",
+			data:    "",
+			wantErr: require.NoError,
+		},
+		{
+			name:    "malformed csv",
+			data:    "\"john\n",
+			wantErr: require.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseCSVMembers(strings.NewReader(tt.data))
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
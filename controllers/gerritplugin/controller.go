@@ -0,0 +1,265 @@
+package gerritplugin
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	gerritApi "github.com/epam/edp-gerrit-operator/v2/api/v1"
+	"github.com/epam/edp-gerrit-operator/v2/controllers/helper"
+	gerritClient "github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit"
+	"github.com/epam/edp-gerrit-operator/v2/pkg/service/gerrit"
+	"github.com/epam/edp-gerrit-operator/v2/pkg/service/platform"
+	"github.com/epam/edp-gerrit-operator/v2/pkg/tracing"
+)
+
+const finalizerName = "gerritplugin.gerrit.finalizer.name"
+
+type Reconcile struct {
+	client   client.Client
+	service  gerrit.Interface
+	log      logr.Logger
+	recorder record.EventRecorder
+}
+
+func NewReconcile(k8sClient client.Client, scheme *runtime.Scheme, log logr.Logger) (helper.Controller, error) {
+	ps, err := platform.NewService(helper.GetPlatformTypeEnv(), scheme)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create platform service")
+	}
+
+	return &Reconcile{
+		client:  k8sClient,
+		service: gerrit.NewComponentService(ps, k8sClient, scheme),
+		log:     log.WithName("gerrit-plugin"),
+	}, nil
+}
+
+func (r *Reconcile) SetupWithManager(mgr ctrl.Manager) error {
+	pred := predicate.Funcs{
+		UpdateFunc: isSpecUpdated,
+	}
+
+	r.recorder = mgr.GetEventRecorderFor("gerrit-plugin")
+
+	err := ctrl.NewControllerManagedBy(mgr).
+		For(&gerritApi.GerritPlugin{}, builder.WithPredicates(pred)).
+		Complete(tracing.NewReconciler("GerritPlugin", r))
+	if err != nil {
+		return fmt.Errorf("failed to setup GerritPlugin controller: %w", err)
+	}
+
+	return nil
+}
+
+func isSpecUpdated(e event.UpdateEvent) bool {
+	oo, ok := e.ObjectOld.(*gerritApi.GerritPlugin)
+	if !ok {
+		return false
+	}
+
+	no, ok := e.ObjectNew.(*gerritApi.GerritPlugin)
+	if !ok {
+		return false
+	}
+
+	return !reflect.DeepEqual(oo.Spec, no.Spec) || helper.IsReconcileModeUpdated(e) ||
+		(oo.GetDeletionTimestamp().IsZero() && !no.GetDeletionTimestamp().IsZero())
+}
+
+// +kubebuilder:rbac:groups=v2.edp.epam.com,namespace=placeholder,resources=gerritplugins,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=v2.edp.epam.com,namespace=placeholder,resources=gerritplugins/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=v2.edp.epam.com,namespace=placeholder,resources=gerritplugins/finalizers,verbs=update
+
+func (r *Reconcile) Reconcile(ctx context.Context, request reconcile.Request) (result reconcile.Result, resError error) {
+	reqLogger := r.log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger.V(2).Info("Reconciling GerritPlugin has been started")
+
+	var instance gerritApi.GerritPlugin
+	if err := r.client.Get(ctx, request.NamespacedName, &instance); err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return
+		}
+
+		return reconcile.Result{}, errors.Wrap(err, "unable to get GerritPlugin instance")
+	}
+
+	tracing.SetAttributes(ctx, tracing.GerritInstance.String(instance.Spec.OwnerName))
+
+	if helper.IsReconcilePaused(&instance) {
+		reqLogger.Info("Reconciliation of GerritPlugin is paused")
+		return
+	}
+
+	defer func() {
+		if err := r.client.Status().Update(context.Background(), &instance); err != nil {
+			reqLogger.Error(err, "unable to update instance status")
+		}
+	}()
+
+	if helper.IsDryRun(&instance) {
+		defer setDryRunStatus(&instance, instance.Status.DeepCopy())
+	}
+
+	if err := r.tryToReconcile(ctx, &instance); err != nil {
+		reqLogger.Error(err, "unable to reconcile GerritPlugin")
+		instance.Status.Value = err.Error()
+		helper.RecordWarning(r.recorder, &instance, helper.EventReasonFailed, err)
+
+		requeueTime := helper.SetFailureCount(&instance)
+		reqLogger.Info("Requeue time", "time", requeueTime.String())
+
+		return reconcile.Result{RequeueAfter: requeueTime}, nil
+	}
+
+	helper.SetSuccessStatus(&instance)
+
+	// the plugin configuration is not watched, its changes are picked up by the periodic checks
+	return reconcile.Result{RequeueAfter: helper.DriftCheckInterval()}, nil
+}
+
+func (r *Reconcile) tryToReconcile(ctx context.Context, instance *gerritApi.GerritPlugin) error {
+	cl, err := helper.GetGerritClient(ctx, r.client, instance, instance.Spec.OwnerName, r.service)
+	if err != nil {
+		return errors.Wrap(err, "unable to init gerrit client")
+	}
+
+	defer func() {
+		instance.Status.PlannedActions = gerritClient.PlannedActions(cl)
+	}()
+
+	if instance.GetDeletionTimestamp().IsZero() {
+		if err := r.applyPlugin(ctx, cl, instance); err != nil {
+			return err
+		}
+	}
+
+	if err := helper.TryToDelete(ctx, r.client, instance, finalizerName,
+		r.makeDeletionFunc(cl, instance)); err != nil {
+		return errors.Wrap(err, "error during TryToDelete")
+	}
+
+	return nil
+}
+
+// applyPlugin installs the plugin if it is missing or its source has changed,
+// enables or disables it and reloads it when its configuration has changed.
+func (r *Reconcile) applyPlugin(ctx context.Context, cl gerritClient.ClientInterface, instance *gerritApi.GerritPlugin) error {
+	name := instance.Spec.Name
+
+	source, err := newPluginSource(ctx, r.client, instance)
+	if err != nil {
+		return err
+	}
+
+	cfgHash, err := configHash(ctx, r.client, instance)
+	if err != nil {
+		return err
+	}
+
+	live, err := cl.GetPlugin(name)
+	if err != nil && !gerritClient.IsErrDoesNotExist(err) {
+		return errors.Wrap(err, "unable to get plugin")
+	}
+
+	changed := false
+	enabled := live != nil && !live.Disabled
+
+	if live == nil || instance.Status.SourceHash != source.hash {
+		if err := source.install(cl); err != nil {
+			return errors.Wrap(err, "unable to install plugin")
+		}
+
+		if live == nil {
+			helper.RecordEvent(r.recorder, instance, helper.EventReasonCreated, "Plugin %s has been installed", name)
+		} else {
+			helper.RecordEvent(r.recorder, instance, helper.EventReasonUpdated, "Plugin %s has been upgraded", name)
+		}
+
+		// the installed plugin is loaded with the current configuration
+		changed, enabled = true, true
+		instance.Status.SourceHash = source.hash
+		instance.Status.ConfigHash = cfgHash
+	}
+
+	switch {
+	case instance.Spec.Disabled && enabled:
+		if err := cl.DisablePlugin(name); err != nil {
+			return errors.Wrap(err, "unable to disable plugin")
+		}
+
+		helper.RecordEvent(r.recorder, instance, helper.EventReasonUpdated, "Plugin %s has been disabled", name)
+
+		changed = true
+	case !instance.Spec.Disabled && !enabled:
+		if err := cl.EnablePlugin(name); err != nil {
+			return errors.Wrap(err, "unable to enable plugin")
+		}
+
+		helper.RecordEvent(r.recorder, instance, helper.EventReasonUpdated, "Plugin %s has been enabled", name)
+
+		changed = true
+	case !instance.Spec.Disabled && instance.Status.ConfigHash != cfgHash:
+		if err := cl.RestartPlugin(name); err != nil {
+			return errors.Wrap(err, "unable to reload plugin")
+		}
+
+		helper.RecordEvent(r.recorder, instance, helper.EventReasonUpdated, "Plugin %s has been reloaded", name)
+	}
+
+	instance.Status.ConfigHash = cfgHash
+
+	if changed {
+		if live, err = cl.GetPlugin(name); err != nil && !gerritClient.IsErrDoesNotExist(err) {
+			return errors.Wrap(err, "unable to get plugin")
+		}
+	}
+
+	if live != nil {
+		instance.Status.Version = live.Version
+		instance.Status.Enabled = !live.Disabled
+	}
+
+	return nil
+}
+
+// makeDeletionFunc disables the plugin, Gerrit does not support the removal of the plugin jar with the REST API.
+func (r *Reconcile) makeDeletionFunc(cl gerritClient.ClientInterface, instance *gerritApi.GerritPlugin) func() error {
+	return func() error {
+		if err := cl.DisablePlugin(instance.Spec.Name); err != nil {
+			if gerritClient.IsErrDoesNotExist(err) {
+				return nil
+			}
+
+			return errors.Wrap(err, "unable to disable plugin")
+		}
+
+		helper.RecordEvent(r.recorder, instance, helper.EventReasonDeleted, "Plugin %s has been disabled", instance.Spec.Name)
+
+		return nil
+	}
+}
+
+// setDryRunStatus restores the status changed by the dry-run reconciliation, only the planned actions and the result are kept.
+func setDryRunStatus(instance *gerritApi.GerritPlugin, status *gerritApi.GerritPluginStatus) {
+	status.PlannedActions = instance.Status.PlannedActions
+	status.Value = instance.Status.Value
+
+	if status.Value == helper.StatusOK {
+		status.Value = helper.StatusDryRun
+	}
+
+	instance.Status = *status
+}
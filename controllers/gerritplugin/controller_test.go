@@ -0,0 +1,330 @@
+package gerritplugin
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilRuntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	commonmock "github.com/epam/edp-common/pkg/mock"
+
+	gerritApi "github.com/epam/edp-gerrit-operator/v2/api/v1"
+	"github.com/epam/edp-gerrit-operator/v2/controllers/helper"
+	gmock "github.com/epam/edp-gerrit-operator/v2/mock/gerrit"
+	gerritClient "github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit"
+	gerritClientMocks "github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit/mocks"
+)
+
+func TestReconcile_Reconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilRuntime.Must(gerritApi.AddToScheme(scheme))
+	utilRuntime.Must(coreV1.AddToScheme(scheme))
+
+	g := gerritApi.Gerrit{
+		ObjectMeta: metaV1.ObjectMeta{
+			Namespace: "ns1",
+			Name:      "ger1",
+		},
+	}
+
+	config := coreV1.ConfigMap{
+		ObjectMeta: metaV1.ObjectMeta{Name: "gitiles-config", Namespace: g.Namespace},
+		Data:       map[string]string{"gitiles.config": "[gerrit]\n  linkname = browse\n"},
+	}
+
+	newPlugin := func(status gerritApi.GerritPluginStatus) *gerritApi.GerritPlugin {
+		return &gerritApi.GerritPlugin{
+			ObjectMeta: metaV1.ObjectMeta{
+				Name:       "gitiles",
+				Namespace:  g.Namespace,
+				Finalizers: []string{finalizerName},
+			},
+			Spec: gerritApi.GerritPluginSpec{
+				Name:          "gitiles",
+				Source:        gerritApi.PluginSource{URL: "https://example.com/gitiles.jar"},
+				ConfigMapName: config.Name,
+			},
+			Status: status,
+		}
+	}
+
+	sourceHash := hashOf([]byte("url:https://example.com/gitiles.jar"))
+
+	tests := []struct {
+		name       string
+		instance   *gerritApi.GerritPlugin
+		prepare    func(cl *gerritClientMocks.ClientInterface)
+		wantEvents []string
+	}{
+		{
+			name:     "install plugin",
+			instance: newPlugin(gerritApi.GerritPluginStatus{}),
+			prepare: func(cl *gerritClientMocks.ClientInterface) {
+				cl.On("GetPlugin", "gitiles").Return(nil, gerritClient.DoesNotExistError("not found")).Once()
+				cl.On("InstallPlugin", "gitiles", "https://example.com/gitiles.jar").Return(nil)
+				cl.On("GetPlugin", "gitiles").Return(&gerritClient.Plugin{ID: "gitiles", Version: "3.9.1"}, nil).Once()
+			},
+			wantEvents: []string{"Normal Created Plugin gitiles has been installed"},
+		},
+		{
+			name:     "upgrade plugin",
+			instance: newPlugin(gerritApi.GerritPluginStatus{SourceHash: "old"}),
+			prepare: func(cl *gerritClientMocks.ClientInterface) {
+				cl.On("GetPlugin", "gitiles").Return(&gerritClient.Plugin{ID: "gitiles", Version: "3.8.0"}, nil).Once()
+				cl.On("InstallPlugin", "gitiles", "https://example.com/gitiles.jar").Return(nil)
+				cl.On("GetPlugin", "gitiles").Return(&gerritClient.Plugin{ID: "gitiles", Version: "3.9.1"}, nil).Once()
+			},
+			wantEvents: []string{"Normal Updated Plugin gitiles has been upgraded"},
+		},
+		{
+			name:     "enable disabled plugin",
+			instance: newPlugin(gerritApi.GerritPluginStatus{SourceHash: sourceHash}),
+			prepare: func(cl *gerritClientMocks.ClientInterface) {
+				cl.On("GetPlugin", "gitiles").
+					Return(&gerritClient.Plugin{ID: "gitiles", Version: "3.9.1", Disabled: true}, nil).Once()
+				cl.On("EnablePlugin", "gitiles").Return(nil)
+				cl.On("GetPlugin", "gitiles").Return(&gerritClient.Plugin{ID: "gitiles", Version: "3.9.1"}, nil).Once()
+			},
+			wantEvents: []string{"Normal Updated Plugin gitiles has been enabled"},
+		},
+		{
+			name:     "reload plugin on config change",
+			instance: newPlugin(gerritApi.GerritPluginStatus{SourceHash: sourceHash, ConfigHash: "old"}),
+			prepare: func(cl *gerritClientMocks.ClientInterface) {
+				cl.On("GetPlugin", "gitiles").Return(&gerritClient.Plugin{ID: "gitiles", Version: "3.9.1"}, nil).Once()
+				cl.On("RestartPlugin", "gitiles").Return(nil)
+			},
+			wantEvents: []string{"Normal Updated Plugin gitiles has been reloaded"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewClientBuilder().WithStatusSubresource(&gerritApi.GerritPlugin{}).WithScheme(scheme).
+				WithRuntimeObjects(tt.instance, &config, &g).Build()
+
+			serviceMock := gmock.Interface{}
+			clientMock := gerritClientMocks.ClientInterface{}
+
+			serviceMock.On("GetRestClient", &g).Return(&clientMock, nil)
+			tt.prepare(&clientMock)
+
+			recorder := record.NewFakeRecorder(len(tt.wantEvents))
+
+			rcn := Reconcile{
+				client:   client,
+				log:      commonmock.NewLogr(),
+				service:  &serviceMock,
+				recorder: recorder,
+			}
+
+			nn := types.NamespacedName{Name: tt.instance.Name, Namespace: tt.instance.Namespace}
+
+			res, err := rcn.Reconcile(context.Background(), reconcile.Request{NamespacedName: nn})
+			require.NoError(t, err)
+			assert.Equal(t, helper.DriftCheckInterval(), res.RequeueAfter)
+
+			var updateInstance gerritApi.GerritPlugin
+			require.NoError(t, client.Get(context.Background(), nn, &updateInstance))
+
+			assert.Equal(t, helper.StatusOK, updateInstance.Status.Value)
+			assert.Equal(t, "3.9.1", updateInstance.Status.Version)
+			assert.True(t, updateInstance.Status.Enabled)
+			assert.Equal(t, sourceHash, updateInstance.Status.SourceHash)
+			assert.NotEmpty(t, updateInstance.Status.ConfigHash)
+			assert.NotEqual(t, "old", updateInstance.Status.ConfigHash)
+
+			require.Len(t, recorder.Events, len(tt.wantEvents))
+
+			for _, want := range tt.wantEvents {
+				assert.Equal(t, want, <-recorder.Events)
+			}
+
+			serviceMock.AssertExpectations(t)
+			clientMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestReconcile_Reconcile_Failure(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilRuntime.Must(gerritApi.AddToScheme(scheme))
+	utilRuntime.Must(coreV1.AddToScheme(scheme))
+
+	g := gerritApi.Gerrit{
+		ObjectMeta: metaV1.ObjectMeta{
+			Namespace: "ns1",
+			Name:      "ger1",
+		},
+	}
+
+	newPlugin := func(source gerritApi.PluginSource) *gerritApi.GerritPlugin {
+		return &gerritApi.GerritPlugin{
+			ObjectMeta: metaV1.ObjectMeta{Name: "gitiles", Namespace: g.Namespace},
+			Spec:       gerritApi.GerritPluginSpec{Name: "gitiles", Source: source},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		instance  *gerritApi.GerritPlugin
+		prepare   func(cl *gerritClientMocks.ClientInterface)
+		wantError string
+	}{
+		{
+			name: "several sources",
+			instance: newPlugin(gerritApi.PluginSource{
+				URL:  "https://example.com/gitiles.jar",
+				Path: "/var/gerrit/plugins-src/gitiles.jar",
+			}),
+			prepare:   func(cl *gerritClientMocks.ClientInterface) {},
+			wantError: "exactly one of url, configMap and path must be set",
+		},
+		{
+			name: "config map does not exist",
+			instance: newPlugin(gerritApi.PluginSource{
+				ConfigMap: &gerritApi.ConfigMapPluginSource{Name: "gitiles-jar"},
+			}),
+			prepare:   func(cl *gerritClientMocks.ClientInterface) {},
+			wantError: "unable to get ConfigMap gitiles-jar",
+		},
+		{
+			name:     "unable to install plugin",
+			instance: newPlugin(gerritApi.PluginSource{Path: "/var/gerrit/plugins-src/gitiles.jar"}),
+			prepare: func(cl *gerritClientMocks.ClientInterface) {
+				cl.On("GetPlugin", "gitiles").Return(nil, gerritClient.DoesNotExistError("not found"))
+				cl.On("InstallPlugin", "gitiles", "file:///var/gerrit/plugins-src/gitiles.jar").
+					Return(errors.New("remote plugin administration is disabled"))
+			},
+			wantError: "unable to install plugin",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewClientBuilder().WithStatusSubresource(&gerritApi.GerritPlugin{}).WithScheme(scheme).
+				WithRuntimeObjects(tt.instance, &g).Build()
+
+			serviceMock := gmock.Interface{}
+			clientMock := gerritClientMocks.ClientInterface{}
+
+			serviceMock.On("GetRestClient", &g).Return(&clientMock, nil)
+			tt.prepare(&clientMock)
+
+			recorder := record.NewFakeRecorder(1)
+
+			rcn := Reconcile{
+				client:   client,
+				log:      commonmock.NewLogr(),
+				service:  &serviceMock,
+				recorder: recorder,
+			}
+
+			nn := types.NamespacedName{Name: tt.instance.Name, Namespace: tt.instance.Namespace}
+
+			res, err := rcn.Reconcile(context.Background(), reconcile.Request{NamespacedName: nn})
+			require.NoError(t, err)
+			assert.Greater(t, res.RequeueAfter, time.Duration(0))
+
+			var updateInstance gerritApi.GerritPlugin
+			require.NoError(t, client.Get(context.Background(), nn, &updateInstance))
+
+			assert.Contains(t, updateInstance.Status.Value, tt.wantError)
+			assert.Equal(t, int64(1), updateInstance.Status.FailureCount)
+
+			require.Len(t, recorder.Events, 1)
+
+			event := <-recorder.Events
+			assert.True(t, strings.HasPrefix(event, "Warning Failed "))
+			assert.Contains(t, event, tt.wantError)
+
+			clientMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestReconcile_Reconcile_Delete(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilRuntime.Must(gerritApi.AddToScheme(scheme))
+
+	g := gerritApi.Gerrit{
+		ObjectMeta: metaV1.ObjectMeta{
+			Namespace: "ns1",
+			Name:      "ger1",
+		},
+	}
+
+	plugin := gerritApi.GerritPlugin{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:              "gitiles",
+			Namespace:         g.Namespace,
+			Finalizers:        []string{finalizerName},
+			DeletionTimestamp: &metaV1.Time{Time: time.Now()},
+		},
+		Spec: gerritApi.GerritPluginSpec{
+			Name:   "gitiles",
+			Source: gerritApi.PluginSource{URL: "https://example.com/gitiles.jar"},
+		},
+	}
+
+	client := fake.NewClientBuilder().WithStatusSubresource(&gerritApi.GerritPlugin{}).WithScheme(scheme).
+		WithRuntimeObjects(&plugin, &g).Build()
+
+	serviceMock := gmock.Interface{}
+	clientMock := gerritClientMocks.ClientInterface{}
+
+	serviceMock.On("GetRestClient", &g).Return(&clientMock, nil)
+	clientMock.On("DisablePlugin", "gitiles").Return(nil)
+
+	recorder := record.NewFakeRecorder(1)
+
+	rcn := Reconcile{
+		client:   client,
+		log:      commonmock.NewLogr(),
+		service:  &serviceMock,
+		recorder: recorder,
+	}
+
+	nn := types.NamespacedName{Name: plugin.Name, Namespace: plugin.Namespace}
+
+	_, err := rcn.Reconcile(context.Background(), reconcile.Request{NamespacedName: nn})
+	require.NoError(t, err)
+
+	require.Len(t, recorder.Events, 1)
+	assert.Equal(t, "Normal Deleted Plugin gitiles has been disabled", <-recorder.Events)
+
+	clientMock.AssertExpectations(t)
+}
+
+func Test_configMapJar(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilRuntime.Must(coreV1.AddToScheme(scheme))
+
+	client := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(&coreV1.ConfigMap{
+		ObjectMeta: metaV1.ObjectMeta{Name: "gitiles-jar", Namespace: "ns1"},
+		BinaryData: map[string][]byte{defaultConfigMapKey: []byte("jar")},
+	}).Build()
+
+	jar, err := configMapJar(context.Background(), client, "ns1", &gerritApi.ConfigMapPluginSource{Name: "gitiles-jar"})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("jar"), jar)
+
+	_, err = configMapJar(context.Background(), client, "ns1",
+		&gerritApi.ConfigMapPluginSource{Name: "gitiles-jar", Key: "gitiles.jar"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not contain key gitiles.jar")
+}
@@ -0,0 +1,133 @@
+package gerritplugin
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+
+	"github.com/pkg/errors"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gerritApi "github.com/epam/edp-gerrit-operator/v2/api/v1"
+	gerritClient "github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit"
+)
+
+const defaultConfigMapKey = "plugin.jar"
+
+// pluginSource installs the plugin jar into Gerrit.
+// Hash identifies the installed jar, the plugin is re-installed when it changes.
+type pluginSource struct {
+	hash    string
+	install func(cl gerritClient.ClientInterface) error
+}
+
+// newPluginSource builds the source of the plugin jar, exactly one source must be set in the spec.
+func newPluginSource(ctx context.Context, k8sClient client.Client, instance *gerritApi.GerritPlugin) (*pluginSource, error) {
+	src := instance.Spec.Source
+	name := instance.Spec.Name
+
+	set := 0
+
+	for _, ok := range []bool{src.URL != "", src.ConfigMap != nil, src.Path != ""} {
+		if ok {
+			set++
+		}
+	}
+
+	if set != 1 {
+		return nil, errors.New("exactly one of url, configMap and path must be set in the plugin source")
+	}
+
+	switch {
+	case src.URL != "":
+		return &pluginSource{
+			hash: hashOf([]byte("url:" + src.URL)),
+			install: func(cl gerritClient.ClientInterface) error {
+				return cl.InstallPlugin(name, src.URL)
+			},
+		}, nil
+	case src.Path != "":
+		return &pluginSource{
+			hash: hashOf([]byte("path:" + src.Path)),
+			install: func(cl gerritClient.ClientInterface) error {
+				return cl.InstallPlugin(name, "file://"+src.Path)
+			},
+		}, nil
+	default:
+		jar, err := configMapJar(ctx, k8sClient, instance.Namespace, src.ConfigMap)
+		if err != nil {
+			return nil, err
+		}
+
+		return &pluginSource{
+			hash: hashOf(jar),
+			install: func(cl gerritClient.ClientInterface) error {
+				return cl.UploadPlugin(name, jar)
+			},
+		}, nil
+	}
+}
+
+func configMapJar(ctx context.Context, k8sClient client.Client, namespace string, source *gerritApi.ConfigMapPluginSource) ([]byte, error) {
+	key := source.Key
+	if key == "" {
+		key = defaultConfigMapKey
+	}
+
+	var cm coreV1.ConfigMap
+	if err := k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: source.Name}, &cm); err != nil {
+		return nil, errors.Wrapf(err, "unable to get ConfigMap %s", source.Name)
+	}
+
+	if jar, ok := cm.BinaryData[key]; ok {
+		return jar, nil
+	}
+
+	if jar, ok := cm.Data[key]; ok {
+		return []byte(jar), nil
+	}
+
+	return nil, errors.Errorf("ConfigMap %s does not contain key %s", source.Name, key)
+}
+
+// configHash returns the hash of the plugin configuration stored in the ConfigMap, it is empty if there is no configuration.
+func configHash(ctx context.Context, k8sClient client.Client, instance *gerritApi.GerritPlugin) (string, error) {
+	if instance.Spec.ConfigMapName == "" {
+		return "", nil
+	}
+
+	var cm coreV1.ConfigMap
+	if err := k8sClient.Get(ctx, types.NamespacedName{Namespace: instance.Namespace, Name: instance.Spec.ConfigMapName}, &cm); err != nil {
+		return "", errors.Wrapf(err, "unable to get ConfigMap %s", instance.Spec.ConfigMapName)
+	}
+
+	keys := make([]string, 0, len(cm.Data)+len(cm.BinaryData))
+	for k := range cm.Data {
+		keys = append(keys, k)
+	}
+
+	for k := range cm.BinaryData {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	h := sha256.New()
+
+	for _, k := range keys {
+		h.Write([]byte(k))
+		h.Write([]byte(cm.Data[k]))
+		h.Write(cm.BinaryData[k])
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func hashOf(data []byte) string {
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
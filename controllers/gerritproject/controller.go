@@ -11,6 +11,7 @@ import (
 	"github.com/pkg/errors"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -23,6 +24,7 @@ import (
 	gerritClient "github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit"
 	"github.com/epam/edp-gerrit-operator/v2/pkg/service/gerrit"
 	"github.com/epam/edp-gerrit-operator/v2/pkg/service/platform"
+	"github.com/epam/edp-gerrit-operator/v2/pkg/tracing"
 )
 
 const (
@@ -33,9 +35,10 @@ const (
 )
 
 type Reconcile struct {
-	client  client.Client
-	service gerrit.Interface
-	log     logr.Logger
+	client   client.Client
+	service  gerrit.Interface
+	log      logr.Logger
+	recorder record.EventRecorder
 }
 
 func NewReconcile(k8sClient client.Client, scheme *runtime.Scheme, log logr.Logger) (*Reconcile, error) {
@@ -56,11 +59,18 @@ func (r *Reconcile) SetupWithManager(mgr ctrl.Manager, syncInterval time.Duratio
 		UpdateFunc: isSpecUpdated,
 	}
 
-	go r.syncBackendProjects(syncInterval)
+	r.recorder = mgr.GetEventRecorderFor("gerrit-project")
+
+	// the sync is disabled by the zero or negative interval
+	if syncInterval > 0 {
+		if err := mgr.Add(&backendSyncer{reconciler: r, interval: syncInterval}); err != nil {
+			return fmt.Errorf("failed to add GerritProject syncer: %w", err)
+		}
+	}
 
 	err := ctrl.NewControllerManagedBy(mgr).
 		For(&gerritApi.GerritProject{}, builder.WithPredicates(pred)).
-		Complete(r)
+		Complete(tracing.NewReconciler("GerritProject", r))
 	if err != nil {
 		return fmt.Errorf("failed to setup GerritProject controller: %w", err)
 	}
@@ -79,7 +89,7 @@ func isSpecUpdated(e event.UpdateEvent) bool {
 		return false
 	}
 
-	return !reflect.DeepEqual(oo.Spec, no.Spec) ||
+	return !reflect.DeepEqual(oo.Spec, no.Spec) || helper.IsReconcileModeUpdated(e) ||
 		(oo.GetDeletionTimestamp().IsZero() && !no.GetDeletionTimestamp().IsZero())
 }
 
@@ -101,22 +111,35 @@ func (r *Reconcile) Reconcile(ctx context.Context, request reconcile.Request) (r
 		return reconcile.Result{}, errors.Wrap(err, "unable to get GerritProject instance")
 	}
 
+	tracing.SetAttributes(ctx, tracing.GerritInstance.String(instance.Spec.OwnerName),
+		tracing.GerritProject.String(instance.Spec.Name))
+
+	if helper.IsReconcilePaused(&instance) {
+		reqLogger.Info("Reconciliation of GerritProject is paused")
+		return
+	}
+
 	defer func() {
 		if err := r.client.Status().Update(context.Background(), &instance); err != nil {
 			reqLogger.Error(err, "unable to update instance status")
 		}
 	}()
 
+	if helper.IsDryRun(&instance) {
+		defer setDryRunStatus(&instance, instance.Status.DeepCopy())
+	}
+
 	if err := r.tryToReconcile(ctx, &instance); err != nil {
 		reqLogger.Error(err, "unable to reconcile GerritProject")
 		instance.Status.Value = err.Error()
+		helper.RecordWarning(r.recorder, &instance, helper.EventReasonFailed, err)
 
 		return reconcile.Result{RequeueAfter: requeueTime}, nil
 	}
 
 	instance.Status.Value = helper.StatusOK
 
-	return
+	return reconcile.Result{RequeueAfter: helper.DriftCheckInterval()}, nil
 }
 
 func (r *Reconcile) tryToReconcile(ctx context.Context, instance *gerritApi.GerritProject) error {
@@ -125,7 +148,11 @@ func (r *Reconcile) tryToReconcile(ctx context.Context, instance *gerritApi.Gerr
 		return errors.Wrap(err, "unable to init gerrit client")
 	}
 
-	_, err = cl.GetProject(instance.Spec.Name)
+	defer func() {
+		instance.Status.PlannedActions = gerritClient.PlannedActions(cl)
+	}()
+
+	live, err := cl.GetProject(instance.Spec.Name)
 	if err != nil && !gerritClient.IsErrDoesNotExist(err) {
 		return errors.Wrap(err, "unable to get project")
 	}
@@ -142,34 +169,115 @@ func (r *Reconcile) tryToReconcile(ctx context.Context, instance *gerritApi.Gerr
 		SubmitType:        instance.Spec.SubmitType,
 	}
 
-	if gerritClient.IsErrDoesNotExist(err) {
-		if err := cl.CreateProject(&prj); err != nil {
-			return errors.Wrap(err, "unable to create gerrit project")
+	exists := !gerritClient.IsErrDoesNotExist(err)
+
+	if !exists {
+		if err := r.createProject(cl, instance, &prj); err != nil {
+			return err
 		}
 	} else {
-		if err := cl.UpdateProject(&prj); err != nil {
-			return errors.Wrap(err, "unable to update project")
+		drift := projectDrift(instance, live)
+		applied := helper.NeedsApply(instance, instance.Status.Conditions, drift)
+
+		if applied {
+			if err := cl.UpdateProject(&prj); err != nil {
+				return errors.Wrap(err, "unable to update project")
+			}
+
+			helper.RecordEvent(r.recorder, instance, helper.EventReasonUpdated, "Project %s has been updated", prj.Name)
 		}
+
+		helper.SetDriftCondition(r.recorder, instance, &instance.Status.Conditions, drift, applied)
 	}
 
 	if err := helper.TryToDelete(ctx, r.client, instance, finalizerName,
-		r.makeDeletionFunc(cl, instance.Spec.Name)); err != nil {
+		r.makeDeletionFunc(ctx, cl, instance, exists)); err != nil {
 		return errors.Wrap(err, "error during TryToDelete")
 	}
 
 	return nil
 }
 
-func (*Reconcile) makeDeletionFunc(gc gerritClient.ClientInterface, projectName string) func() error {
+// createProject creates the missing project. The project deleted in Gerrit after the spec has been applied is a drift,
+// so it is recreated only if the drift correction is enabled, and it is never recreated for the deleted resource.
+func (r *Reconcile) createProject(cl gerritClient.ClientInterface, instance *gerritApi.GerritProject, prj *gerritClient.Project) error {
+	var drift helper.Drift
+
+	drift.Compare("exists", true, false)
+
+	applied := instance.GetDeletionTimestamp().IsZero() && helper.NeedsApply(instance, instance.Status.Conditions, drift)
+
+	if applied {
+		if err := cl.CreateProject(prj); err != nil {
+			return errors.Wrap(err, "unable to create gerrit project")
+		}
+
+		helper.RecordEvent(r.recorder, instance, helper.EventReasonCreated, "Project %s has been created", prj.Name)
+	}
+
+	helper.SetDriftCondition(r.recorder, instance, &instance.Status.Conditions, drift, applied)
+
+	return nil
+}
+
+// makeDeletionFunc returns the function that deletes the project, the project that does not exist in Gerrit is skipped.
+func (r *Reconcile) makeDeletionFunc(ctx context.Context, gc gerritClient.ClientInterface, instance *gerritApi.GerritProject,
+	exists bool,
+) func() error {
 	return func() error {
-		if err := gc.DeleteProject(projectName); err != nil {
+		if !exists {
+			return nil
+		}
+
+		gerritInstance, err := helper.ResolveGerritOwner(ctx, r.client, instance, instance.Spec.OwnerName)
+		if err != nil {
+			return errors.Wrap(err, "unable to get instance owner")
+		}
+
+		// the delete-project plugin provides the project deletion, the resource is removed without it
+		if gerritInstance.IsFeatureUnsupported(gerritApi.ConditionDeleteProjectSupported) {
+			helper.RecordWarning(r.recorder, instance, helper.EventReasonFailed,
+				errors.Errorf("project %s is not deleted from Gerrit: the delete-project plugin is not enabled", instance.Spec.Name))
+
+			return nil
+		}
+
+		if err := gc.DeleteProject(instance.Spec.Name); err != nil {
 			return errors.Wrap(err, "unable to delete project")
 		}
 
+		helper.RecordEvent(r.recorder, instance, helper.EventReasonDeleted, "Project %s has been deleted", instance.Spec.Name)
+
 		return nil
 	}
 }
 
+// projectDrift compares the project settings that are updated by the operator with the project in Gerrit.
+func projectDrift(instance *gerritApi.GerritProject, live *gerritClient.Project) helper.Drift {
+	var drift helper.Drift
+
+	drift.Compare("description", instance.Spec.Description, live.Description)
+
+	if instance.Spec.Parent != "" {
+		drift.Compare("parent", instance.Spec.Parent, live.Parent)
+	}
+
+	return drift
+}
+
+// setDryRunStatus restores the status changed by the dry-run reconciliation, only the planned actions and the result are kept.
+func setDryRunStatus(instance *gerritApi.GerritProject, status *gerritApi.GerritProjectStatus) {
+	status.PlannedActions = instance.Status.PlannedActions
+	status.Value = instance.Status.Value
+
+	if status.Value == helper.StatusOK {
+		status.Value = helper.StatusDryRun
+	}
+
+	instance.Status = *status
+}
+
+// SyncInterval returns the interval of the import of Gerrit projects, 0 disables the import.
 func SyncInterval() time.Duration {
 	value, ok := os.LookupEnv(syncIntervalEnv)
 	if !ok {
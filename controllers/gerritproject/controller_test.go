@@ -8,8 +8,10 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	coreV1Api "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -21,6 +23,7 @@ import (
 	commonmock "github.com/epam/edp-common/pkg/mock"
 
 	gerritApi "github.com/epam/edp-gerrit-operator/v2/api/v1"
+	"github.com/epam/edp-gerrit-operator/v2/controllers/helper"
 	gmock "github.com/epam/edp-gerrit-operator/v2/mock/gerrit"
 	gerritClient "github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit"
 	gerritClientMocks "github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit/mocks"
@@ -47,7 +50,7 @@ func TestReconcile_Reconcile_CreateProject(t *testing.T) {
 	clientMock := gerritClientMocks.ClientInterface{}
 
 	clientMock.On("GetProject", prj.Spec.Name).Return(nil, gerritClient.DoesNotExistError("")).Once()
-	clientMock.On("CreateProject", &gerritClient.Project{Name: prj.Spec.Name}).Return(nil).Once()
+	clientMock.On("CreateProject", &gerritClient.Project{Name: prj.Spec.Name}).Return(errors.New("create fatal")).Once()
 	serviceMock.On("GetRestClient", &g).Return(&clientMock, nil)
 
 	logger := commonmock.NewLogr()
@@ -68,12 +71,17 @@ func TestReconcile_Reconcile_CreateProject(t *testing.T) {
 	loggerSink, ok := logger.GetSink().(*commonmock.Logger)
 	assert.True(t, ok)
 
-	if err := loggerSink.LastError(); err != nil {
-		t.Fatalf("%+v", err)
+	err := loggerSink.LastError()
+	if err == nil {
+		t.Fatal("no error logged")
+	}
+
+	if !strings.Contains(err.Error(), "create fatal") {
+		t.Fatalf("wrong error returnded: %s", err.Error())
 	}
 
 	clientMock.On("GetProject", prj.Spec.Name).Return(nil, gerritClient.DoesNotExistError("")).Once()
-	clientMock.On("CreateProject", &gerritClient.Project{Name: prj.Spec.Name}).Return(errors.New("create fatal")).Once()
+	clientMock.On("CreateProject", &gerritClient.Project{Name: prj.Spec.Name}).Return(nil).Once()
 
 	if _, err := rcn.Reconcile(context.Background(),
 		reconcile.Request{NamespacedName: types.NamespacedName{
@@ -82,14 +90,9 @@ func TestReconcile_Reconcile_CreateProject(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	err := loggerSink.LastError()
-	if err == nil {
-		t.Fatal("no error logged")
-	}
-
-	if !strings.Contains(err.Error(), "create fatal") {
-		t.Fatalf("wrong error returnded: %s", err.Error())
-	}
+	var created gerritApi.GerritProject
+	require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Name: prj.Name, Namespace: prj.Namespace}, &created))
+	assert.Equal(t, helper.StatusOK, created.Status.Value)
 
 	clientMock.On("GetProject", prj.Spec.Name).
 		Return(nil, errors.New("unknown get fatal")).Once()
@@ -210,6 +213,65 @@ func TestReconcile_Reconcile_UpdateProject(t *testing.T) {
 	clientMock.AssertExpectations(t)
 }
 
+func TestReconcile_Reconcile_DeleteProjectUnsupported(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilRuntime.Must(gerritApi.AddToScheme(scheme))
+	utilRuntime.Must(coreV1Api.AddToScheme(scheme))
+
+	prj := gerritApi.GerritProject{
+		ObjectMeta: metaV1.ObjectMeta{
+			Namespace:  "ns",
+			Name:       "prj1",
+			Finalizers: []string{finalizerName},
+			DeletionTimestamp: &metaV1.Time{
+				Time: time.Now(),
+			},
+		},
+		Spec: gerritApi.GerritProjectSpec{
+			Name: "sprj1",
+		},
+	}
+
+	g := gerritApi.Gerrit{
+		ObjectMeta: metaV1.ObjectMeta{
+			Namespace: prj.Namespace,
+			Name:      "ger1",
+		},
+		Status: gerritApi.GerritStatus{
+			Conditions: []metaV1.Condition{{
+				Type:   gerritApi.ConditionDeleteProjectSupported,
+				Status: metaV1.ConditionFalse,
+				Reason: "PluginNotEnabled",
+			}},
+		},
+	}
+
+	cl := fake.NewClientBuilder().WithStatusSubresource(&gerritApi.Gerrit{}, &gerritApi.GerritProject{}).WithScheme(scheme).WithRuntimeObjects(&prj, &g).Build()
+	serviceMock := gmock.Interface{}
+	clientMock := gerritClientMocks.ClientInterface{}
+
+	clientMock.On("GetProject", prj.Spec.Name).Return(&gerritClient.Project{Name: prj.Spec.Name}, nil)
+	clientMock.On("UpdateProject", &gerritClient.Project{Name: prj.Spec.Name}).Return(nil)
+	serviceMock.On("GetRestClient", mock.Anything).Return(&clientMock, nil)
+
+	rcn := Reconcile{
+		client:  cl,
+		log:     commonmock.NewLogr(),
+		service: &serviceMock,
+	}
+
+	_, err := rcn.Reconcile(context.Background(),
+		reconcile.Request{NamespacedName: types.NamespacedName{
+			Name: prj.Name, Namespace: prj.Namespace,
+		}})
+	require.NoError(t, err)
+
+	clientMock.AssertNotCalled(t, "DeleteProject", prj.Spec.Name)
+
+	err = cl.Get(context.Background(), types.NamespacedName{Name: prj.Name, Namespace: prj.Namespace}, &gerritApi.GerritProject{})
+	assert.True(t, k8sErrors.IsNotFound(err))
+}
+
 func TestIsSpecUpdated(t *testing.T) {
 	prj := gerritApi.GerritProject{
 		ObjectMeta: metaV1.ObjectMeta{
@@ -319,3 +381,233 @@ func TestReconcile_Reconcile_FailureGetClient(t *testing.T) {
 
 	serviceMock.AssertExpectations(t)
 }
+
+func TestReconcile_Reconcile_Drift(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilRuntime.Must(gerritApi.AddToScheme(scheme))
+
+	g := gerritApi.Gerrit{
+		ObjectMeta: metaV1.ObjectMeta{Namespace: "ns", Name: "ger1"},
+	}
+
+	// the spec has been applied before, the description has been changed in Gerrit
+	prj := gerritApi.GerritProject{
+		ObjectMeta: metaV1.ObjectMeta{Namespace: g.Namespace, Name: "prj1", Finalizers: []string{finalizerName}},
+		Spec:       gerritApi.GerritProjectSpec{Name: "sprj1", Description: "backend"},
+		Status: gerritApi.GerritProjectStatus{
+			Value: helper.StatusOK,
+			Conditions: []metaV1.Condition{{
+				Type:               helper.ConditionDrifted,
+				Status:             metaV1.ConditionFalse,
+				Reason:             helper.ReasonInSync,
+				LastTransitionTime: metaV1.Now(),
+			}},
+		},
+	}
+
+	cl := fake.NewClientBuilder().WithStatusSubresource(&gerritApi.GerritProject{}).WithScheme(scheme).
+		WithRuntimeObjects(&prj, &g).Build()
+
+	serviceMock := gmock.Interface{}
+	clientMock := gerritClientMocks.ClientInterface{}
+
+	serviceMock.On("GetRestClient", &g).Return(&clientMock, nil)
+	clientMock.On("GetProject", "sprj1").Return(&gerritClient.Project{Name: "sprj1", Description: "changed in UI"}, nil)
+
+	rcn := Reconcile{
+		client:  cl,
+		log:     commonmock.NewLogr(),
+		service: &serviceMock,
+	}
+
+	nn := types.NamespacedName{Name: prj.Name, Namespace: prj.Namespace}
+
+	res, err := rcn.Reconcile(context.Background(), reconcile.Request{NamespacedName: nn})
+	require.NoError(t, err)
+	assert.Equal(t, helper.DriftCheckInterval(), res.RequeueAfter)
+
+	var updated gerritApi.GerritProject
+	require.NoError(t, cl.Get(context.Background(), nn, &updated))
+
+	require.Len(t, updated.Status.Conditions, 1)
+	assert.Equal(t, metaV1.ConditionTrue, updated.Status.Conditions[0].Status)
+	assert.Equal(t, "description: changed in UI, expected backend", updated.Status.Conditions[0].Message)
+	clientMock.AssertNotCalled(t, "UpdateProject", &gerritClient.Project{Name: "sprj1", Description: "backend"})
+
+	updated.Annotations = map[string]string{helper.DriftCorrectionAnnotation: "true"}
+	require.NoError(t, cl.Update(context.Background(), &updated))
+
+	clientMock.On("UpdateProject", &gerritClient.Project{Name: "sprj1", Description: "backend"}).Return(nil).Once()
+
+	_, err = rcn.Reconcile(context.Background(), reconcile.Request{NamespacedName: nn})
+	require.NoError(t, err)
+
+	require.NoError(t, cl.Get(context.Background(), nn, &updated))
+	assert.Equal(t, metaV1.ConditionFalse, updated.Status.Conditions[0].Status)
+	assert.Equal(t, helper.EventReasonDriftCorrected, updated.Status.Conditions[0].Reason)
+	clientMock.AssertExpectations(t)
+}
+
+func TestReconcile_Reconcile_DeletedInGerrit(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilRuntime.Must(gerritApi.AddToScheme(scheme))
+
+	g := gerritApi.Gerrit{
+		ObjectMeta: metaV1.ObjectMeta{Namespace: "ns", Name: "ger1"},
+	}
+
+	// the spec has been applied before, the project has been deleted in Gerrit
+	prj := gerritApi.GerritProject{
+		ObjectMeta: metaV1.ObjectMeta{Namespace: g.Namespace, Name: "prj1", Finalizers: []string{finalizerName}},
+		Spec:       gerritApi.GerritProjectSpec{Name: "sprj1"},
+		Status: gerritApi.GerritProjectStatus{
+			Value: helper.StatusOK,
+			Conditions: []metaV1.Condition{{
+				Type:               helper.ConditionDrifted,
+				Status:             metaV1.ConditionFalse,
+				Reason:             helper.ReasonInSync,
+				LastTransitionTime: metaV1.Now(),
+			}},
+		},
+	}
+
+	cl := fake.NewClientBuilder().WithStatusSubresource(&gerritApi.GerritProject{}).WithScheme(scheme).
+		WithRuntimeObjects(&prj, &g).Build()
+
+	serviceMock := gmock.Interface{}
+	clientMock := gerritClientMocks.ClientInterface{}
+
+	serviceMock.On("GetRestClient", &g).Return(&clientMock, nil)
+	clientMock.On("GetProject", "sprj1").Return(nil, gerritClient.DoesNotExistError("not found"))
+
+	rcn := Reconcile{
+		client:  cl,
+		log:     commonmock.NewLogr(),
+		service: &serviceMock,
+	}
+
+	nn := types.NamespacedName{Name: prj.Name, Namespace: prj.Namespace}
+
+	res, err := rcn.Reconcile(context.Background(), reconcile.Request{NamespacedName: nn})
+	require.NoError(t, err)
+	assert.Equal(t, helper.DriftCheckInterval(), res.RequeueAfter)
+
+	var updated gerritApi.GerritProject
+	require.NoError(t, cl.Get(context.Background(), nn, &updated))
+
+	require.Len(t, updated.Status.Conditions, 1)
+	assert.Equal(t, metaV1.ConditionTrue, updated.Status.Conditions[0].Status)
+	assert.Equal(t, "exists: false, expected true", updated.Status.Conditions[0].Message)
+	clientMock.AssertNotCalled(t, "CreateProject", &gerritClient.Project{Name: "sprj1"})
+
+	updated.Annotations = map[string]string{helper.DriftCorrectionAnnotation: "true"}
+	require.NoError(t, cl.Update(context.Background(), &updated))
+
+	clientMock.On("CreateProject", &gerritClient.Project{Name: "sprj1"}).Return(nil).Once()
+
+	_, err = rcn.Reconcile(context.Background(), reconcile.Request{NamespacedName: nn})
+	require.NoError(t, err)
+
+	require.NoError(t, cl.Get(context.Background(), nn, &updated))
+	assert.Equal(t, metaV1.ConditionFalse, updated.Status.Conditions[0].Status)
+	assert.Equal(t, helper.EventReasonDriftCorrected, updated.Status.Conditions[0].Reason)
+	clientMock.AssertExpectations(t)
+}
+
+func TestReconcile_Reconcile_ReconcileMode(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilRuntime.Must(gerritApi.AddToScheme(scheme))
+
+	g := gerritApi.Gerrit{
+		ObjectMeta: metaV1.ObjectMeta{Namespace: "ns", Name: "ger1"},
+	}
+
+	tests := []struct {
+		name              string
+		annotation        string
+		deletionTimestamp *metaV1.Time
+		prepare           func(cl *gerritClientMocks.ClientInterface)
+		wantStatus        gerritApi.GerritProjectStatus
+		wantFinalizers    []string
+	}{
+		{
+			name:           "paused",
+			annotation:     helper.ReconcilePaused,
+			prepare:        func(cl *gerritClientMocks.ClientInterface) {},
+			wantStatus:     gerritApi.GerritProjectStatus{Value: helper.StatusOK, Branches: []string{"master"}},
+			wantFinalizers: []string{finalizerName},
+		},
+		{
+			name:       "dry-run",
+			annotation: helper.ReconcileDryRun,
+			prepare: func(cl *gerritClientMocks.ClientInterface) {
+				cl.On("GetProject", "sprj1").Return(nil, gerritClient.DoesNotExistError(""))
+			},
+			wantStatus: gerritApi.GerritProjectStatus{
+				Value:          helper.StatusDryRun,
+				Branches:       []string{"master"},
+				PlannedActions: []string{"create project sprj1"},
+			},
+			wantFinalizers: []string{finalizerName},
+		},
+		{
+			name:              "dry-run deletion",
+			annotation:        helper.ReconcileDryRun,
+			deletionTimestamp: &metaV1.Time{Time: time.Now()},
+			prepare: func(cl *gerritClientMocks.ClientInterface) {
+				cl.On("GetProject", "sprj1").Return(&gerritClient.Project{Name: "sprj1"}, nil)
+			},
+			wantStatus: gerritApi.GerritProjectStatus{
+				Value:          helper.StatusDryRun,
+				Branches:       []string{"master"},
+				PlannedActions: []string{"delete project sprj1"},
+			},
+			wantFinalizers: []string{finalizerName},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			prj := gerritApi.GerritProject{
+				ObjectMeta: metaV1.ObjectMeta{
+					Namespace:         g.Namespace,
+					Name:              "prj1",
+					Annotations:       map[string]string{helper.ReconcileAnnotation: tt.annotation},
+					Finalizers:        []string{finalizerName},
+					DeletionTimestamp: tt.deletionTimestamp,
+				},
+				Spec:   gerritApi.GerritProjectSpec{Name: "sprj1"},
+				Status: gerritApi.GerritProjectStatus{Value: helper.StatusOK, Branches: []string{"master"}},
+			}
+
+			cl := fake.NewClientBuilder().WithStatusSubresource(&gerritApi.GerritProject{}).WithScheme(scheme).
+				WithRuntimeObjects(&prj, &g).Build()
+
+			serviceMock := gmock.Interface{}
+			clientMock := gerritClientMocks.ClientInterface{}
+
+			serviceMock.On("GetRestClient", &g).Return(&clientMock, nil).Maybe()
+			tt.prepare(&clientMock)
+
+			rcn := Reconcile{
+				client:  cl,
+				log:     commonmock.NewLogr(),
+				service: &serviceMock,
+			}
+
+			nn := types.NamespacedName{Name: prj.Name, Namespace: prj.Namespace}
+
+			_, err := rcn.Reconcile(context.Background(), reconcile.Request{NamespacedName: nn})
+			require.NoError(t, err)
+
+			var updated gerritApi.GerritProject
+			require.NoError(t, cl.Get(context.Background(), nn, &updated))
+
+			assert.Equal(t, tt.wantStatus, updated.Status)
+			assert.Equal(t, tt.wantFinalizers, updated.Finalizers)
+
+			clientMock.AssertExpectations(t)
+		})
+	}
+}
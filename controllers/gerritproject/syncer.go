@@ -3,87 +3,229 @@ package gerritproject
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	utilErrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	gerritApi "github.com/epam/edp-gerrit-operator/v2/api/v1"
+	"github.com/epam/edp-gerrit-operator/v2/controllers/helper"
 	gerritClient "github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit"
+	"github.com/epam/edp-gerrit-operator/v2/pkg/manifest"
+	"github.com/epam/edp-gerrit-operator/v2/pkg/metrics"
+	"github.com/epam/edp-gerrit-operator/v2/pkg/tracing"
 )
 
-const syncRetries = 3
+const (
+	syncRetries       = 3
+	conditionOrphaned = "Orphaned"
 
-func (r *Reconcile) syncBackendProjects(interval time.Duration) {
-	ticker := time.Tick(interval)
+	eventReasonImported = "Imported"
+	eventReasonOrphaned = "Orphaned"
+)
 
-	for range ticker {
-		for i := 0; i < syncRetries; i++ {
-			if err := r.syncBackendProjectsTick(); err != nil {
-				r.log.Error(err, "unable to sync gerrit projects")
-				continue
-			}
+// backendSyncer periodically imports Gerrit projects into GerritProject CRs.
+// It runs only on the leader, so operator replicas do not create the same CRs concurrently.
+type backendSyncer struct {
+	reconciler *Reconcile
+	interval   time.Duration
+}
 
-			break
+var _ manager.LeaderElectionRunnable = (*backendSyncer)(nil)
+
+func (*backendSyncer) NeedLeaderElection() bool {
+	return true
+}
+
+func (s *backendSyncer) Start(ctx context.Context) error {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := s.reconciler.syncBackendProjectsTick(ctx); err != nil && ctx.Err() == nil {
+				s.reconciler.log.Error(err, "unable to sync gerrit projects")
+			}
 		}
 	}
 }
 
-func (r *Reconcile) syncBackendProjectsTick() error {
-	var (
-		gerritList        gerritApi.GerritList
-		gerritProjectList gerritApi.GerritProjectList
-		ctx               = context.Background()
-	)
-
+// syncBackendProjectsTick syncs all Gerrit instances concurrently.
+// A failed instance does not stop the sync of the others, its error is reported on its status.
+func (r *Reconcile) syncBackendProjectsTick(ctx context.Context) error {
+	var gerritList gerritApi.GerritList
 	if err := r.client.List(ctx, &gerritList); err != nil {
 		return errors.Wrap(err, "unable to list gerrits")
 	}
 
-	if err := r.client.List(ctx, &gerritProjectList); err != nil {
-		return errors.Wrap(err, "unable to list gerrit projects")
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+
+	for i := range gerritList.Items {
+		if helper.IsReconcilePaused(&gerritList.Items[i]) {
+			r.log.Info("Gerrit projects sync is paused", "gerrit", gerritList.Items[i].Name)
+			continue
+		}
+
+		wg.Add(1)
+
+		go func(gr *gerritApi.Gerrit) {
+			defer wg.Done()
+
+			if err := r.syncGerrit(ctx, gr); err != nil {
+				mu.Lock()
+				errs = append(errs, errors.Wrapf(err, "unable to sync gerrit instance: %s", gr.Name))
+				mu.Unlock()
+			}
+		}(&gerritList.Items[i])
 	}
 
-	for i := 0; i < len(gerritList.Items); i++ {
-		if err := r.syncGerritInstance(ctx, &gerritList.Items[i], gerritProjectList.Items); err != nil {
-			return errors.Wrapf(err, "unable to sync gerrit instance: %s", gerritList.Items[i].Name)
+	wg.Wait()
+
+	return utilErrors.NewAggregate(errs)
+}
+
+// syncGerrit syncs the Gerrit instance with retries and stores the result in its status.
+func (r *Reconcile) syncGerrit(ctx context.Context, gr *gerritApi.Gerrit) error {
+	var err error
+
+	start := time.Now()
+	ctx, span := tracing.Start(ctx, "Sync Gerrit projects", tracing.GerritInstance.String(gr.Name))
+
+	defer func() {
+		metrics.ObserveProjectSync(gr.Name, time.Since(start), err)
+		tracing.End(span, err)
+	}()
+
+	for i := 0; i < syncRetries; i++ {
+		if err = r.syncGerritInstance(ctx, gr); err == nil {
+			break
 		}
+
+		if ctx.Err() != nil {
+			return errors.Wrap(ctx.Err(), "gerrit projects sync is canceled")
+		}
+
+		r.log.Error(err, "unable to sync gerrit projects", "gerrit", gr.Name, "attempt", i+1)
+	}
+
+	if statusErr := r.setSyncStatus(ctx, gr, err); statusErr != nil {
+		r.log.Error(statusErr, "unable to update gerrit sync status", "gerrit", gr.Name)
+	}
+
+	return err
+}
+
+func (r *Reconcile) setSyncStatus(ctx context.Context, gr *gerritApi.Gerrit, syncErr error) error {
+	patch := client.MergeFrom(gr.DeepCopy())
+
+	gr.Status.LastProjectSyncTime = metaV1.Now()
+	gr.Status.ProjectSyncError = ""
+
+	if syncErr != nil {
+		gr.Status.ProjectSyncError = syncErr.Error()
+	}
+
+	if err := r.client.Status().Patch(ctx, gr, patch); err != nil {
+		return errors.Wrap(err, "unable to patch gerrit status")
 	}
 
 	return nil
 }
 
-func (r *Reconcile) syncGerritInstance(ctx context.Context, gr *gerritApi.Gerrit,
-	allK8sGerritProjects []gerritApi.GerritProject,
-) error {
+func (r *Reconcile) syncGerritInstance(ctx context.Context, gr *gerritApi.Gerrit) error {
 	cl, err := r.service.GetRestClient(gr)
 	if err != nil {
 		return errors.Wrap(err, "unable to init gerrit client")
 	}
 
+	if ctxCl, ok := cl.(gerritClient.ContextClient); ok {
+		cl = ctxCl.WithContext(ctx)
+	}
+
+	filter, err := newProjectFilter(&gr.Spec.Sync)
+	if err != nil {
+		return err
+	}
+
 	backendProjects, err := cl.ListProjects("CODE")
 	if err != nil {
 		return errors.Wrap(err, "unable to list projects from gerrit")
 	}
 
-	k8sProjects := filterGerritProjectsByGerrit(gr, allK8sGerritProjects)
+	// the projects of the allowed namespaces reference the Gerrit as namespace/name
+	listOptions := []client.ListOption{client.InNamespace(gr.Namespace)}
+	if len(gr.Spec.AllowedNamespaces) > 0 {
+		listOptions = nil
+	}
+
+	var gerritProjectList gerritApi.GerritProjectList
+	if err := r.client.List(ctx, &gerritProjectList, listOptions...); err != nil {
+		return errors.Wrap(err, "unable to list gerrit projects")
+	}
+
+	k8sProjects := filterGerritProjectsByGerrit(gr, gerritProjectList.Items)
+	backendProjectNames := make(map[string]bool, len(backendProjects))
+	importedProjects := make([]gerritClient.Project, 0, len(backendProjects))
 
 	for _, backendProject := range backendProjects {
+		backendProjectNames[backendProject.Name] = true
+
 		k8sProject, ok := k8sProjects[backendProject.Name]
+		if ok && !filter.selects(k8sProject.Labels) {
+			continue
+		}
+
 		if !ok {
+			if !filter.matches(backendProject.Name) || !filter.selects(manifest.ProjectLabels(gr, &backendProject)) {
+				continue
+			}
+
 			k8sProject, err = r.createGerritProject(ctx, gr, &backendProject)
 			if err != nil {
 				return errors.Wrap(err, "unable to create gerrit project")
 			}
 		}
 
+		importedProjects = append(importedProjects, backendProject)
+
 		if err := r.syncProjectBranches(ctx, cl, k8sProject); err != nil {
 			return errors.Wrap(err, "unable to sync gerrit project branches")
 		}
 	}
 
+	if gr.Spec.Sync.ImportGroups {
+		if err := r.importGroups(ctx, cl, gr); err != nil {
+			return errors.Wrap(err, "unable to import gerrit groups")
+		}
+	}
+
+	if gr.Spec.Sync.ImportAccess {
+		if err := r.importProjectAccesses(ctx, cl, gr, importedProjects); err != nil {
+			return errors.Wrap(err, "unable to import gerrit project accesses")
+		}
+	}
+
+	if err := r.handleOrphanProjects(ctx, cl, gr, filter, k8sProjects, backendProjectNames); err != nil {
+		return errors.Wrap(err, "unable to handle orphan gerrit projects")
+	}
+
 	return nil
 }
 
@@ -109,6 +251,9 @@ func (r *Reconcile) syncProjectBranches(ctx context.Context, cl gerritClient.Cli
 		prj.Status.Branches = append(prj.Status.Branches, br.Ref)
 	}
 
+	// the project is back in Gerrit
+	meta.RemoveStatusCondition(&prj.Status.Conditions, conditionOrphaned)
+
 	if err := r.client.Status().Update(ctx, &prj); err != nil {
 		return errors.Wrap(err, "unable to update gerrit project")
 	}
@@ -119,36 +264,42 @@ func (r *Reconcile) syncProjectBranches(ctx context.Context, cl gerritClient.Cli
 func (r *Reconcile) createGerritProject(ctx context.Context, gr *gerritApi.Gerrit,
 	backendProject *gerritClient.Project,
 ) (*gerritApi.GerritProject, error) {
-	prj := gerritApi.GerritProject{
-		ObjectMeta: metaV1.ObjectMeta{
-			Name:      strings.ToLower(fmt.Sprintf("%s-%s", gr.Name, backendProject.SlugifyName())),
-			Namespace: gr.Namespace,
-		},
-		Spec: gerritApi.GerritProjectSpec{
-			Name:              backendProject.Name,
-			Parent:            backendProject.Parent,
-			Description:       backendProject.Description,
-			SubmitType:        backendProject.SubmitType,
-			Owners:            backendProject.Owners,
-			RejectEmptyCommit: backendProject.RejectEmptyCommit,
-			PermissionsOnly:   backendProject.PermissionsOnly,
-			CreateEmptyCommit: backendProject.CreateEmptyCommit,
-			Branches:          backendProject.Branches,
-			OwnerName:         gr.Name,
-		},
-	}
-
-	if err := r.client.Create(ctx, &prj); err != nil {
+	prj := manifest.Project(gr, backendProject)
+	prj.Labels = manifest.ProjectLabels(gr, backendProject)
+
+	err := r.client.Create(ctx, prj)
+	if err == nil {
+		metrics.AddProjectsImported(gr.Name, 1)
+		helper.RecordEvent(r.recorder, prj, eventReasonImported, "Project %s has been imported from Gerrit %s",
+			backendProject.Name, gr.Name)
+
+		return prj, nil
+	}
+
+	if !k8sErrors.IsAlreadyExists(err) {
 		return nil, errors.Wrap(err, "unable to create gerrit project")
 	}
 
-	return &prj, nil
+	// the CR is created by a previous sync attempt, but it is not reconciled yet
+	if err := r.client.Get(ctx, types.NamespacedName{Name: prj.Name, Namespace: prj.Namespace}, prj); err != nil {
+		return nil, errors.Wrap(err, "unable to get gerrit project")
+	}
+
+	return prj, nil
 }
 
 func filterGerritProjectsByGerrit(g *gerritApi.Gerrit, projects []gerritApi.GerritProject) map[string]*gerritApi.GerritProject {
 	result := make(map[string]*gerritApi.GerritProject)
 
 	for i := 0; i < len(projects); i++ {
+		if projects[i].Namespace != g.Namespace {
+			if strings.EqualFold(projects[i].Spec.OwnerName, g.Namespace+"/"+g.Name) && g.IsNamespaceAllowed(projects[i].Namespace) {
+				result[projects[i].Spec.Name] = &projects[i]
+			}
+
+			continue
+		}
+
 		for _, owner := range projects[i].OwnerReferences {
 			if owner.UID == g.UID && owner.Kind == g.Kind {
 				result[projects[i].Spec.Name] = &projects[i]
@@ -158,3 +309,148 @@ func filterGerritProjectsByGerrit(g *gerritApi.Gerrit, projects []gerritApi.Gerr
 
 	return result
 }
+
+// projectFilter selects the Gerrit projects that are imported by the syncer.
+// The regular expressions are matched against the project names, the selector against the labels of the CRs.
+type projectFilter struct {
+	include  *regexp.Regexp
+	exclude  *regexp.Regexp
+	selector labels.Selector
+}
+
+func newProjectFilter(syncSpec *gerritApi.GerritSyncSpec) (*projectFilter, error) {
+	var (
+		filter = projectFilter{selector: labels.Everything()}
+		err    error
+	)
+
+	if syncSpec.IncludeProjects != "" {
+		if filter.include, err = regexp.Compile(syncSpec.IncludeProjects); err != nil {
+			return nil, errors.Wrap(err, "unable to parse includeProjects")
+		}
+	}
+
+	if syncSpec.ExcludeProjects != "" {
+		if filter.exclude, err = regexp.Compile(syncSpec.ExcludeProjects); err != nil {
+			return nil, errors.Wrap(err, "unable to parse excludeProjects")
+		}
+	}
+
+	if syncSpec.ProjectSelector != nil {
+		if filter.selector, err = metaV1.LabelSelectorAsSelector(syncSpec.ProjectSelector); err != nil {
+			return nil, errors.Wrap(err, "unable to parse projectSelector")
+		}
+	}
+
+	return &filter, nil
+}
+
+func (f *projectFilter) matches(projectName string) bool {
+	if f.include != nil && !f.include.MatchString(projectName) {
+		return false
+	}
+
+	return f.exclude == nil || !f.exclude.MatchString(projectName)
+}
+
+func (f *projectFilter) selects(projectLabels map[string]string) bool {
+	return f.selector.Matches(labels.Set(projectLabels))
+}
+
+// handleOrphanProjects applies the orphan policy to the GerritProject CRs whose projects were deleted from Gerrit.
+func (r *Reconcile) handleOrphanProjects(ctx context.Context, cl gerritClient.ClientInterface, gr *gerritApi.Gerrit,
+	filter *projectFilter, k8sProjects map[string]*gerritApi.GerritProject, backendProjectNames map[string]bool,
+) error {
+	policy := gr.Spec.Sync.OrphanPolicy
+	if policy != gerritApi.OrphanPolicyMark && policy != gerritApi.OrphanPolicyDelete {
+		return nil
+	}
+
+	for name, k8sProject := range k8sProjects {
+		// a CR that has not been applied successfully may refer to a project that is not created yet
+		if backendProjectNames[name] || k8sProject.Status.Value != helper.StatusOK ||
+			!k8sProject.GetDeletionTimestamp().IsZero() || !filter.selects(k8sProject.Labels) {
+			continue
+		}
+
+		// the operator does not touch the paused CRs and the dry-run CRs only plan changes
+		if helper.IsReconcilePaused(k8sProject) || helper.IsDryRun(k8sProject) {
+			continue
+		}
+
+		// the project can be missing in the list because of its type, so check it directly
+		if _, err := cl.GetProject(name); err == nil {
+			continue
+		} else if !gerritClient.IsErrDoesNotExist(err) {
+			return errors.Wrapf(err, "unable to get project %s", name)
+		}
+
+		r.log.Info("Gerrit project does not exist anymore", "project", name, "policy", policy)
+
+		if policy == gerritApi.OrphanPolicyDelete {
+			if err := r.deleteOrphanProject(ctx, k8sProject); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if err := r.markOrphanProject(ctx, k8sProject); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Reconcile) markOrphanProject(ctx context.Context, k8sProject *gerritApi.GerritProject) error {
+	var prj gerritApi.GerritProject
+	if err := r.client.Get(ctx, types.NamespacedName{Name: k8sProject.Name, Namespace: k8sProject.Namespace}, &prj); err != nil {
+		return errors.Wrap(err, "unable to get gerrit project")
+	}
+
+	changed := meta.SetStatusCondition(&prj.Status.Conditions, metaV1.Condition{
+		Type:               conditionOrphaned,
+		Status:             metaV1.ConditionTrue,
+		Reason:             "ProjectNotFound",
+		Message:            fmt.Sprintf("Project %s does not exist in Gerrit", prj.Spec.Name),
+		ObservedGeneration: prj.Generation,
+	})
+	if !changed {
+		return nil
+	}
+
+	if err := r.client.Status().Update(ctx, &prj); err != nil {
+		return errors.Wrap(err, "unable to mark gerrit project as orphaned")
+	}
+
+	helper.RecordWarning(r.recorder, &prj, eventReasonOrphaned,
+		errors.Errorf("project %s does not exist in Gerrit", prj.Spec.Name))
+
+	return nil
+}
+
+// deleteOrphanProject deletes the CR without touching Gerrit, so the finalizer is removed first.
+func (r *Reconcile) deleteOrphanProject(ctx context.Context, k8sProject *gerritApi.GerritProject) error {
+	var prj gerritApi.GerritProject
+	if err := r.client.Get(ctx, types.NamespacedName{Name: k8sProject.Name, Namespace: k8sProject.Namespace}, &prj); err != nil {
+		return errors.Wrap(err, "unable to get gerrit project")
+	}
+
+	if helper.ContainsString(prj.Finalizers, finalizerName) {
+		prj.Finalizers = helper.RemoveString(prj.Finalizers, finalizerName)
+
+		if err := r.client.Update(ctx, &prj); err != nil {
+			return errors.Wrap(err, "unable to remove finalizer from gerrit project")
+		}
+	}
+
+	if err := r.client.Delete(ctx, &prj); err != nil && !k8sErrors.IsNotFound(err) {
+		return errors.Wrap(err, "unable to delete orphaned gerrit project")
+	}
+
+	helper.RecordEvent(r.recorder, &prj, helper.EventReasonDeleted,
+		"Project %s does not exist in Gerrit, the orphaned resource has been deleted", prj.Spec.Name)
+
+	return nil
+}
@@ -0,0 +1,168 @@
+package gerritproject
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gerritApi "github.com/epam/edp-gerrit-operator/v2/api/v1"
+	"github.com/epam/edp-gerrit-operator/v2/controllers/helper"
+	gerritClient "github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit"
+	"github.com/epam/edp-gerrit-operator/v2/pkg/manifest"
+)
+
+// importGroups creates GerritGroup and GerritGroupMember CRs for the internal Gerrit groups that have no CR yet.
+func (r *Reconcile) importGroups(ctx context.Context, cl gerritClient.ClientInterface, gr *gerritApi.Gerrit) error {
+	backendGroups, err := cl.ListGroups()
+	if err != nil {
+		return errors.Wrap(err, "unable to list groups from gerrit")
+	}
+
+	var k8sGroups gerritApi.GerritGroupList
+	if err := r.client.List(ctx, &k8sGroups, client.InNamespace(gr.Namespace)); err != nil {
+		return errors.Wrap(err, "unable to list gerrit groups")
+	}
+
+	imported := make(map[string]bool, len(k8sGroups.Items))
+
+	for i := range k8sGroups.Items {
+		if isOwnedByGerrit(&k8sGroups.Items[i], k8sGroups.Items[i].Spec.OwnerName, gr) {
+			imported[k8sGroups.Items[i].Spec.Name] = true
+		}
+	}
+
+	for i := range backendGroups {
+		backendGroup := &backendGroups[i]
+		if !backendGroup.IsInternal() || imported[backendGroup.Name] {
+			continue
+		}
+
+		// members go first, so a failed import is retried completely on the next sync
+		if err := r.createGerritGroupMembers(ctx, gr, backendGroup); err != nil {
+			return errors.Wrapf(err, "unable to import members of group %s", backendGroup.Name)
+		}
+
+		if err := r.createGerritGroup(ctx, gr, backendGroup); err != nil {
+			return errors.Wrapf(err, "unable to import group %s", backendGroup.Name)
+		}
+	}
+
+	return nil
+}
+
+func (r *Reconcile) createGerritGroup(ctx context.Context, gr *gerritApi.Gerrit, backendGroup *gerritClient.Group) error {
+	group := manifest.Group(gr, backendGroup)
+
+	if err := r.client.Create(ctx, group); err != nil {
+		if k8sErrors.IsAlreadyExists(err) {
+			return nil
+		}
+
+		return errors.Wrap(err, "unable to create gerrit group")
+	}
+
+	helper.RecordEvent(r.recorder, group, eventReasonImported, "Group %s has been imported from Gerrit %s",
+		backendGroup.Name, gr.Name)
+
+	return nil
+}
+
+func (r *Reconcile) createGerritGroupMembers(ctx context.Context, gr *gerritApi.Gerrit, backendGroup *gerritClient.Group) error {
+	members := manifest.GroupMembers(gr, backendGroup)
+
+	for i := range members {
+		member := &members[i]
+
+		if err := r.client.Create(ctx, member); err != nil {
+			if k8sErrors.IsAlreadyExists(err) {
+				continue
+			}
+
+			return errors.Wrap(err, "unable to create gerrit group member")
+		}
+
+		helper.RecordEvent(r.recorder, member, eventReasonImported, "Member %s of group %s has been imported from Gerrit %s",
+			member.Spec.AccountID, backendGroup.Name, gr.Name)
+	}
+
+	return nil
+}
+
+// importProjectAccesses creates GerritProjectAccess CRs for the projects
+// that have local access sections and no GerritProjectAccess CR yet.
+func (r *Reconcile) importProjectAccesses(ctx context.Context, cl gerritClient.ClientInterface, gr *gerritApi.Gerrit,
+	backendProjects []gerritClient.Project,
+) error {
+	var k8sAccesses gerritApi.GerritProjectAccessList
+	if err := r.client.List(ctx, &k8sAccesses, client.InNamespace(gr.Namespace)); err != nil {
+		return errors.Wrap(err, "unable to list gerrit project accesses")
+	}
+
+	imported := make(map[string]bool, len(k8sAccesses.Items))
+
+	for i := range k8sAccesses.Items {
+		if isOwnedByGerrit(&k8sAccesses.Items[i], k8sAccesses.Items[i].Spec.OwnerName, gr) {
+			imported[k8sAccesses.Items[i].Spec.ProjectName] = true
+		}
+	}
+
+	for i := range backendProjects {
+		if imported[backendProjects[i].Name] {
+			continue
+		}
+
+		access, err := cl.GetAccessRights(backendProjects[i].Name)
+		if err != nil {
+			return errors.Wrapf(err, "unable to get access rights of project %s", backendProjects[i].Name)
+		}
+
+		if len(access.Permissions) == 0 {
+			continue
+		}
+
+		if err := r.createGerritProjectAccess(ctx, gr, &backendProjects[i], access); err != nil {
+			return errors.Wrapf(err, "unable to import access rights of project %s", backendProjects[i].Name)
+		}
+	}
+
+	return nil
+}
+
+func (r *Reconcile) createGerritProjectAccess(ctx context.Context, gr *gerritApi.Gerrit, backendProject *gerritClient.Project,
+	access *gerritClient.ProjectAccess,
+) error {
+	prjAccess := manifest.ProjectAccess(gr, backendProject, access)
+
+	if err := r.client.Create(ctx, prjAccess); err != nil {
+		if k8sErrors.IsAlreadyExists(err) {
+			return nil
+		}
+
+		return errors.Wrap(err, "unable to create gerrit project access")
+	}
+
+	helper.RecordEvent(r.recorder, prjAccess, eventReasonImported, "Access rights of project %s have been imported from Gerrit %s",
+		backendProject.Name, gr.Name)
+
+	return nil
+}
+
+// isOwnedByGerrit checks whether the CR belongs to the given Gerrit instance.
+// CRs that have not been reconciled yet have no owner reference, so their owner name is checked instead.
+func isOwnedByGerrit(obj metaV1.Object, ownerName string, gr *gerritApi.Gerrit) bool {
+	owners := obj.GetOwnerReferences()
+	if len(owners) == 0 {
+		return ownerName == "" || ownerName == gr.Name
+	}
+
+	for _, owner := range owners {
+		if owner.UID == gr.UID {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,151 @@
+package gerritproject
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coreV1Api "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilRuntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	commonmock "github.com/epam/edp-common/pkg/mock"
+
+	gerritApi "github.com/epam/edp-gerrit-operator/v2/api/v1"
+	gmock "github.com/epam/edp-gerrit-operator/v2/mock/gerrit"
+	gerritClient "github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit"
+	gerritClientMocks "github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit/mocks"
+)
+
+func TestSyncBackendProjectsTick_Import(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilRuntime.Must(gerritApi.AddToScheme(scheme))
+	utilRuntime.Must(coreV1Api.AddToScheme(scheme))
+
+	g := gerritApi.Gerrit{
+		ObjectMeta: metaV1.ObjectMeta{
+			Namespace: "ns", Name: "ger1", UID: "ger1-uid",
+		},
+		Spec: gerritApi.GerritSpec{
+			Sync: gerritApi.GerritSyncSpec{
+				ImportGroups: true,
+				ImportAccess: true,
+			},
+		},
+	}
+
+	existingGroup := gerritApi.GerritGroup{
+		ObjectMeta: metaV1.ObjectMeta{
+			Namespace: "ns", Name: "admins",
+		},
+		Spec: gerritApi.GerritGroupSpec{Name: "Administrators"},
+	}
+
+	cl := fake.NewClientBuilder().WithStatusSubresource(&gerritApi.Gerrit{}, &gerritApi.GerritProject{}).WithScheme(scheme).
+		WithRuntimeObjects(&g, &existingGroup).Build()
+	serviceMock := gmock.Interface{}
+	clientMock := gerritClientMocks.ClientInterface{}
+
+	serviceMock.On("GetRestClient", &g).Return(&clientMock, nil)
+
+	clientMock.On("ListProjects", "CODE").Return([]gerritClient.Project{
+		{Name: "team/backend"},
+		{Name: "team/frontend"},
+	}, nil)
+	clientMock.On("ListProjectBranches", "team/backend").Return([]gerritClient.Branch{}, nil)
+	clientMock.On("ListProjectBranches", "team/frontend").Return([]gerritClient.Branch{}, nil)
+	clientMock.On("ListGroups").Return([]gerritClient.Group{
+		{ID: "uuid-admins", Name: "Administrators"},
+		{
+			ID:       "uuid-devs",
+			Name:     "Developers",
+			OwnerID:  "uuid-admins",
+			Options:  gerritClient.GroupOptions{VisibleToAll: true},
+			Members:  []gerritClient.GroupMember{{AccountID: 1000096, Username: "john"}, {AccountID: 1000097}},
+			Includes: []gerritClient.Group{{ID: "uuid-contractors"}},
+		},
+		{ID: "global:Registered-Users", Name: "Registered Users"},
+	}, nil)
+	clientMock.On("GetAccessRights", "team/backend").Return(&gerritClient.ProjectAccess{
+		Parent: "All-Projects",
+		Permissions: []gerritClient.AccessInfo{
+			{RefPattern: "refs/heads/*", PermissionName: "read", GroupName: "uuid-devs", Action: "ALLOW"},
+		},
+	}, nil)
+	clientMock.On("GetAccessRights", "team/frontend").Return(&gerritClient.ProjectAccess{Parent: "All-Projects"}, nil)
+
+	rcn := Reconcile{
+		client:  cl,
+		log:     commonmock.NewLogr(),
+		service: &serviceMock,
+	}
+
+	require.NoError(t, rcn.syncBackendProjectsTick(context.Background()))
+
+	var groups gerritApi.GerritGroupList
+	require.NoError(t, cl.List(context.Background(), &groups))
+	require.Len(t, groups.Items, 2, "only the Developers group should be imported, Administrators already has a CR")
+
+	var devs gerritApi.GerritGroup
+	require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: "ns", Name: "ger1-developers"}, &devs))
+	assert.Equal(t, gerritApi.GerritGroupSpec{
+		Name:           "Developers",
+		OwnerName:      "ger1",
+		VisibleToAll:   true,
+		OwnerGroup:     "uuid-admins",
+		IncludedGroups: []string{"uuid-contractors"},
+	}, devs.Spec)
+
+	var members gerritApi.GerritGroupMemberList
+	require.NoError(t, cl.List(context.Background(), &members))
+	require.Len(t, members.Items, 2)
+	assert.Equal(t, "ger1-developers-1000096", members.Items[0].Name)
+	assert.Equal(t, "john", members.Items[0].Spec.AccountID)
+	assert.Equal(t, "uuid-devs", members.Items[0].Spec.GroupID)
+	assert.Equal(t, "1000097", members.Items[1].Spec.AccountID)
+
+	var accesses gerritApi.GerritProjectAccessList
+	require.NoError(t, cl.List(context.Background(), &accesses))
+	require.Len(t, accesses.Items, 1, "projects without local access sections should be skipped")
+	assert.Equal(t, "ger1-team-backend-access", accesses.Items[0].Name)
+	assert.Equal(t, "team/backend", accesses.Items[0].Spec.ProjectName)
+	assert.Equal(t, []gerritApi.Reference{
+		{Pattern: "refs/heads/*", PermissionName: "read", GroupName: "uuid-devs", Action: "ALLOW"},
+	}, accesses.Items[0].Spec.References)
+
+	serviceMock.AssertExpectations(t)
+	clientMock.AssertExpectations(t)
+}
+
+func TestIsOwnedByGerrit(t *testing.T) {
+	t.Parallel()
+
+	g := gerritApi.Gerrit{ObjectMeta: metaV1.ObjectMeta{Name: "ger1", UID: "ger1-uid"}}
+
+	tests := []struct {
+		name      string
+		owners    []metaV1.OwnerReference
+		ownerName string
+		want      bool
+	}{
+		{name: "owner reference", owners: []metaV1.OwnerReference{{UID: "ger1-uid"}}, want: true},
+		{name: "other owner reference", owners: []metaV1.OwnerReference{{UID: "ger2-uid"}}, ownerName: "ger1", want: false},
+		{name: "owner name", ownerName: "ger1", want: true},
+		{name: "no owner", want: true},
+		{name: "other owner name", ownerName: "ger2", want: false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			obj := gerritApi.GerritGroup{ObjectMeta: metaV1.ObjectMeta{OwnerReferences: tt.owners}}
+			assert.Equal(t, tt.want, isOwnedByGerrit(&obj, tt.ownerName, &g))
+		})
+	}
+}
@@ -8,16 +8,25 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	coreV1Api "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	utilRuntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/config"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
 	commonmock "github.com/epam/edp-common/pkg/mock"
 
 	gerritApi "github.com/epam/edp-gerrit-operator/v2/api/v1"
+	"github.com/epam/edp-gerrit-operator/v2/controllers/helper"
 	gmock "github.com/epam/edp-gerrit-operator/v2/mock/gerrit"
 	gerritClient "github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit"
 	gerritClientMocks "github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit/mocks"
@@ -72,7 +81,7 @@ func TestSyncBackendProjectsTick(t *testing.T) {
 		},
 	}, nil)
 
-	if err := rcn.syncBackendProjectsTick(); err != nil {
+	if err := rcn.syncBackendProjectsTick(context.Background()); err != nil {
 		t.Fatal(err)
 	}
 
@@ -90,6 +99,52 @@ func TestSyncBackendProjectsTick(t *testing.T) {
 	clientMock.AssertExpectations(t)
 }
 
+func TestSyncBackendProjectsTick_AllowedNamespaces(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilRuntime.Must(gerritApi.AddToScheme(scheme))
+	utilRuntime.Must(coreV1Api.AddToScheme(scheme))
+
+	g := gerritApi.Gerrit{
+		ObjectMeta: metaV1.ObjectMeta{
+			Namespace: "ns", Name: "ger1",
+		},
+		Spec: gerritApi.GerritSpec{AllowedNamespaces: []string{"team"}},
+	}
+
+	prj := gerritApi.GerritProject{
+		ObjectMeta: metaV1.ObjectMeta{
+			Namespace: "team", Name: "google",
+		},
+		Spec: gerritApi.GerritProjectSpec{Name: "alphabet/google", OwnerName: "ns/ger1"},
+	}
+
+	cl := fake.NewClientBuilder().WithStatusSubresource(&gerritApi.Gerrit{}, &gerritApi.GerritProject{}).WithScheme(scheme).WithRuntimeObjects(&g, &prj).Build()
+	serviceMock := gmock.Interface{}
+	clientMock := gerritClientMocks.ClientInterface{}
+
+	serviceMock.On("GetRestClient", mock.Anything).Return(&clientMock, nil)
+	clientMock.On("ListProjects", "CODE").Return([]gerritClient.Project{{Name: "alphabet/google"}}, nil)
+	clientMock.On("ListProjectBranches", "alphabet/google").Return([]gerritClient.Branch{{Ref: "refs/heads/master"}}, nil)
+
+	rcn := Reconcile{
+		client:  cl,
+		log:     commonmock.NewLogr(),
+		service: &serviceMock,
+	}
+
+	assert.NoError(t, rcn.syncBackendProjectsTick(context.Background()))
+
+	var k8sGerritProject gerritApi.GerritProject
+
+	err := cl.Get(context.Background(), types.NamespacedName{Name: "ger1-alphabet-google", Namespace: g.Namespace}, &k8sGerritProject)
+	assert.Error(t, err, "the project of the tenant namespace must not be imported")
+
+	assert.NoError(t, cl.Get(context.Background(), types.NamespacedName{Name: "google", Namespace: "team"}, &k8sGerritProject))
+	assert.Equal(t, []string{"refs/heads/master"}, k8sGerritProject.Status.Branches)
+
+	clientMock.AssertExpectations(t)
+}
+
 func TestSyncBackendProjectsTick_BranchesFailure(t *testing.T) {
 	scheme := runtime.NewScheme()
 	utilRuntime.Must(gerritApi.AddToScheme(scheme))
@@ -135,7 +190,7 @@ func TestSyncBackendProjectsTick_BranchesFailure(t *testing.T) {
 	}, nil)
 	clientMock.On("ListProjectBranches", "alphabet").Return(nil, errors.New("list branches fatal"))
 
-	err := rcn.syncBackendProjectsTick()
+	err := rcn.syncBackendProjectsTick(context.Background())
 	if err == nil {
 		t.Fatal("no error returned")
 	}
@@ -162,15 +217,15 @@ func TestSyncBackendProjectsTick_Failure(t *testing.T) {
 	cl := fake.NewClientBuilder().WithStatusSubresource(&gerritApi.Gerrit{}, &gerritApi.GerritProject{}).WithScheme(scheme).WithRuntimeObjects(&g).Build()
 	serviceMock := gmock.Interface{}
 
-	serviceMock.On("GetRestClient", &g).
-		Return(nil, errors.New("gerrit client fatal")).Once()
+	serviceMock.On("GetRestClient", mock.AnythingOfType("*v1.Gerrit")).
+		Return(nil, errors.New("gerrit client fatal")).Times(syncRetries)
 
 	rcn := Reconcile{
 		client:  cl,
 		service: &serviceMock,
 	}
 
-	err := rcn.syncBackendProjectsTick()
+	err := rcn.syncBackendProjectsTick(context.Background())
 	if err == nil {
 		t.Fatal("no error returned")
 	}
@@ -179,13 +234,21 @@ func TestSyncBackendProjectsTick_Failure(t *testing.T) {
 		t.Fatalf("wrong error returned: %s", err.Error())
 	}
 
+	var ger gerritApi.Gerrit
+	if err = cl.Get(context.Background(), types.NamespacedName{Namespace: "ns", Name: "ger1"}, &ger); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Contains(t, ger.Status.ProjectSyncError, "gerrit client fatal")
+	assert.False(t, ger.Status.LastProjectSyncTime.IsZero())
+
 	clientMock := gerritClientMocks.ClientInterface{}
-	serviceMock.On("GetRestClient", &g).Return(&clientMock, nil)
+	serviceMock.On("GetRestClient", mock.AnythingOfType("*v1.Gerrit")).Return(&clientMock, nil)
 
 	clientMock.On("ListProjects", "CODE").
 		Return(nil, errors.New("list projects fatal"))
 
-	err = rcn.syncBackendProjectsTick()
+	err = rcn.syncBackendProjectsTick(context.Background())
 	if err == nil {
 		t.Fatal("no error returned")
 	}
@@ -198,7 +261,76 @@ func TestSyncBackendProjectsTick_Failure(t *testing.T) {
 	clientMock.AssertExpectations(t)
 }
 
-func TestSyncBackendProjects(t *testing.T) {
+func TestSyncBackendProjectsTick_InstanceIsolation(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilRuntime.Must(gerritApi.AddToScheme(scheme))
+	utilRuntime.Must(coreV1Api.AddToScheme(scheme))
+
+	broken := gerritApi.Gerrit{
+		ObjectMeta: metaV1.ObjectMeta{
+			Namespace: "ns", Name: "broken",
+		},
+		Status: gerritApi.GerritStatus{ProjectSyncError: "old error"},
+	}
+	healthy := gerritApi.Gerrit{
+		ObjectMeta: metaV1.ObjectMeta{
+			Namespace: "ns", Name: "healthy",
+		},
+		Status: gerritApi.GerritStatus{ProjectSyncError: "old error"},
+	}
+
+	cl := fake.NewClientBuilder().WithStatusSubresource(&gerritApi.Gerrit{}, &gerritApi.GerritProject{}).WithScheme(scheme).
+		WithRuntimeObjects(&broken, &healthy).Build()
+	serviceMock := gmock.Interface{}
+	clientMock := gerritClientMocks.ClientInterface{}
+
+	serviceMock.On("GetRestClient", mock.MatchedBy(func(g *gerritApi.Gerrit) bool { return g.Name == "broken" })).
+		Return(nil, errors.New("gerrit client fatal"))
+	serviceMock.On("GetRestClient", mock.MatchedBy(func(g *gerritApi.Gerrit) bool { return g.Name == "healthy" })).
+		Return(&clientMock, nil)
+	clientMock.On("ListProjects", "CODE").Return([]gerritClient.Project{{Name: "alphabet"}}, nil)
+	clientMock.On("ListProjectBranches", "alphabet").Return([]gerritClient.Branch{}, nil)
+
+	rcn := Reconcile{
+		client:  cl,
+		service: &serviceMock,
+		log:     commonmock.NewLogr(),
+	}
+
+	err := rcn.syncBackendProjectsTick(context.Background())
+	if err == nil {
+		t.Fatal("no error returned")
+	}
+
+	assert.Contains(t, err.Error(), "unable to sync gerrit instance: broken")
+	assert.NotContains(t, err.Error(), "healthy")
+
+	var prjList gerritApi.GerritProjectList
+	if err = cl.List(context.Background(), &prjList); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, prjList.Items, 1, "the healthy instance should be synced")
+
+	var ger gerritApi.Gerrit
+	if err = cl.Get(context.Background(), types.NamespacedName{Namespace: "ns", Name: "broken"}, &ger); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Contains(t, ger.Status.ProjectSyncError, "gerrit client fatal")
+
+	if err = cl.Get(context.Background(), types.NamespacedName{Namespace: "ns", Name: "healthy"}, &ger); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Empty(t, ger.Status.ProjectSyncError)
+	assert.False(t, ger.Status.LastProjectSyncTime.IsZero())
+
+	serviceMock.AssertExpectations(t)
+	clientMock.AssertExpectations(t)
+}
+
+func TestBackendSyncer_Start(t *testing.T) {
 	scheme := runtime.NewScheme()
 	utilRuntime.Must(gerritApi.AddToScheme(scheme))
 	utilRuntime.Must(coreV1Api.AddToScheme(scheme))
@@ -212,19 +344,28 @@ func TestSyncBackendProjects(t *testing.T) {
 	cl := fake.NewClientBuilder().WithStatusSubresource(&gerritApi.Gerrit{}, &gerritApi.GerritProject{}).WithScheme(scheme).WithRuntimeObjects(&g).Build()
 	serviceMock := gmock.Interface{}
 
-	serviceMock.On("GetRestClient", &g).
+	serviceMock.On("GetRestClient", mock.AnythingOfType("*v1.Gerrit")).
 		Return(nil, errors.New("gerrit client fatal"))
 
 	logger := commonmock.NewLogr()
 
-	rcn := Reconcile{
-		client:  cl,
-		service: &serviceMock,
-		log:     logger,
+	syncer := backendSyncer{
+		reconciler: &Reconcile{
+			client:  cl,
+			service: &serviceMock,
+			log:     logger,
+		},
+		interval: time.Millisecond,
 	}
 
-	go rcn.syncBackendProjects(time.Millisecond)
-	time.Sleep(time.Millisecond * 10)
+	assert.True(t, syncer.NeedLeaderElection())
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+	defer cancel()
+
+	if err := syncer.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
 
 	loggerSink, ok := logger.GetSink().(*commonmock.Logger)
 	assert.True(t, ok)
@@ -237,6 +378,291 @@ func TestSyncBackendProjects(t *testing.T) {
 	if !strings.Contains(err.Error(), "gerrit client fatal") {
 		t.Fatalf("wrong error returned: %s", err.Error())
 	}
+}
+
+// runnablesRecorder records the runnables added to the manager.
+type runnablesRecorder struct {
+	ctrl.Manager
+	runnables []manager.Runnable
+}
+
+func (m *runnablesRecorder) Add(r manager.Runnable) error {
+	m.runnables = append(m.runnables, r)
+
+	return m.Manager.Add(r)
+}
+
+func TestReconcile_SetupWithManager_SyncInterval(t *testing.T) {
+	tests := []struct {
+		name       string
+		interval   time.Duration
+		wantSyncer bool
+	}{
+		{name: "enabled", interval: time.Minute, wantSyncer: true},
+		{name: "disabled by zero", interval: 0},
+		{name: "disabled by negative", interval: -time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			utilRuntime.Must(gerritApi.AddToScheme(scheme))
+
+			skipNameValidation := true
+
+			mgr, err := ctrl.NewManager(&rest.Config{Host: "http://127.0.0.1:1"}, ctrl.Options{
+				Scheme:  scheme,
+				Metrics: metricsserver.Options{BindAddress: "0"},
+				// every subtest registers the controller of the same name
+				Controller: config.Controller{SkipNameValidation: &skipNameValidation},
+			})
+			require.NoError(t, err)
+
+			recorder := &runnablesRecorder{Manager: mgr}
+
+			require.NoError(t, (&Reconcile{log: commonmock.NewLogr()}).SetupWithManager(recorder, tt.interval))
+
+			syncers := 0
+
+			for _, r := range recorder.runnables {
+				if _, ok := r.(*backendSyncer); ok {
+					syncers++
+				}
+			}
+
+			if tt.wantSyncer {
+				assert.Equal(t, 1, syncers)
+			} else {
+				assert.Zero(t, syncers)
+			}
+		})
+	}
+}
+
+func TestSyncBackendProjectsTick_FilterAndOrphans(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilRuntime.Must(gerritApi.AddToScheme(scheme))
+	utilRuntime.Must(coreV1Api.AddToScheme(scheme))
+
+	tests := []struct {
+		name           string
+		policy         string
+		selector       *metaV1.LabelSelector
+		wantOrphaned   bool
+		wantDeleted    bool
+		wantProjectGet bool
+	}{
+		{
+			name:   "orphans are ignored",
+			policy: gerritApi.OrphanPolicyIgnore,
+		},
+		{
+			name:           "orphans are marked",
+			policy:         gerritApi.OrphanPolicyMark,
+			wantOrphaned:   true,
+			wantProjectGet: true,
+		},
+		{
+			name:           "orphans are deleted",
+			policy:         gerritApi.OrphanPolicyDelete,
+			wantDeleted:    true,
+			wantProjectGet: true,
+		},
+		{
+			name:     "orphans are not selected",
+			policy:   gerritApi.OrphanPolicyDelete,
+			selector: &metaV1.LabelSelector{MatchLabels: map[string]string{"imported": "true"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gerritApi.Gerrit{
+				ObjectMeta: metaV1.ObjectMeta{
+					Namespace: "ns", Name: "ger1", UID: "ger1-uid",
+				},
+				Spec: gerritApi.GerritSpec{
+					Sync: gerritApi.GerritSyncSpec{
+						IncludeProjects: "^team/",
+						ExcludeProjects: "-archive$",
+						ImportLabels:    map[string]string{"imported": "true"},
+						OrphanPolicy:    tt.policy,
+						ProjectSelector: tt.selector,
+					},
+				},
+			}
+
+			orphan := gerritApi.GerritProject{
+				ObjectMeta: metaV1.ObjectMeta{
+					Namespace: "ns", Name: "orphan",
+					Finalizers: []string{finalizerName},
+					OwnerReferences: []metaV1.OwnerReference{
+						{Kind: g.Kind, UID: g.UID},
+					},
+				},
+				Spec:   gerritApi.GerritProjectSpec{Name: "team/removed"},
+				Status: gerritApi.GerritProjectStatus{Value: helper.StatusOK},
+			}
+
+			cl := fake.NewClientBuilder().WithStatusSubresource(&gerritApi.Gerrit{}, &gerritApi.GerritProject{}).
+				WithScheme(scheme).WithRuntimeObjects(&g, &orphan).Build()
+			serviceMock := gmock.Interface{}
+			clientMock := gerritClientMocks.ClientInterface{}
+
+			serviceMock.On("GetRestClient", &g).Return(&clientMock, nil)
+			clientMock.On("ListProjects", "CODE").Return([]gerritClient.Project{
+				{Name: "other/project"},
+				{Name: "team/backend"},
+				{Name: "team/backend-archive"},
+			}, nil)
+			clientMock.On("ListProjectBranches", "team/backend").Return([]gerritClient.Branch{}, nil)
+
+			if tt.wantProjectGet {
+				clientMock.On("GetProject", "team/removed").Return(nil, gerritClient.DoesNotExistError("does not exists"))
+			}
+
+			rcn := Reconcile{
+				client:  cl,
+				log:     commonmock.NewLogr(),
+				service: &serviceMock,
+			}
+
+			if err := rcn.syncBackendProjectsTick(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+
+			var projects gerritApi.GerritProjectList
+			if err := cl.List(context.Background(), &projects); err != nil {
+				t.Fatal(err)
+			}
+
+			names := make([]string, 0, len(projects.Items))
+			for i := range projects.Items {
+				names = append(names, projects.Items[i].Spec.Name)
+			}
+
+			if tt.wantDeleted {
+				assert.ElementsMatch(t, []string{"team/backend"}, names)
+			} else {
+				assert.ElementsMatch(t, []string{"team/backend", "team/removed"}, names)
+			}
+
+			var imported gerritApi.GerritProject
+			if err := cl.Get(context.Background(), types.NamespacedName{Namespace: "ns", Name: "ger1-team-backend"}, &imported); err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, map[string]string{"imported": "true"}, imported.Labels)
+
+			if !tt.wantDeleted {
+				var updated gerritApi.GerritProject
+				if err := cl.Get(context.Background(), types.NamespacedName{Namespace: "ns", Name: "orphan"}, &updated); err != nil {
+					t.Fatal(err)
+				}
+
+				assert.Equal(t, tt.wantOrphaned, meta.IsStatusConditionTrue(updated.Status.Conditions, conditionOrphaned))
+			}
+
+			serviceMock.AssertExpectations(t)
+			clientMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSyncBackendProjectsTick_WrongFilter(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilRuntime.Must(gerritApi.AddToScheme(scheme))
+
+	g := gerritApi.Gerrit{
+		ObjectMeta: metaV1.ObjectMeta{
+			Namespace: "ns", Name: "ger1",
+		},
+		Spec: gerritApi.GerritSpec{
+			Sync: gerritApi.GerritSyncSpec{IncludeProjects: "team/("},
+		},
+	}
+
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(&g).Build()
+	serviceMock := gmock.Interface{}
+	serviceMock.On("GetRestClient", &g).Return(&gerritClientMocks.ClientInterface{}, nil)
+
+	rcn := Reconcile{
+		client:  cl,
+		log:     commonmock.NewLogr(),
+		service: &serviceMock,
+	}
+
+	err := rcn.syncBackendProjectsTick(context.Background())
+	if err == nil {
+		t.Fatal("no error returned")
+	}
+
+	assert.Contains(t, err.Error(), "unable to parse includeProjects")
+}
+
+func TestSyncBackendProjectsTick_ProjectSelector(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilRuntime.Must(gerritApi.AddToScheme(scheme))
+	utilRuntime.Must(coreV1Api.AddToScheme(scheme))
+
+	g := gerritApi.Gerrit{
+		ObjectMeta: metaV1.ObjectMeta{
+			Namespace: "ns", Name: "ger1", UID: "ger1-uid",
+		},
+		Spec: gerritApi.GerritSpec{
+			Sync: gerritApi.GerritSyncSpec{
+				ProjectSelector: &metaV1.LabelSelector{MatchLabels: map[string]string{gerritApi.ProjectParentLabel: "team-a"}},
+				OrphanPolicy:    gerritApi.OrphanPolicyDelete,
+			},
+		},
+	}
+
+	// the CR of another team is out of the selector, so it is neither synced nor deleted as an orphan
+	other := gerritApi.GerritProject{
+		ObjectMeta: metaV1.ObjectMeta{
+			Namespace: "ns", Name: "other",
+			OwnerReferences: []metaV1.OwnerReference{
+				{Kind: g.Kind, UID: g.UID},
+			},
+		},
+		Spec:   gerritApi.GerritProjectSpec{Name: "team-b/removed"},
+		Status: gerritApi.GerritProjectStatus{Value: helper.StatusOK},
+	}
+
+	cl := fake.NewClientBuilder().WithStatusSubresource(&gerritApi.Gerrit{}, &gerritApi.GerritProject{}).
+		WithScheme(scheme).WithRuntimeObjects(&g, &other).Build()
+	serviceMock := gmock.Interface{}
+	clientMock := gerritClientMocks.ClientInterface{}
+
+	serviceMock.On("GetRestClient", &g).Return(&clientMock, nil)
+	clientMock.On("ListProjects", "CODE").Return([]gerritClient.Project{
+		{Name: "team-a/backend", Parent: "team-a"},
+		{Name: "team-b/backend", Parent: "team-b"},
+	}, nil)
+	clientMock.On("ListProjectBranches", "team-a/backend").Return([]gerritClient.Branch{}, nil)
+
+	rcn := Reconcile{
+		client:  cl,
+		log:     commonmock.NewLogr(),
+		service: &serviceMock,
+	}
+
+	require.NoError(t, rcn.syncBackendProjectsTick(context.Background()))
+
+	var projects gerritApi.GerritProjectList
+	require.NoError(t, cl.List(context.Background(), &projects))
+
+	names := make([]string, 0, len(projects.Items))
+	for i := range projects.Items {
+		names = append(names, projects.Items[i].Spec.Name)
+	}
+
+	assert.ElementsMatch(t, []string{"team-a/backend", "team-b/removed"}, names)
+
+	var imported gerritApi.GerritProject
+	require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: "ns", Name: "ger1-team-a-backend"}, &imported))
+	assert.Equal(t, map[string]string{gerritApi.ProjectParentLabel: "team-a"}, imported.Labels)
 
 	serviceMock.AssertExpectations(t)
+	clientMock.AssertExpectations(t)
 }
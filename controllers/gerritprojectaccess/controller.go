@@ -4,12 +4,17 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -22,17 +27,25 @@ import (
 	gerritClient "github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit"
 	"github.com/epam/edp-gerrit-operator/v2/pkg/service/gerrit"
 	"github.com/epam/edp-gerrit-operator/v2/pkg/service/platform"
+	"github.com/epam/edp-gerrit-operator/v2/pkg/tracing"
 )
 
 const (
 	finalizerName = "gerritprojectaccess.gerrit.finalizer.name"
 	requeueTime   = 10 * time.Second
+
+	// conditionDegraded reports whether the access checks of the assertions differ from the expected results.
+	conditionDegraded = "Degraded"
+
+	reasonAssertionsPassed   = "AssertionsPassed"
+	reasonAssertionsViolated = "AssertionsViolated"
 )
 
 type Reconcile struct {
-	client  client.Client
-	service gerrit.Interface
-	log     logr.Logger
+	client   client.Client
+	service  gerrit.Interface
+	log      logr.Logger
+	recorder record.EventRecorder
 }
 
 func NewReconcile(k8sClient client.Client, scheme *runtime.Scheme, log logr.Logger) (helper.Controller, error) {
@@ -53,9 +66,11 @@ func (r *Reconcile) SetupWithManager(mgr ctrl.Manager) error {
 		UpdateFunc: isSpecUpdated,
 	}
 
+	r.recorder = mgr.GetEventRecorderFor("gerrit-project-access")
+
 	err := ctrl.NewControllerManagedBy(mgr).
 		For(&gerritApi.GerritProjectAccess{}, builder.WithPredicates(pred)).
-		Complete(r)
+		Complete(tracing.NewReconciler("GerritProjectAccess", r))
 	if err != nil {
 		return fmt.Errorf("failed to setup GerritProjectAccess controller: %w", err)
 	}
@@ -74,7 +89,7 @@ func isSpecUpdated(e event.UpdateEvent) bool {
 		return false
 	}
 
-	return !reflect.DeepEqual(oo.Spec, no.Spec) ||
+	return !reflect.DeepEqual(oo.Spec, no.Spec) || helper.IsReconcileModeUpdated(e) ||
 		(oo.GetDeletionTimestamp().IsZero() && !no.GetDeletionTimestamp().IsZero())
 }
 
@@ -95,81 +110,369 @@ func (r *Reconcile) Reconcile(ctx context.Context, request reconcile.Request) (r
 		return reconcile.Result{}, errors.Wrap(err, "unable to get GerritProjectAccess instance")
 	}
 
+	tracing.SetAttributes(ctx, tracing.GerritInstance.String(instance.Spec.OwnerName),
+		tracing.GerritProject.String(instance.Spec.ProjectName))
+
+	if helper.IsReconcilePaused(&instance) {
+		reqLogger.Info("Reconciliation of GerritProjectAccess is paused")
+		return
+	}
+
 	defer func() {
 		if err := r.client.Status().Update(context.Background(), &instance); err != nil {
 			reqLogger.Error(err, "unable to update instance status")
 		}
 	}()
 
-	if err := r.tryToReconcile(ctx, &instance); err != nil {
+	if helper.IsDryRun(&instance) {
+		defer setDryRunStatus(&instance, instance.Status.DeepCopy())
+	}
+
+	value, err := r.tryToReconcile(ctx, &instance)
+	if err != nil {
 		reqLogger.Error(err, "unable to reconcile GerritProjectAccess")
 		instance.Status.Value = err.Error()
+		helper.RecordWarning(r.recorder, &instance, helper.EventReasonFailed, err)
 
 		return reconcile.Result{RequeueAfter: requeueTime}, nil
 	}
 
-	instance.Status.Value = helper.StatusOK
-	instance.Status.Created = true
+	instance.Status.Value = value
 
-	return
+	switch value {
+	case statusReviewPending:
+		return reconcile.Result{RequeueAfter: reviewPollInterval}, nil
+	case helper.StatusOK:
+		instance.Status.Created = true
+	}
+
+	return reconcile.Result{RequeueAfter: helper.DriftCheckInterval()}, nil
+}
+
+// globalCapabilities is the pseudo ref pattern of the global capabilities of All-Projects.
+const globalCapabilities = "GLOBAL_CAPABILITIES"
+
+var allowedActions = map[string]bool{
+	gerritClient.ActionAllow:       true,
+	gerritClient.ActionDeny:        true,
+	gerritClient.ActionBlock:       true,
+	gerritClient.ActionInteractive: true,
+	gerritClient.ActionBatch:       true,
 }
 
-func prepareAccessInfo(references []gerritApi.Reference) []gerritClient.AccessInfo {
+// prepareAccessInfo validates the references and converts them to the access rules,
+// the group names are resolved to UUIDs, so the rules can be compared with the rules returned by Gerrit.
+func prepareAccessInfo(cl gerritClient.ClientInterface, references []gerritApi.Reference) ([]gerritClient.AccessInfo, error) {
+	if err := validateReferences(references); err != nil {
+		return nil, err
+	}
+
+	groups := make(map[string]string)
 	ai := make([]gerritClient.AccessInfo, 0, len(references))
+
 	for _, ref := range references {
+		groupUUID, ok := groups[ref.GroupName]
+		if !ok {
+			var err error
+
+			if groupUUID, err = gerritClient.ResolveGroupUUID(cl, ref.GroupName); err != nil {
+				return nil, err
+			}
+
+			groups[ref.GroupName] = groupUUID
+		}
+
+		label := ref.PermissionLabel
+		if label == "" {
+			label = gerritClient.PermissionLabel(ref.PermissionName)
+		}
+
 		ai = append(ai, gerritClient.AccessInfo{
-			Action:          ref.Action,
+			Action:          gerritClient.NormalizeAction(ref.Action),
 			Force:           ref.Force,
-			GroupName:       ref.GroupName,
+			GroupName:       groupUUID,
 			Max:             ref.Max,
 			Min:             ref.Min,
 			RefPattern:      ref.Pattern,
-			PermissionLabel: ref.PermissionLabel,
+			PermissionLabel: label,
 			PermissionName:  ref.PermissionName,
+			Exclusive:       ref.Exclusive,
 		})
 	}
 
-	return ai
+	return ai, nil
+}
+
+// validateReferences checks the references before they are sent to Gerrit,
+// Gerrit rejects the whole access change if a single rule is invalid.
+func validateReferences(references []gerritApi.Reference) error {
+	exclusive := make(map[string]bool)
+
+	for i := range references {
+		ref := &references[i]
+
+		if err := validateRefPattern(ref.Pattern); err != nil {
+			return err
+		}
+
+		if ref.PermissionName == "" {
+			return fmt.Errorf("permission name of ref %s is empty", ref.Pattern)
+		}
+
+		if ref.GroupName == "" {
+			return fmt.Errorf("group name of permission %s on ref %s is empty", ref.PermissionName, ref.Pattern)
+		}
+
+		if !allowedActions[gerritClient.NormalizeAction(ref.Action)] {
+			return fmt.Errorf("action %s of permission %s on ref %s is not supported", ref.Action, ref.PermissionName, ref.Pattern)
+		}
+
+		if ref.Min > ref.Max {
+			return fmt.Errorf("range %d..%d of permission %s on ref %s is invalid", ref.Min, ref.Max, ref.PermissionName, ref.Pattern)
+		}
+
+		key := ref.Pattern + " " + ref.PermissionName
+		if prev, ok := exclusive[key]; ok && prev != ref.Exclusive {
+			return fmt.Errorf("exclusive flag of permission %s on ref %s differs between the groups", ref.PermissionName, ref.Pattern)
+		}
+
+		exclusive[key] = ref.Exclusive
+	}
+
+	return nil
 }
 
-func (r *Reconcile) tryToReconcile(ctx context.Context, instance *gerritApi.GerritProjectAccess) error {
+// validateRefPattern checks the ref pattern, the patterns starting with ^ must be valid regular expressions.
+func validateRefPattern(pattern string) error {
+	switch {
+	case pattern == globalCapabilities:
+		return nil
+	case strings.HasPrefix(pattern, "^"):
+		if !strings.HasPrefix(pattern, "^refs/") {
+			return fmt.Errorf("ref pattern %s must start with ^refs/", pattern)
+		}
+
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("ref pattern %s is not a valid regular expression: %w", pattern, err)
+		}
+
+		return nil
+	case strings.HasPrefix(pattern, "refs/"):
+		return nil
+	default:
+		return fmt.Errorf("ref pattern %s must start with refs/, ^refs/ or be %s", pattern, globalCapabilities)
+	}
+}
+
+// tryToReconcile applies the access rights and returns the status value of the resource.
+func (r *Reconcile) tryToReconcile(ctx context.Context, instance *gerritApi.GerritProjectAccess) (string, error) {
 	cl, err := helper.GetGerritClient(ctx, r.client, instance, instance.Spec.OwnerName, r.service)
 	if err != nil {
-		return errors.Wrap(err, "unable to init gerrit client")
+		return "", errors.Wrap(err, "unable to init gerrit client")
+	}
+
+	defer func() {
+		instance.Status.PlannedActions = gerritClient.PlannedActions(cl)
+	}()
+
+	if !instance.GetDeletionTimestamp().IsZero() {
+		return helper.StatusOK, r.tryToDelete(ctx, cl, instance)
+	}
+
+	rules, err := prepareAccessInfo(cl, instance.Spec.References)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid access rights")
+	}
+
+	if instance.Spec.Review {
+		return r.reconcileReview(ctx, cl, instance, rules)
 	}
 
-	if len(instance.Spec.References) > 0 && !instance.Status.Created {
-		if err := cl.AddAccessRights(instance.Spec.ProjectName, prepareAccessInfo(instance.Spec.References)); err != nil {
-			return errors.Wrap(err, "unable to add access rights")
+	var drift helper.Drift
+
+	if instance.Status.Created {
+		if drift, err = accessDrift(cl, instance, rules); err != nil {
+			return "", err
 		}
-	} else if len(instance.Spec.References) > 0 {
-		if err := cl.UpdateAccessRights(instance.Spec.ProjectName, prepareAccessInfo(instance.Spec.References)); err != nil {
-			return errors.Wrap(err, "unable to update access rights")
+	}
+
+	applied := helper.NeedsApply(instance, instance.Status.Conditions, drift)
+
+	if len(rules) > 0 && !instance.Status.Created {
+		if err := cl.AddAccessRights(instance.Spec.ProjectName, rules); err != nil {
+			return "", errors.Wrap(err, "unable to add access rights")
 		}
+
+		helper.RecordEvent(r.recorder, instance, helper.EventReasonCreated, "Access rights of project %s have been added",
+			instance.Spec.ProjectName)
+	} else if len(rules) > 0 && applied {
+		if err := cl.UpdateAccessRights(instance.Spec.ProjectName, rules); err != nil {
+			return "", errors.Wrap(err, "unable to update access rights")
+		}
+
+		helper.RecordEvent(r.recorder, instance, helper.EventReasonUpdated, "Access rights of project %s have been updated",
+			instance.Spec.ProjectName)
 	}
 
-	if instance.Spec.Parent != "" {
+	if instance.Spec.Parent != "" && applied {
 		if err := cl.SetProjectParent(instance.Spec.ProjectName, instance.Spec.Parent); err != nil {
-			return errors.Wrap(err, "unable to set project parent")
+			return "", errors.Wrap(err, "unable to set project parent")
 		}
 	}
 
+	helper.SetDriftCondition(r.recorder, instance, &instance.Status.Conditions, drift, applied)
+
+	if err := r.checkAssertions(cl, instance); err != nil {
+		return "", err
+	}
+
+	return helper.StatusOK, r.tryToDelete(ctx, cl, instance)
+}
+
+// checkAssertions runs the access checks of the assertions against the applied access rights and sets the Degraded condition.
+// The checks are skipped in the dry-run mode, since the planned changes are not applied.
+func (r *Reconcile) checkAssertions(cl gerritClient.ClientInterface, instance *gerritApi.GerritProjectAccess) error {
+	if len(instance.Spec.Assertions) == 0 {
+		meta.RemoveStatusCondition(&instance.Status.Conditions, conditionDegraded)
+		return nil
+	}
+
+	if helper.IsDryRun(instance) {
+		return nil
+	}
+
+	var violations helper.Drift
+
+	for i := range instance.Spec.Assertions {
+		assertion := &instance.Spec.Assertions[i]
+
+		res, info, err := helper.CheckAccess(cl, instance.Spec.ProjectName, &assertion.AccessCheck)
+		if err != nil {
+			return err
+		}
+
+		if res == assertion.Expect {
+			continue
+		}
+
+		violation := fmt.Sprintf("%s: %s, expected %s", helper.DescribeAccessCheck(&assertion.AccessCheck), res, assertion.Expect)
+		if info.Message != "" {
+			violation += " (" + info.Message + ")"
+		}
+
+		violations = append(violations, violation)
+	}
+
+	condition := metaV1.Condition{
+		Type:               conditionDegraded,
+		Status:             metaV1.ConditionFalse,
+		Reason:             reasonAssertionsPassed,
+		Message:            fmt.Sprintf("%d assertions passed", len(instance.Spec.Assertions)),
+		ObservedGeneration: instance.Generation,
+	}
+
+	if len(violations) > 0 {
+		condition.Status = metaV1.ConditionTrue
+		condition.Reason = reasonAssertionsViolated
+		condition.Message = violations.String()
+	}
+
+	if meta.SetStatusCondition(&instance.Status.Conditions, condition) && condition.Status == metaV1.ConditionTrue {
+		helper.RecordWarning(r.recorder, instance, reasonAssertionsViolated, errors.New(condition.Message))
+	}
+
+	return nil
+}
+
+func (r *Reconcile) tryToDelete(ctx context.Context, cl gerritClient.ClientInterface, instance *gerritApi.GerritProjectAccess) error {
+	// TryToDelete may update the instance, which round-trips the server state back into it
+	// and discards the status computed by the reconciliation, e.g. the ID of the created review change.
+	status := instance.Status
+
 	if err := helper.TryToDelete(ctx, r.client, instance, finalizerName,
-		r.makeDeletionFunc(cl, instance.Spec.ProjectName, instance.Spec.References)); err != nil {
+		r.makeDeletionFunc(cl, instance)); err != nil {
 		return errors.Wrap(err, "error during TryToDelete")
 	}
 
+	instance.Status = status
+
 	return nil
 }
 
-func (*Reconcile) makeDeletionFunc(gc gerritClient.ClientInterface, projectName string,
-	refs []gerritApi.Reference,
-) func() error {
+func (r *Reconcile) makeDeletionFunc(gc gerritClient.ClientInterface, instance *gerritApi.GerritProjectAccess) func() error {
 	return func() error {
-		if err := gc.DeleteAccessRights(projectName, prepareAccessInfo(refs)); err != nil {
+		rules, err := prepareAccessInfo(gc, instance.Spec.References)
+		if err != nil {
+			// the invalid rules and the rules of the removed groups have never been applied or are already gone.
+			r.log.Info("Access rights are not deleted", "reason", err.Error())
+			rules = nil
+		}
+
+		if instance.Spec.Review {
+			return r.deleteReview(gc, instance, rules)
+		}
+
+		if len(rules) == 0 {
+			return nil
+		}
+
+		if err := gc.DeleteAccessRights(instance.Spec.ProjectName, rules); err != nil {
 			return errors.Wrap(err, "unable to delete access rights")
 		}
 
+		helper.RecordEvent(r.recorder, instance, helper.EventReasonDeleted, "Access rights of project %s have been deleted",
+			instance.Spec.ProjectName)
+
 		return nil
 	}
 }
+
+// accessDrift compares the access rules and the parent from the spec with the local access rights of the project in Gerrit.
+// The rules that are not declared in the spec are not reported, the operator does not remove them.
+func accessDrift(cl gerritClient.ClientInterface, instance *gerritApi.GerritProjectAccess,
+	rules []gerritClient.AccessInfo,
+) (helper.Drift, error) {
+	var drift helper.Drift
+
+	live, err := cl.GetAccessRights(instance.Spec.ProjectName)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get access rights")
+	}
+
+	if instance.Spec.Parent != "" {
+		drift.Compare("parent", instance.Spec.Parent, live.Parent)
+	}
+
+	liveRules := make(map[string]*gerritClient.AccessInfo, len(live.Permissions))
+	for i := range live.Permissions {
+		liveRules[live.Permissions[i].Key()] = &live.Permissions[i]
+	}
+
+	for i := range rules {
+		key := rules[i].Key()
+
+		liveRule, ok := liveRules[key]
+		if !ok {
+			drift.Addf("%s: missing", key)
+			continue
+		}
+
+		for _, d := range rules[i].Diff(liveRule) {
+			drift.Addf("%s %s", key, d)
+		}
+	}
+
+	return drift, nil
+}
+
+// setDryRunStatus restores the status changed by the dry-run reconciliation, only the planned actions and the result are kept.
+func setDryRunStatus(instance *gerritApi.GerritProjectAccess, status *gerritApi.GerritProjectAccessStatus) {
+	status.PlannedActions = instance.Status.PlannedActions
+	status.Value = instance.Status.Value
+
+	if status.Value == helper.StatusOK {
+		status.Value = helper.StatusDryRun
+	}
+
+	instance.Status = *status
+}
@@ -11,10 +11,12 @@ import (
 	"github.com/stretchr/testify/assert"
 	appsV1 "k8s.io/api/apps/v1"
 	coreV1Api "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	utilRuntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -25,6 +27,7 @@ import (
 	"github.com/epam/edp-gerrit-operator/v2/controllers/helper"
 	mocks "github.com/epam/edp-gerrit-operator/v2/mock"
 	gmock "github.com/epam/edp-gerrit-operator/v2/mock/gerrit"
+	gerritClient "github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit"
 	gerritClientMocks "github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit/mocks"
 	"github.com/epam/edp-gerrit-operator/v2/pkg/service/platform"
 )
@@ -74,15 +77,24 @@ func TestReconcile_Reconcile(t *testing.T) {
 	serviceMock := gmock.Interface{}
 	clientMock := gerritClientMocks.ClientInterface{}
 
+	rules := []gerritClient.AccessInfo{
+		{
+			RefPattern:      "refs/heads/*",
+			PermissionName:  "label-Code-Review",
+			PermissionLabel: "Code-Review",
+			GroupName:       "important-group-uuid",
+			Min:             -2,
+			Max:             2,
+			Action:          "ALLOW",
+		},
+	}
+
 	serviceMock.On("GetRestClient", &g).Return(&clientMock, nil)
-	clientMock.On("AddAccessRights", projectAccessInstance.Spec.ProjectName,
-		prepareAccessInfo(projectAccessInstance.Spec.References)).Return(nil)
-	clientMock.On("UpdateAccessRights", projectAccessInstance.Spec.ProjectName,
-		prepareAccessInfo(projectAccessInstance.Spec.References)).Return(nil)
+	clientMock.On("GetGroup", "important-group").Return(&gerritClient.Group{ID: "important-group-uuid"}, nil)
+	clientMock.On("AddAccessRights", projectAccessInstance.Spec.ProjectName, rules).Return(nil)
 	clientMock.On("SetProjectParent", projectAccessInstance.Spec.ProjectName,
 		projectAccessInstance.Spec.Parent).Return(nil)
-	clientMock.On("DeleteAccessRights", projectAccessInstance.Spec.ProjectName,
-		prepareAccessInfo(projectAccessInstance.Spec.References)).Return(nil)
+	clientMock.On("DeleteAccessRights", projectAccessInstance.Spec.ProjectName, rules).Return(nil)
 
 	rcn := Reconcile{
 		client:  client,
@@ -311,3 +323,177 @@ func TestReconcileGerrit_Reconcile_UpdateStatusErr(t *testing.T) {
 	sw.AssertExpectations(t)
 	mc.AssertExpectations(t)
 }
+
+func TestValidateReferences(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		refs    []gerritApi.Reference
+		wantErr string
+	}{
+		{
+			name: "block force push on release branches",
+			refs: []gerritApi.Reference{
+				{Pattern: "refs/heads/release/*", PermissionName: "push", GroupName: "global:Registered-Users", Action: "BLOCK", Force: true},
+				{Pattern: "^refs/heads/release/[0-9]+", PermissionName: "label-Verified", GroupName: "ci", Min: -1, Max: 1, Exclusive: true},
+				{Pattern: "GLOBAL_CAPABILITIES", PermissionName: "streamEvents", GroupName: "ci", Action: "allow"},
+			},
+		},
+		{
+			name:    "pattern outside of refs",
+			refs:    []gerritApi.Reference{{Pattern: "heads/*", PermissionName: "push", GroupName: "ci"}},
+			wantErr: "ref pattern heads/* must start with refs/",
+		},
+		{
+			name:    "invalid regular expression",
+			refs:    []gerritApi.Reference{{Pattern: "^refs/heads/(release", PermissionName: "push", GroupName: "ci"}},
+			wantErr: "is not a valid regular expression",
+		},
+		{
+			name:    "unsupported action",
+			refs:    []gerritApi.Reference{{Pattern: "refs/*", PermissionName: "push", GroupName: "ci", Action: "REJECT"}},
+			wantErr: "action REJECT of permission push on ref refs/* is not supported",
+		},
+		{
+			name:    "empty group",
+			refs:    []gerritApi.Reference{{Pattern: "refs/*", PermissionName: "push"}},
+			wantErr: "group name of permission push on ref refs/* is empty",
+		},
+		{
+			name:    "invalid range",
+			refs:    []gerritApi.Reference{{Pattern: "refs/*", PermissionName: "label-Code-Review", GroupName: "ci", Min: 2, Max: -2}},
+			wantErr: "range 2..-2 of permission label-Code-Review on ref refs/* is invalid",
+		},
+		{
+			name: "inconsistent exclusive flag",
+			refs: []gerritApi.Reference{
+				{Pattern: "refs/*", PermissionName: "push", GroupName: "ci", Exclusive: true},
+				{Pattern: "refs/*", PermissionName: "push", GroupName: "dev"},
+			},
+			wantErr: "exclusive flag of permission push on ref refs/* differs between the groups",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validateReferences(tt.refs)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestPrepareAccessInfo(t *testing.T) {
+	clientMock := gerritClientMocks.ClientInterface{}
+	clientMock.On("GetGroup", "ci").Return(&gerritClient.Group{ID: "ci-uuid"}, nil).Once()
+
+	rules, err := prepareAccessInfo(&clientMock, []gerritApi.Reference{
+		{Pattern: "refs/heads/release/*", PermissionName: "push", GroupName: "global:Registered-Users", Action: "block", Force: true},
+		{Pattern: "refs/heads/*", PermissionName: "label-Verified", GroupName: "ci", Min: -1, Max: 1, Exclusive: true},
+		{Pattern: "refs/heads/*", PermissionName: "submit", GroupName: "ci"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []gerritClient.AccessInfo{
+		{RefPattern: "refs/heads/release/*", PermissionName: "push", GroupName: "global:Registered-Users", Action: "BLOCK", Force: true},
+		{
+			RefPattern: "refs/heads/*", PermissionName: "label-Verified", PermissionLabel: "Verified", GroupName: "ci-uuid",
+			Action: "ALLOW", Min: -1, Max: 1, Exclusive: true,
+		},
+		{RefPattern: "refs/heads/*", PermissionName: "submit", GroupName: "ci-uuid", Action: "ALLOW"},
+	}, rules)
+
+	clientMock.On("GetGroup", "unknown").Return(nil, gerritClient.DoesNotExistError("not found"))
+
+	_, err = prepareAccessInfo(&clientMock, []gerritApi.Reference{{Pattern: "refs/*", PermissionName: "read", GroupName: "unknown"}})
+	assert.ErrorContains(t, err, "group unknown does not exist")
+
+	clientMock.AssertExpectations(t)
+}
+
+func TestAccessDrift(t *testing.T) {
+	instance := &gerritApi.GerritProjectAccess{
+		Spec: gerritApi.GerritProjectAccessSpec{ProjectName: "All-Projects"},
+	}
+	rules := []gerritClient.AccessInfo{
+		{RefPattern: "refs/heads/release/*", PermissionName: "push", GroupName: "global:Registered-Users", Action: "BLOCK", Force: true},
+		{RefPattern: "refs/heads/*", PermissionName: "label-Verified", GroupName: "ci-uuid", Action: "ALLOW", Min: -1, Max: 1, Exclusive: true},
+		{RefPattern: "refs/heads/*", PermissionName: "submit", GroupName: "ci-uuid", Action: "ALLOW"},
+	}
+
+	clientMock := gerritClientMocks.ClientInterface{}
+	clientMock.On("GetAccessRights", "All-Projects").Return(&gerritClient.ProjectAccess{
+		Permissions: []gerritClient.AccessInfo{
+			{RefPattern: "refs/heads/release/*", PermissionName: "push", GroupName: "global:Registered-Users", Action: "BLOCK", Force: true},
+			{RefPattern: "refs/heads/*", PermissionName: "label-Verified", GroupName: "ci-uuid", Action: "ALLOW", Min: -2, Max: 2},
+		},
+	}, nil)
+
+	drift, err := accessDrift(&clientMock, instance, rules)
+	assert.NoError(t, err)
+	assert.Equal(t, helper.Drift{
+		"refs/heads/* label-Verified ci-uuid range: -2..2, expected -1..1",
+		"refs/heads/* label-Verified ci-uuid exclusive: false, expected true",
+		"refs/heads/* submit ci-uuid: missing",
+	}, drift)
+}
+
+func TestReconcile_checkAssertions(t *testing.T) {
+	instance := &gerritApi.GerritProjectAccess{
+		ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: namespace, Generation: 2},
+		Spec: gerritApi.GerritProjectAccessSpec{
+			ProjectName: "All-Projects",
+			Assertions: []gerritApi.AccessAssertion{
+				{
+					AccessCheck: gerritApi.AccessCheck{Account: "developer", Ref: "refs/heads/release/1.0", Permission: "push"},
+					Expect:      gerritApi.AccessAllowed,
+				},
+				{
+					AccessCheck: gerritApi.AccessCheck{Account: "developer", Ref: "refs/heads/main", Permission: "push"},
+					Expect:      gerritApi.AccessAllowed,
+				},
+			},
+		},
+	}
+
+	clientMock := gerritClientMocks.ClientInterface{}
+	clientMock.On("CheckAccess", "All-Projects",
+		gerritClient.AccessCheck{Account: "developer", Ref: "refs/heads/release/1.0", Permission: "push"}).
+		Return(&gerritClient.AccessCheckInfo{Status: 403, Message: "push is blocked"}, nil)
+	clientMock.On("CheckAccess", "All-Projects",
+		gerritClient.AccessCheck{Account: "developer", Ref: "refs/heads/main", Permission: "push"}).
+		Return(&gerritClient.AccessCheckInfo{Status: 200}, nil)
+
+	recorder := record.NewFakeRecorder(1)
+	rcn := Reconcile{recorder: recorder}
+
+	assert.NoError(t, rcn.checkAssertions(&clientMock, instance))
+
+	condition := meta.FindStatusCondition(instance.Status.Conditions, conditionDegraded)
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, metaV1.ConditionTrue, condition.Status)
+		assert.Equal(t, reasonAssertionsViolated, condition.Reason)
+		assert.Equal(t, "developer push refs/heads/release/1.0: Denied, expected Allowed (push is blocked)", condition.Message)
+		assert.Equal(t, int64(2), condition.ObservedGeneration)
+	}
+
+	assert.Len(t, recorder.Events, 1)
+
+	instance.Spec.Assertions[0].Expect = gerritApi.AccessDenied
+
+	assert.NoError(t, rcn.checkAssertions(&clientMock, instance))
+	assert.True(t, meta.IsStatusConditionFalse(instance.Status.Conditions, conditionDegraded))
+
+	instance.Spec.Assertions = nil
+
+	assert.NoError(t, rcn.checkAssertions(&clientMock, instance))
+	assert.Nil(t, meta.FindStatusCondition(instance.Status.Conditions, conditionDegraded))
+}
@@ -0,0 +1,153 @@
+package gerritprojectaccess
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	gerritApi "github.com/epam/edp-gerrit-operator/v2/api/v1"
+	"github.com/epam/edp-gerrit-operator/v2/controllers/helper"
+	gerritClient "github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit"
+)
+
+const (
+	statusReviewPending   = "review pending"
+	statusReviewAbandoned = "review abandoned"
+
+	reasonReviewAbandoned = "ReviewAbandoned"
+
+	reviewPollInterval = helper.DefaultRequeueTime * time.Second
+)
+
+// reconcileReview applies the access rights through a review change of refs/meta/config instead of applying them directly.
+// The pending change is tracked until it is merged or abandoned, the resource is applied once the change is merged.
+func (r *Reconcile) reconcileReview(ctx context.Context, cl gerritClient.ClientInterface,
+	instance *gerritApi.GerritProjectAccess, rules []gerritClient.AccessInfo,
+) (string, error) {
+	if instance.Status.ReviewStatus == gerritClient.ChangeStatusNew {
+		pending, err := r.trackReview(cl, instance)
+		if err != nil {
+			return "", err
+		}
+
+		if pending {
+			return statusReviewPending, r.tryToDelete(ctx, cl, instance)
+		}
+	}
+
+	drift, err := accessDrift(cl, instance, rules)
+	if err != nil {
+		return "", err
+	}
+
+	// the change is requested for the spec that is not applied yet, or for the drift if the drift correction is enabled
+	if len(drift) > 0 && (!instance.Status.Created || helper.NeedsApply(instance, instance.Status.Conditions, drift)) {
+		if instance.Status.ReviewStatus == gerritClient.ChangeStatusAbandoned &&
+			instance.Status.ReviewGeneration == instance.Generation {
+			// the abandoned change is not requested again until the spec is changed
+			return statusReviewAbandoned, r.tryToDelete(ctx, cl, instance)
+		}
+
+		if err := r.requestReview(cl, instance, rules); err != nil {
+			return "", err
+		}
+
+		return statusReviewPending, r.tryToDelete(ctx, cl, instance)
+	}
+
+	helper.SetDriftCondition(r.recorder, instance, &instance.Status.Conditions, drift, false)
+
+	if err := r.checkAssertions(cl, instance); err != nil {
+		return "", err
+	}
+
+	return helper.StatusOK, r.tryToDelete(ctx, cl, instance)
+}
+
+// trackReview updates the status of the pending review change and reports whether it is still pending.
+// The pending change of an outdated generation is abandoned, so a change for the current spec can be requested.
+func (r *Reconcile) trackReview(cl gerritClient.ClientInterface, instance *gerritApi.GerritProjectAccess) (bool, error) {
+	change, err := cl.ChangeGet(instance.Status.ReviewChangeID)
+	if err != nil {
+		return false, errors.Wrap(err, "unable to get review change")
+	}
+
+	switch change.Status {
+	case gerritClient.ChangeStatusMerged:
+		instance.Status.ReviewStatus = gerritClient.ChangeStatusMerged
+		helper.RecordEvent(r.recorder, instance, helper.EventReasonUpdated,
+			"Review change %d of access rights of project %s has been merged", instance.Status.ReviewChangeNumber,
+			instance.Spec.ProjectName)
+
+		return false, nil
+	case gerritClient.ChangeStatusAbandoned:
+		instance.Status.ReviewStatus = gerritClient.ChangeStatusAbandoned
+		helper.RecordWarning(r.recorder, instance, reasonReviewAbandoned,
+			errors.Errorf("review change %d of access rights of project %s has been abandoned",
+				instance.Status.ReviewChangeNumber, instance.Spec.ProjectName))
+
+		return false, nil
+	}
+
+	if instance.Status.ReviewGeneration == instance.Generation {
+		return true, nil
+	}
+
+	if err := cl.ChangeAbandon(instance.Status.ReviewChangeID); err != nil {
+		return false, errors.Wrap(err, "unable to abandon outdated review change")
+	}
+
+	instance.Status.ReviewStatus = gerritClient.ChangeStatusAbandoned
+	helper.RecordEvent(r.recorder, instance, helper.EventReasonUpdated,
+		"Outdated review change %d of access rights of project %s has been abandoned", instance.Status.ReviewChangeNumber,
+		instance.Spec.ProjectName)
+
+	return false, nil
+}
+
+// requestReview creates the review change that replaces the rules of the groups and sets the parent of the project.
+func (r *Reconcile) requestReview(cl gerritClient.ClientInterface, instance *gerritApi.GerritProjectAccess,
+	rules []gerritClient.AccessInfo,
+) error {
+	change, err := cl.ReviewAccessRights(instance.Spec.ProjectName, instance.Spec.Parent, rules, rules)
+	if err != nil {
+		return errors.Wrap(err, "unable to request review of access rights")
+	}
+
+	instance.Status.ReviewChangeID = change.ID
+	instance.Status.ReviewChangeNumber = change.Number
+	instance.Status.ReviewStatus = gerritClient.ChangeStatusNew
+	instance.Status.ReviewGeneration = instance.Generation
+
+	helper.RecordEvent(r.recorder, instance, helper.EventReasonCreated,
+		"Review change %d of access rights of project %s has been created", change.Number, instance.Spec.ProjectName)
+
+	return nil
+}
+
+// deleteReview abandons the pending review change and requests the review of the removal of the applied rules.
+// The resource is deleted without waiting for the review.
+func (r *Reconcile) deleteReview(cl gerritClient.ClientInterface, instance *gerritApi.GerritProjectAccess,
+	rules []gerritClient.AccessInfo,
+) error {
+	if instance.Status.ReviewStatus == gerritClient.ChangeStatusNew {
+		if err := cl.ChangeAbandon(instance.Status.ReviewChangeID); err != nil {
+			return errors.Wrap(err, "unable to abandon pending review change")
+		}
+	}
+
+	if !instance.Status.Created || len(rules) == 0 {
+		return nil
+	}
+
+	change, err := cl.ReviewAccessRights(instance.Spec.ProjectName, "", nil, rules)
+	if err != nil {
+		return errors.Wrap(err, "unable to request review of access rights removal")
+	}
+
+	helper.RecordEvent(r.recorder, instance, helper.EventReasonDeleted,
+		"Review change %d removing access rights of project %s has been created", change.Number, instance.Spec.ProjectName)
+
+	return nil
+}
@@ -0,0 +1,200 @@
+package gerritprojectaccess
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coreV1Api "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilRuntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	commonmock "github.com/epam/edp-common/pkg/mock"
+
+	gerritApi "github.com/epam/edp-gerrit-operator/v2/api/v1"
+	"github.com/epam/edp-gerrit-operator/v2/controllers/helper"
+	gmock "github.com/epam/edp-gerrit-operator/v2/mock/gerrit"
+	gerritClient "github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit"
+	gerritClientMocks "github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit/mocks"
+)
+
+var reviewRules = []gerritClient.AccessInfo{
+	{RefPattern: "refs/heads/release/*", PermissionName: "push", GroupName: "global:Registered-Users", Action: "BLOCK", Force: true},
+}
+
+func newReviewReconcile(t *testing.T, instance *gerritApi.GerritProjectAccess) (*Reconcile, client.Client, *gerritClientMocks.ClientInterface) {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	utilRuntime.Must(gerritApi.AddToScheme(scheme))
+	utilRuntime.Must(coreV1Api.AddToScheme(scheme))
+
+	g := gerritApi.Gerrit{
+		ObjectMeta: metaV1.ObjectMeta{
+			Namespace: instance.Namespace,
+			Name:      "ger1",
+		},
+	}
+
+	k8sClient := fake.NewClientBuilder().WithStatusSubresource(&gerritApi.GerritProjectAccess{}).WithScheme(scheme).
+		WithRuntimeObjects(instance, &g).Build()
+
+	serviceMock := gmock.Interface{}
+	clientMock := gerritClientMocks.ClientInterface{}
+
+	serviceMock.On("GetRestClient", &g).Return(&clientMock, nil)
+
+	return &Reconcile{
+		client:   k8sClient,
+		log:      commonmock.NewLogr(),
+		service:  &serviceMock,
+		recorder: record.NewFakeRecorder(10),
+	}, k8sClient, &clientMock
+}
+
+func newReviewInstance() *gerritApi.GerritProjectAccess {
+	return &gerritApi.GerritProjectAccess{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: gerritApi.GerritProjectAccessSpec{
+			ProjectName: "All-Projects",
+			Review:      true,
+			References: []gerritApi.Reference{
+				{Pattern: "refs/heads/release/*", PermissionName: "push", GroupName: "global:Registered-Users", Action: "BLOCK", Force: true},
+			},
+		},
+	}
+}
+
+func reconcileReviewInstance(t *testing.T, rcn *Reconcile, k8sClient client.Client) (reconcile.Result, *gerritApi.GerritProjectAccess) {
+	t.Helper()
+
+	nn := types.NamespacedName{Name: name, Namespace: namespace}
+
+	res, err := rcn.Reconcile(context.Background(), reconcile.Request{NamespacedName: nn})
+	require.NoError(t, err)
+
+	var updated gerritApi.GerritProjectAccess
+	require.NoError(t, k8sClient.Get(context.Background(), nn, &updated))
+
+	return res, &updated
+}
+
+func TestReconcile_Review(t *testing.T) {
+	rcn, k8sClient, clientMock := newReviewReconcile(t, newReviewInstance())
+
+	clientMock.On("GetAccessRights", "All-Projects").Return(&gerritClient.ProjectAccess{}, nil).Once()
+	clientMock.On("ReviewAccessRights", "All-Projects", "", reviewRules, reviewRules).
+		Return(&gerritClient.Change{ID: "All-Projects~refs%2Fmeta%2Fconfig~I1", Number: 42, Status: "NEW"}, nil)
+
+	res, updated := reconcileReviewInstance(t, rcn, k8sClient)
+
+	assert.Equal(t, reviewPollInterval, res.RequeueAfter)
+	assert.Equal(t, statusReviewPending, updated.Status.Value)
+	assert.False(t, updated.Status.Created)
+	assert.Equal(t, "All-Projects~refs%2Fmeta%2Fconfig~I1", updated.Status.ReviewChangeID)
+	assert.Equal(t, 42, updated.Status.ReviewChangeNumber)
+	assert.Equal(t, gerritClient.ChangeStatusNew, updated.Status.ReviewStatus)
+	assert.Contains(t, updated.Finalizers, finalizerName)
+
+	clientMock.On("ChangeGet", "All-Projects~refs%2Fmeta%2Fconfig~I1").Return(&gerritClient.Change{Status: "NEW"}, nil).Once()
+
+	res, updated = reconcileReviewInstance(t, rcn, k8sClient)
+
+	assert.Equal(t, reviewPollInterval, res.RequeueAfter)
+	assert.Equal(t, statusReviewPending, updated.Status.Value)
+
+	clientMock.On("ChangeGet", "All-Projects~refs%2Fmeta%2Fconfig~I1").Return(&gerritClient.Change{Status: "MERGED"}, nil).Once()
+	clientMock.On("GetAccessRights", "All-Projects").Return(&gerritClient.ProjectAccess{Permissions: reviewRules}, nil)
+
+	res, updated = reconcileReviewInstance(t, rcn, k8sClient)
+
+	assert.Equal(t, helper.DriftCheckInterval(), res.RequeueAfter)
+	assert.Equal(t, helper.StatusOK, updated.Status.Value)
+	assert.True(t, updated.Status.Created)
+	assert.Equal(t, gerritClient.ChangeStatusMerged, updated.Status.ReviewStatus)
+
+	clientMock.AssertExpectations(t)
+}
+
+func TestReconcile_Review_OutdatedChange(t *testing.T) {
+	instance := newReviewInstance()
+	instance.Generation = 2
+	instance.Status = gerritApi.GerritProjectAccessStatus{
+		ReviewChangeID:     "I1",
+		ReviewChangeNumber: 41,
+		ReviewStatus:       gerritClient.ChangeStatusNew,
+		ReviewGeneration:   1,
+	}
+
+	rcn, k8sClient, clientMock := newReviewReconcile(t, instance)
+
+	clientMock.On("ChangeGet", "I1").Return(&gerritClient.Change{Status: "NEW"}, nil)
+	clientMock.On("ChangeAbandon", "I1").Return(nil)
+	clientMock.On("GetAccessRights", "All-Projects").Return(&gerritClient.ProjectAccess{}, nil)
+	clientMock.On("ReviewAccessRights", "All-Projects", "", reviewRules, reviewRules).
+		Return(&gerritClient.Change{ID: "I2", Number: 42, Status: "NEW"}, nil)
+
+	_, updated := reconcileReviewInstance(t, rcn, k8sClient)
+
+	assert.Equal(t, statusReviewPending, updated.Status.Value)
+	assert.Equal(t, "I2", updated.Status.ReviewChangeID)
+	assert.Equal(t, updated.Generation, updated.Status.ReviewGeneration)
+
+	clientMock.AssertExpectations(t)
+}
+
+func TestReconcile_Review_Abandoned(t *testing.T) {
+	instance := newReviewInstance()
+	instance.Generation = 1
+	instance.Status = gerritApi.GerritProjectAccessStatus{
+		ReviewChangeID:     "I1",
+		ReviewChangeNumber: 41,
+		ReviewStatus:       gerritClient.ChangeStatusNew,
+		ReviewGeneration:   1,
+	}
+
+	rcn, k8sClient, clientMock := newReviewReconcile(t, instance)
+
+	clientMock.On("ChangeGet", "I1").Return(&gerritClient.Change{Status: "ABANDONED"}, nil)
+	clientMock.On("GetAccessRights", "All-Projects").Return(&gerritClient.ProjectAccess{}, nil)
+
+	res, updated := reconcileReviewInstance(t, rcn, k8sClient)
+
+	assert.Equal(t, helper.DriftCheckInterval(), res.RequeueAfter)
+	assert.Equal(t, statusReviewAbandoned, updated.Status.Value)
+	assert.Equal(t, gerritClient.ChangeStatusAbandoned, updated.Status.ReviewStatus)
+	assert.False(t, updated.Status.Created)
+
+	clientMock.AssertNotCalled(t, "ReviewAccessRights", "All-Projects", "", reviewRules, reviewRules)
+	clientMock.AssertExpectations(t)
+}
+
+func TestReconcile_deleteReview(t *testing.T) {
+	instance := newReviewInstance()
+	instance.Status = gerritApi.GerritProjectAccessStatus{
+		Created:        true,
+		ReviewChangeID: "I2",
+		ReviewStatus:   gerritClient.ChangeStatusNew,
+	}
+
+	clientMock := gerritClientMocks.ClientInterface{}
+	clientMock.On("ChangeAbandon", "I2").Return(nil)
+	clientMock.On("ReviewAccessRights", "All-Projects", "", []gerritClient.AccessInfo(nil), reviewRules).
+		Return(&gerritClient.Change{ID: "I3", Number: 43, Status: "NEW"}, nil)
+
+	rcn := Reconcile{}
+
+	require.NoError(t, rcn.deleteReview(&clientMock, instance, reviewRules))
+
+	clientMock.AssertExpectations(t)
+}
@@ -6,7 +6,10 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"reflect"
 	"regexp"
+	"sort"
+	"strings"
 	"text/template"
 	"time"
 
@@ -14,6 +17,7 @@ import (
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -29,6 +33,7 @@ import (
 	"github.com/epam/edp-gerrit-operator/v2/pkg/service/gerrit/spec"
 	"github.com/epam/edp-gerrit-operator/v2/pkg/service/platform"
 	platformHelper "github.com/epam/edp-gerrit-operator/v2/pkg/service/platform/helper"
+	"github.com/epam/edp-gerrit-operator/v2/pkg/tracing"
 )
 
 const (
@@ -39,9 +44,12 @@ const (
 	containerFlag = "-c"
 )
 
+// errNoReadyPods means that there are no ready Gerrit primary pods, e.g. Gerrit is restarted or rolled out.
+var errNoReadyPods = errors.New("there are no ready Gerrit primary pods")
+
 type configurationArguments struct {
 	sshPortService     int32
-	gerritPodName      string
+	gerritPodNames     []string
 	gerritUrl          string
 	gerritAdminSshKeys map[string][]byte
 	gerritVCSSshKey    map[string][]byte
@@ -68,6 +76,7 @@ type ReconcileGerritReplicationConfig struct {
 	platform         platform.PlatformService
 	componentService gerritService.Interface
 	log              logr.Logger
+	recorder         record.EventRecorder
 }
 
 func (r *ReconcileGerritReplicationConfig) SetupWithManager(mgr ctrl.Manager) error {
@@ -75,9 +84,11 @@ func (r *ReconcileGerritReplicationConfig) SetupWithManager(mgr ctrl.Manager) er
 		UpdateFunc: isSpecUpdated,
 	}
 
+	r.recorder = mgr.GetEventRecorderFor("gerrit-replication-config")
+
 	err := ctrl.NewControllerManagedBy(mgr).
 		For(&gerritApi.GerritReplicationConfig{}, builder.WithPredicates(p)).
-		Complete(r)
+		Complete(tracing.NewReconciler("GerritReplicationConfig", r))
 	if err != nil {
 		return fmt.Errorf("failed to setup GerritReplicationConfig controller: %w", err)
 	}
@@ -96,7 +107,7 @@ func isSpecUpdated(e event.UpdateEvent) bool {
 		return false
 	}
 
-	return oo.Status == no.Status
+	return reflect.DeepEqual(oo.Status, no.Status)
 }
 
 // +kubebuilder:rbac:groups=v2.edp.epam.com,namespace=placeholder,resources=gerritreplicationconfigs,verbs=get;list;watch;create;update;patch;delete
@@ -119,25 +130,12 @@ func (r *ReconcileGerritReplicationConfig) Reconcile(ctx context.Context, reques
 		return reconcile.Result{}, fmt.Errorf("failed to get instance: %w", err)
 	}
 
-	if !helper.IsInstanceOwnerSet(instance) {
-		ownerReference := helper.FindCROwnerName(instance.Spec.OwnerName)
-
-		var gerritInstance *gerritApi.Gerrit
-
-		gerritInstance, err = helper.GetGerritInstance(ctx, r.client, ownerReference, instance.Namespace)
-		if err != nil {
-			return reconcile.Result{}, fmt.Errorf("failed to get gerrit instance: %w", err)
-		}
-
-		helper.SetOwnerReference(instance, metaV1.TypeMeta{Kind: "Gerrit", APIVersion: gerritApi.GroupVersion.String()}, &gerritInstance.ObjectMeta)
-
-		err = r.client.Update(ctx, instance)
-		if err != nil {
-			return reconcile.Result{}, fmt.Errorf("failed to update instance owner refs: %w", err)
-		}
+	if helper.IsReconcilePaused(instance) {
+		log.Info("Reconciliation of GerritReplicationConfig is paused")
+		return reconcile.Result{}, nil
 	}
 
-	gerritInstance, err := helper.GetInstanceOwner(ctx, r.client, instance)
+	gerritInstance, err := helper.ResolveHealthyGerritOwner(ctx, r.client, instance, instance.Spec.OwnerName)
 	if err != nil {
 		if k8sErrors.IsNotFound(err) {
 			return reconcile.Result{}, nil
@@ -146,21 +144,51 @@ func (r *ReconcileGerritReplicationConfig) Reconcile(ctx context.Context, reques
 		return reconcile.Result{}, fmt.Errorf("failed to get instance owner: %w", err)
 	}
 
-	if gerritInstance.Status.Status == gerritController.StatusReady && (instance.Status.Status == "" || instance.Status.Status == spec.StatusFailed) {
+	if helper.IsDryRun(instance) {
+		return reconcile.Result{}, r.planReplication(ctx, instance, gerritInstance)
+	}
+
+	instance.Status.PlannedActions = nil
+
+	if needsConfiguration(instance, gerritInstance) {
 		log.Info(fmt.Sprintf("Replication configuration of %s/%s object with name has been started",
 			gerritInstance.Namespace, gerritInstance.Name))
 		log.Info(fmt.Sprintf("Configuration of %s/%s object with name has been started", instance.Namespace, instance.Name))
 
+		previousStatus := instance.Status.Status
+
 		err = r.updateStatus(ctx, instance, spec.StatusConfiguring)
 		if err != nil {
 			log.Error(err, "error while updating status", "status", instance.Status.Status)
 			return reconcile.Result{RequeueAfter: requeueTime}, nil
 		}
 
-		err = r.configureReplication(instance, gerritInstance)
+		err = r.configureReplication(ctx, instance, gerritInstance)
+		if errors.Is(err, errNoReadyPods) {
+			log.Info("Gerrit primary pods are not ready for configuration yet")
+
+			if err = r.updateStatus(ctx, instance, previousStatus); err != nil {
+				log.Error(err, "error while updating status", "status", instance.Status.Status)
+			}
+
+			return reconcile.Result{RequeueAfter: requeueTime}, nil
+		}
+
 		if err != nil {
+			helper.RecordWarning(r.recorder, instance, helper.EventReasonFailed, err)
+
+			// the failed status makes the next reconciliation configure all pods again
+			if statusErr := r.updateStatus(ctx, instance, spec.StatusFailed); statusErr != nil {
+				log.Error(statusErr, "error while updating status", "status", instance.Status.Status)
+			}
+
 			return reconcile.Result{}, err
 		}
+
+		instance.Status.ObservedGeneration = instance.Generation
+
+		helper.RecordEvent(r.recorder, instance, helper.EventReasonUpdated,
+			"Replication has been configured and the replication plugin has been reloaded")
 	}
 
 	if instance.Status.Status == spec.StatusConfiguring {
@@ -202,7 +230,37 @@ func (r *ReconcileGerritReplicationConfig) updateStatus(ctx context.Context, ins
 	return nil
 }
 
-func (r *ReconcileGerritReplicationConfig) configureReplication(config *gerritApi.GerritReplicationConfig, gerritObj *gerritApi.Gerrit) error {
+// needsConfiguration reports whether the replication has to be written into the Gerrit pods,
+// i.e. it has not been configured yet, the last configuration has failed or the spec has changed since.
+func needsConfiguration(instance *gerritApi.GerritReplicationConfig, gerritInstance *gerritApi.Gerrit) bool {
+	return gerritInstance.Status.Status == gerritController.StatusReady &&
+		(instance.Status.Status == "" || instance.Status.Status == spec.StatusFailed ||
+			instance.Status.ObservedGeneration != instance.Generation)
+}
+
+// planReplication writes the replication changes into the status without configuring Gerrit.
+func (r *ReconcileGerritReplicationConfig) planReplication(ctx context.Context, instance *gerritApi.GerritReplicationConfig,
+	gerritInstance *gerritApi.Gerrit,
+) error {
+	instance.Status.PlannedActions = nil
+
+	if needsConfiguration(instance, gerritInstance) {
+		instance.Status.PlannedActions = []string{
+			fmt.Sprintf("configure replication of Gerrit %s to %s", gerritInstance.Name, instance.Spec.SSHUrl),
+			"reload plugin replication",
+		}
+	}
+
+	if err := r.client.Status().Update(ctx, instance); err != nil {
+		return fmt.Errorf("failed to update GerritReplicationConfig status: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ReconcileGerritReplicationConfig) configureReplication(ctx context.Context, config *gerritApi.GerritReplicationConfig,
+	gerritObj *gerritApi.Gerrit,
+) error {
 	gerritTemplatesPath := platformHelper.LocalTemplatesRelativePath
 
 	executableFilePath, err := helper.GetExecutableFilePath()
@@ -220,11 +278,31 @@ func (r *ReconcileGerritReplicationConfig) configureReplication(config *gerritAp
 		return fmt.Errorf("failed to get configuration arguments: %w", err)
 	}
 
-	if err := r.saveSshReplicationKey(gerritObj.Namespace, configArgs.gerritPodName,
-		string(configArgs.gerritVCSSshKey["ssh-privatekey"])); err != nil {
+	remotes, err := r.getReplicationConfigs(ctx, config, gerritObj)
+	if err != nil {
 		return err
 	}
 
+	replicationConfig, err := renderReplicationConfig(remotes, gerritTemplatesPath)
+	if err != nil {
+		return err
+	}
+
+	sshConfig, err := renderSshConfig(remotes, gerritTemplatesPath,
+		filepath.Join(spec.GerritDefaultVCSKeyPath, spec.GerritDefaultVCSKeyName))
+	if err != nil {
+		return err
+	}
+
+	// the files are rendered from all replication configs of the Gerrit and replaced in every primary pod,
+	// so the changed and drifted remotes converge and the pods sharing the site volume are supported
+	for _, podName := range configArgs.gerritPodNames {
+		if err := r.configurePod(gerritObj.Namespace, podName, string(configArgs.gerritVCSSshKey["ssh-privatekey"]),
+			replicationConfig, sshConfig); err != nil {
+			return fmt.Errorf("failed to configure replication in pod %q: %w", podName, err)
+		}
+	}
+
 	k8sClient := gerritClient.Client{}
 
 	if err := k8sClient.InitNewSshClient(spec.GerritDefaultAdminUser, configArgs.gerritAdminSshKeys["id_rsa"],
@@ -232,36 +310,42 @@ func (r *ReconcileGerritReplicationConfig) configureReplication(config *gerritAp
 		return fmt.Errorf("failed to init ssh client for Gerrit admin user: %w", err)
 	}
 
-	if err := r.createReplicationConfig(gerritObj.Namespace, configArgs.gerritPodName); err != nil {
+	return r.reloadReplicationPlugin(&k8sClient)
+}
+
+func (r *ReconcileGerritReplicationConfig) configurePod(namespace, podName, key, replicationConfig, sshConfig string) error {
+	if err := r.saveSshReplicationKey(namespace, podName, key); err != nil {
 		return err
 	}
 
-	if err := r.updateReplicationConfig(gerritObj.Namespace, configArgs.gerritPodName, gerritTemplatesPath, config); err != nil {
-		return err
+	if err := r.replaceFile(namespace, podName, spec.DefaultGerritReplicationConfigPath, replicationConfig); err != nil {
+		return fmt.Errorf("failed to update Gerrit replication config: %w", err)
 	}
 
-	if err := r.updateSshConfig(gerritObj.Namespace, configArgs.gerritPodName, gerritTemplatesPath,
-		filepath.Join(spec.GerritDefaultVCSKeyPath, spec.GerritDefaultVCSKeyName), config); err != nil {
-		return err
+	if err := r.replaceFile(namespace, podName, spec.DefaultGerritSSHConfigPath+config, sshConfig); err != nil {
+		return fmt.Errorf("failed to update ssh config: %w", err)
 	}
 
-	return r.reloadReplicationPlugin(&k8sClient)
+	return nil
 }
 
 func (r *ReconcileGerritReplicationConfig) getConfigurationArgs(gerritObj *gerritApi.Gerrit,
 ) (*configurationArguments, error) {
-	podList, err := r.platform.GetPods(gerritObj.Namespace, &metaV1.ListOptions{LabelSelector: fmt.Sprintf("deploymentconfig=%s", gerritObj.Name)})
+	podList, err := r.platform.GetPods(gerritObj.Namespace, &metaV1.ListOptions{LabelSelector: gerritObj.GetPrimaryPodSelector()})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get Gerrit pods: %w", err)
 	}
 
-	if len(podList.Items) != 1 {
-		return nil, errors.New("found multiple pods of Gerrit instance. It seems that some of old pods did not shutdown yet")
+	pods := platformHelper.ReadyPods(podList.Items)
+	if len(pods) == 0 {
+		return nil, errNoReadyPods
 	}
 
 	var args configurationArguments
 
-	args.gerritPodName = podList.Items[0].Name
+	for i := range pods {
+		args.gerritPodNames = append(args.gerritPodNames, pods[i].Name)
+	}
 
 	args.gerritUrl, err = r.componentService.GetGerritSSHUrl(gerritObj)
 	if err != nil {
@@ -286,35 +370,60 @@ func (r *ReconcileGerritReplicationConfig) getConfigurationArgs(gerritObj *gerri
 	return &args, nil
 }
 
-func (r *ReconcileGerritReplicationConfig) createReplicationConfig(namespace, podName string) error {
-	command := []string{
-		bin, containerFlag,
-		fmt.Sprintf("[[ -f %v ]] || printf '%%s\n  %%s\n' '[gerrit]' 'defaultForceUpdate = true' > %v && chown -R gerrit2:gerrit2 %v",
-			spec.DefaultGerritReplicationConfigPath, spec.DefaultGerritReplicationConfigPath, spec.DefaultGerritReplicationConfigPath),
+// getReplicationConfigs returns the replication configs of the Gerrit sorted by namespace and name,
+// the configs in other namespaces reference the Gerrit as <namespace>/<name>.
+func (r *ReconcileGerritReplicationConfig) getReplicationConfigs(ctx context.Context,
+	instance *gerritApi.GerritReplicationConfig, gerritObj *gerritApi.Gerrit,
+) ([]gerritApi.GerritReplicationConfig, error) {
+	var list gerritApi.GerritReplicationConfigList
+
+	if err := r.client.List(ctx, &list); err != nil {
+		return nil, fmt.Errorf("failed to list GerritReplicationConfigs: %w", err)
 	}
 
-	_, _, err := r.platform.ExecInPod(namespace, podName, command)
-	if err != nil {
-		return fmt.Errorf("failed executing command to create replication config: %w", err)
+	// the reconciled instance is taken as is, since the cache may not contain its last version yet
+	remotes := []gerritApi.GerritReplicationConfig{*instance}
+
+	for i := range list.Items {
+		grc := &list.Items[i]
+
+		if (grc.Namespace == instance.Namespace && grc.Name == instance.Name) ||
+			!grc.GetDeletionTimestamp().IsZero() || !belongsTo(grc, gerritObj) {
+			continue
+		}
+
+		remotes = append(remotes, *grc)
 	}
 
-	return nil
+	sort.Slice(remotes, func(i, j int) bool {
+		if remotes[i].Namespace != remotes[j].Namespace {
+			return remotes[i].Namespace < remotes[j].Namespace
+		}
+
+		return remotes[i].Name < remotes[j].Name
+	})
+
+	return remotes, nil
 }
 
-func (r *ReconcileGerritReplicationConfig) createSshConfig(namespace, podName string) error {
-	command := []string{
-		bin, containerFlag,
-		fmt.Sprintf("[[ -f %v ]] || mkdir -p %v && touch %v && chown -R gerrit2:gerrit2 %v",
-			spec.DefaultGerritSSHConfigPath+config, spec.DefaultGerritSSHConfigPath,
-			spec.DefaultGerritSSHConfigPath+config, spec.DefaultGerritSSHConfigPath+config),
+// belongsTo reports whether the replication config configures the Gerrit.
+func belongsTo(grc *gerritApi.GerritReplicationConfig, gerritObj *gerritApi.Gerrit) bool {
+	ns, name, found := strings.Cut(strings.ToLower(grc.Spec.OwnerName), "/")
+
+	if grc.Namespace != gerritObj.Namespace {
+		return found && ns == gerritObj.Namespace && name == gerritObj.Name && gerritObj.IsNamespaceAllowed(grc.Namespace)
 	}
 
-	_, _, err := r.platform.ExecInPod(namespace, podName, command)
-	if err != nil {
-		return fmt.Errorf("failed executing command to create ssh config: %w", err)
+	if owner := helper.GetGerritOwner(grc.GetOwnerReferences()); owner != nil {
+		return owner.Name == gerritObj.Name
 	}
 
-	return nil
+	if !found {
+		name = ns
+	}
+
+	// the config without the owner name belongs to the first Gerrit in the namespace, it is resolved on its reconciliation
+	return name == "" || name == gerritObj.Name
 }
 
 func (r *ReconcileGerritReplicationConfig) saveSshReplicationKey(namespace, podName, key string) error {
@@ -329,47 +438,18 @@ func (r *ReconcileGerritReplicationConfig) saveSshReplicationKey(namespace, podN
 	return nil
 }
 
-func (r *ReconcileGerritReplicationConfig) updateReplicationConfig(
-	namespace, podName, templatePath string,
-	grc *gerritApi.GerritReplicationConfig,
-) error {
-	config, err := resolveReplicationTemplate(grc, templatePath, "replication-conf.tmpl")
-	if err != nil {
-		return err
-	}
-
-	command := []string{bin, containerFlag, fmt.Sprintf("echo \"%v\" >> %v", config.String(), spec.DefaultGerritReplicationConfigPath)}
-
-	_, _, err = r.platform.ExecInPod(namespace, podName, command)
-	if err != nil {
-		return fmt.Errorf("failed executing command to update Gerrit replication config: %w", err)
-	}
-
-	return nil
-}
-
-func (r *ReconcileGerritReplicationConfig) updateSshConfig(
-	namespace, podName, templatePath, keyPath string,
-	grc *gerritApi.GerritReplicationConfig,
-) error {
-	if err := r.createSshConfig(namespace, podName); err != nil {
-		return err
-	}
-
-	sshTemplate, err := resolveSshTemplate(grc, templatePath, "ssh-sshTemplate.tmpl", keyPath)
-	if err != nil {
-		return err
-	}
-
+// replaceFile writes the content into a temporary file and moves it to the path,
+// so Gerrit never reads a partially written file.
+func (r *ReconcileGerritReplicationConfig) replaceFile(namespace, podName, path, content string) error {
 	command := []string{
-		bin,
-		containerFlag,
-		fmt.Sprintf("echo %q >> %s", sshTemplate.String(), spec.DefaultGerritSSHConfigPath+config),
+		bin, containerFlag,
+		fmt.Sprintf("mkdir -p %[1]s && printf '%%s\\n' %[3]s > %[2]s.tmp && chown gerrit2:gerrit2 %[2]s.tmp && mv -f %[2]s.tmp %[2]s",
+			filepath.Dir(path), path, shellQuote(content)),
 	}
 
-	_, _, err = r.platform.ExecInPod(namespace, podName, command)
+	_, _, err := r.platform.ExecInPod(namespace, podName, command)
 	if err != nil {
-		return fmt.Errorf("failed to exec command in pod: %w", err)
+		return fmt.Errorf("failed executing command to replace %s: %w", path, err)
 	}
 
 	return nil
@@ -403,6 +483,50 @@ func (r *ReconcileGerritReplicationConfig) updateAvailableStatus(ctx context.Con
 	return nil
 }
 
+// renderReplicationConfig returns the replication.config with the remotes of all replication configs.
+func renderReplicationConfig(remotes []gerritApi.GerritReplicationConfig, templatePath string) (string, error) {
+	sections := []string{"[gerrit]\n  defaultForceUpdate = true\n  autoReload = true"}
+
+	for i := range remotes {
+		remote, err := resolveReplicationTemplate(&remotes[i], templatePath, "replication-conf.tmpl")
+		if err != nil {
+			return "", err
+		}
+
+		sections = append(sections, remote.String())
+	}
+
+	return strings.Join(sections, "\n"), nil
+}
+
+// renderSshConfig returns the ssh config with a host entry for every replication host.
+func renderSshConfig(remotes []gerritApi.GerritReplicationConfig, templatePath, keyPath string) (string, error) {
+	hosts := make(map[string]bool, len(remotes))
+	entries := make([]string, 0, len(remotes))
+
+	for i := range remotes {
+		hostname, err := replicationHost(&remotes[i])
+		if err != nil {
+			return "", err
+		}
+
+		if hosts[hostname] {
+			continue
+		}
+
+		hosts[hostname] = true
+
+		entry, err := resolveSshTemplate(hostname, templatePath, "ssh-config.tmpl", keyPath)
+		if err != nil {
+			return "", err
+		}
+
+		entries = append(entries, entry.String())
+	}
+
+	return strings.Join(entries, "\n"), nil
+}
+
 func resolveReplicationTemplate(grc *gerritApi.GerritReplicationConfig, path, templateName string) (*bytes.Buffer, error) {
 	var config bytes.Buffer
 
@@ -421,16 +545,23 @@ func resolveReplicationTemplate(grc *gerritApi.GerritReplicationConfig, path, te
 	return &config, nil
 }
 
-func resolveSshTemplate(grc *gerritApi.GerritReplicationConfig, path, templateName, keyPath string) (*bytes.Buffer, error) {
-	var config bytes.Buffer
+// replicationHost returns the host of the ssh url of the replication config, e.g. github.com for git@github.com:org.
+func replicationHost(grc *gerritApi.GerritReplicationConfig) (string, error) {
+	host := regexp.MustCompile(`@([^\[\]]*):`).FindStringSubmatch(grc.Spec.SSHUrl)
+	if host == nil {
+		return "", fmt.Errorf("failed to find host in ssh url %q of GerritReplicationConfig %s", grc.Spec.SSHUrl, grc.Name)
+	}
+
+	return host[1], nil
+}
 
-	re := regexp.MustCompile(`@([^\[\]]*):`)
-	host := re.FindStringSubmatch(grc.Spec.SSHUrl)
+func resolveSshTemplate(hostname, path, templateName, keyPath string) (*bytes.Buffer, error) {
+	var config bytes.Buffer
 
 	data := struct {
 		Hostname string
 		KeyPath  string
-	}{host[1], keyPath}
+	}{hostname, keyPath}
 	templatePath := filepath.FromSlash(filepath.Join(path, templateName))
 
 	tmpl, err := template.New(templateName).ParseFiles(templatePath)
@@ -445,3 +576,8 @@ func resolveSshTemplate(grc *gerritApi.GerritReplicationConfig, path, templateNa
 
 	return &config, nil
 }
+
+// shellQuote quotes the value as a single shell word.
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
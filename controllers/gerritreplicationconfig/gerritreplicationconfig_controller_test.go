@@ -2,18 +2,16 @@ package gerritreplicationconfig
 
 import (
 	"context"
-	"crypto/rand"
-	"crypto/rsa"
-	"crypto/x509"
-	"encoding/pem"
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	appsV1 "k8s.io/api/apps/v1"
 	coreV1Api "k8s.io/api/core/v1"
@@ -139,6 +137,34 @@ func TestReconcileGerritReplicationConfig_Reconcile_GetGerritInstanceErr(t *test
 	assert.Equal(t, reconcile.Result{}, rs)
 }
 
+func TestReconcileGerritReplicationConfig_Reconcile_UnhealthyGerrit(t *testing.T) {
+	ctx := context.Background()
+
+	instance := createGerritReplicationConfig("")
+	gerritInstance := createGerritByStatus(gerritController.StatusReady)
+	gerritInstance.Status.Conditions = []metaV1.Condition{
+		{Type: gerritApi.ConditionHealthy, Status: metaV1.ConditionFalse, Reason: "ProbeFailed", LastTransitionTime: metaV1.Now()},
+	}
+
+	s := runtime.NewScheme()
+	s.AddKnownTypes(appsV1.SchemeGroupVersion, &gerritApi.Gerrit{}, &gerritApi.GerritList{}, &gerritApi.GerritGroup{}, &gerritApi.GerritReplicationConfig{})
+	cl := fake.NewClientBuilder().WithStatusSubresource(&gerritApi.Gerrit{}, &gerritApi.GerritReplicationConfig{}).
+		WithObjects(instance, gerritInstance).WithScheme(s).Build()
+
+	rg := ReconcileGerritReplicationConfig{
+		client: cl,
+		log:    commonmock.NewLogr(),
+	}
+
+	_, err := rg.Reconcile(ctx, reconcile.Request{NamespacedName: nsn})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is unhealthy")
+
+	updated := &gerritApi.GerritReplicationConfig{}
+	require.NoError(t, cl.Get(ctx, nsn, updated))
+	assert.Empty(t, updated.Status.Status)
+}
+
 func TestReconcileGerritReplicationConfig_Reconcile_UpdateAfterSetOwnerErr(t *testing.T) {
 	mc := mocks.Client{}
 	ctx := context.Background()
@@ -411,7 +437,7 @@ func TestReconcileGerritReplicationConfig_Reconcile_configureReplicationErr(t *t
 	mc.On("Status").Return(sw)
 	mc.On("List", &list).Return(cl)
 
-	platformMock.On("GetPods", namespace, &metaV1.ListOptions{LabelSelector: fmt.Sprintf("deploymentconfig=%v", name)}).Return(&coreV1Api.PodList{}, errTest)
+	platformMock.On("GetPods", namespace, &metaV1.ListOptions{LabelSelector: "app=" + name}).Return(&coreV1Api.PodList{}, errTest)
 
 	rg := ReconcileGerritReplicationConfig{
 		client:           &mc,
@@ -428,6 +454,43 @@ func TestReconcileGerritReplicationConfig_Reconcile_configureReplicationErr(t *t
 	assert.Equal(t, reconcile.Result{}, rs)
 }
 
+func TestReconcileGerritReplicationConfig_Reconcile_NoReadyPods(t *testing.T) {
+	sw := &mocks.StatusWriter{}
+	mc := mocks.Client{}
+	ctx := context.Background()
+	platformMock := pmocks.PlatformService{}
+
+	instance := createGerritReplicationConfig(spec.StatusFailed)
+	gerritInstance := createGerritByStatus(gerritController.StatusReady)
+
+	var list gerritApi.GerritList
+
+	s := runtime.NewScheme()
+	s.AddKnownTypes(appsV1.SchemeGroupVersion, &gerritApi.Gerrit{}, &gerritApi.GerritList{}, &gerritApi.GerritReplicationConfig{})
+
+	cl := fake.NewClientBuilder().WithStatusSubresource(&gerritApi.Gerrit{}, &gerritApi.GerritReplicationConfig{}).WithObjects(instance, gerritInstance).WithScheme(s).Build()
+
+	sw.On("Update").Return(nil)
+	mc.On("Update").Return(nil)
+	mc.On("Get", nsn, &gerritApi.GerritReplicationConfig{}).Return(cl)
+	mc.On("Get", nsn, &gerritApi.Gerrit{}).Return(cl)
+	mc.On("Status").Return(sw)
+	mc.On("List", &list).Return(cl)
+
+	platformMock.On("GetPods", namespace, &metaV1.ListOptions{LabelSelector: "app=" + name}).Return(&coreV1Api.PodList{}, nil)
+
+	rg := ReconcileGerritReplicationConfig{
+		client:   &mc,
+		platform: &platformMock,
+		log:      logr.Discard(),
+	}
+
+	rs, err := rg.Reconcile(ctx, reconcile.Request{NamespacedName: nsn})
+
+	assert.NoError(t, err)
+	assert.Equal(t, reconcile.Result{RequeueAfter: 10 * time.Second}, rs)
+}
+
 func Test_configureReplication_GetGerritSSHUrlErr(t *testing.T) {
 	platformMock := pmocks.PlatformService{}
 	gServiceMock := gmock.Interface{}
@@ -435,15 +498,11 @@ func Test_configureReplication_GetGerritSSHUrlErr(t *testing.T) {
 	instance := createGerritReplicationConfig("")
 	gerritInstance := createGerritByStatus(gerritController.StatusReady)
 
-	pl := &coreV1Api.PodList{
-		Items: []coreV1Api.Pod{
-			{},
-		},
-	}
+	pl := createReadyPodList("")
 
 	errTest := errors.New("test")
 
-	platformMock.On("GetPods", namespace, &metaV1.ListOptions{LabelSelector: fmt.Sprintf("deploymentconfig=%v", name)}).Return(pl, nil)
+	platformMock.On("GetPods", namespace, &metaV1.ListOptions{LabelSelector: "app=" + name}).Return(pl, nil)
 	gServiceMock.On("GetGerritSSHUrl", gerritInstance).Return("", errTest)
 
 	rg := ReconcileGerritReplicationConfig{
@@ -452,7 +511,7 @@ func Test_configureReplication_GetGerritSSHUrlErr(t *testing.T) {
 		log:              logr.Discard(),
 	}
 
-	err := rg.configureReplication(instance, gerritInstance)
+	err := rg.configureReplication(context.Background(), instance, gerritInstance)
 	assert.ErrorIs(t, err, errTest)
 }
 
@@ -463,15 +522,11 @@ func Test_configureReplication_GetServicePortErr(t *testing.T) {
 	instance := createGerritReplicationConfig("")
 	gerritInstance := createGerritByStatus(gerritController.StatusReady)
 
-	pl := &coreV1Api.PodList{
-		Items: []coreV1Api.Pod{
-			{},
-		},
-	}
+	pl := createReadyPodList("")
 
 	errTest := errors.New("test")
 
-	platformMock.On("GetPods", namespace, &metaV1.ListOptions{LabelSelector: fmt.Sprintf("deploymentconfig=%v", name)}).Return(pl, nil)
+	platformMock.On("GetPods", namespace, &metaV1.ListOptions{LabelSelector: "app=" + name}).Return(pl, nil)
 	gServiceMock.On("GetGerritSSHUrl", gerritInstance).Return("", nil)
 	gServiceMock.On("GetServicePort", gerritInstance).Return(int32(0), errTest)
 
@@ -481,7 +536,7 @@ func Test_configureReplication_GetServicePortErr(t *testing.T) {
 		log:              logr.Discard(),
 	}
 
-	err := rg.configureReplication(instance, gerritInstance)
+	err := rg.configureReplication(context.Background(), instance, gerritInstance)
 	assert.ErrorIs(t, err, errTest)
 }
 
@@ -492,15 +547,11 @@ func Test_configureReplication_FirstGetSecretErr(t *testing.T) {
 	instance := createGerritReplicationConfig("")
 	gerritInstance := createGerritByStatus(gerritController.StatusReady)
 
-	pl := &coreV1Api.PodList{
-		Items: []coreV1Api.Pod{
-			{},
-		},
-	}
+	pl := createReadyPodList("")
 
 	errTest := errors.New("test")
 
-	platformMock.On("GetPods", namespace, &metaV1.ListOptions{LabelSelector: fmt.Sprintf("deploymentconfig=%v", name)}).Return(pl, nil)
+	platformMock.On("GetPods", namespace, &metaV1.ListOptions{LabelSelector: "app=" + name}).Return(pl, nil)
 	gServiceMock.On("GetGerritSSHUrl", gerritInstance).Return("", nil)
 	gServiceMock.On("GetServicePort", gerritInstance).Return(int32(0), nil)
 	platformMock.On("GetSecret", gerritInstance.Namespace, gerritInstance.Name+"-admin").Return(nil, errTest)
@@ -511,7 +562,7 @@ func Test_configureReplication_FirstGetSecretErr(t *testing.T) {
 		log:              logr.Discard(),
 	}
 
-	err := rg.configureReplication(instance, gerritInstance)
+	err := rg.configureReplication(context.Background(), instance, gerritInstance)
 	assert.ErrorIs(t, err, errTest)
 }
 
@@ -522,15 +573,11 @@ func Test_configureReplication_SecondGetSecretErr(t *testing.T) {
 	instance := createGerritReplicationConfig("")
 	gerritInstance := createGerritByStatus(gerritController.StatusReady)
 
-	pl := &coreV1Api.PodList{
-		Items: []coreV1Api.Pod{
-			{},
-		},
-	}
+	pl := createReadyPodList("")
 
 	errTest := errors.New("test")
 
-	platformMock.On("GetPods", namespace, &metaV1.ListOptions{LabelSelector: fmt.Sprintf("deploymentconfig=%v", name)}).Return(pl, nil)
+	platformMock.On("GetPods", namespace, &metaV1.ListOptions{LabelSelector: "app=" + name}).Return(pl, nil)
 	gServiceMock.On("GetGerritSSHUrl", gerritInstance).Return("", nil)
 	gServiceMock.On("GetServicePort", gerritInstance).Return(int32(0), nil)
 	platformMock.On("GetSecret", gerritInstance.Namespace, gerritInstance.Name+"-admin").Return(nil, nil)
@@ -542,38 +589,44 @@ func Test_configureReplication_SecondGetSecretErr(t *testing.T) {
 		log:              logr.Discard(),
 	}
 
-	err := rg.configureReplication(instance, gerritInstance)
+	err := rg.configureReplication(context.Background(), instance, gerritInstance)
 	assert.ErrorIs(t, err, errTest)
 }
 
-func Test_configureReplication_saveSshReplicationKeyErr(t *testing.T) {
+func Test_configureReplication_NoReadyPods(t *testing.T) {
 	platformMock := pmocks.PlatformService{}
-	gServiceMock := gmock.Interface{}
 
 	instance := createGerritReplicationConfig("")
 	gerritInstance := createGerritByStatus(gerritController.StatusReady)
 
-	pl := &coreV1Api.PodList{
-		Items: []coreV1Api.Pod{
-			{},
-		},
+	pl := &coreV1Api.PodList{Items: []coreV1Api.Pod{{ObjectMeta: metaV1.ObjectMeta{Name: "starting"}}}}
+
+	platformMock.On("GetPods", namespace, &metaV1.ListOptions{LabelSelector: "app=" + name}).Return(pl, nil)
+
+	rg := ReconcileGerritReplicationConfig{
+		platform: &platformMock,
+		log:      logr.Discard(),
 	}
 
-	errTest := errors.New("test")
+	err := rg.configureReplication(context.Background(), instance, gerritInstance)
+	assert.ErrorIs(t, err, errNoReadyPods)
+}
 
-	platformMock.On("GetPods", namespace, &metaV1.ListOptions{LabelSelector: fmt.Sprintf("deploymentconfig=%v", name)}).Return(pl, nil)
-	gServiceMock.On("GetGerritSSHUrl", gerritInstance).Return("", nil)
-	gServiceMock.On("GetServicePort", gerritInstance).Return(int32(0), nil)
-	platformMock.On("GetSecret", gerritInstance.Namespace, gerritInstance.Name+"-admin").Return(nil, nil)
-	platformMock.On("GetSecret", gerritInstance.Namespace, spec.GerritDefaultVCSKeyName).Return(nil, nil)
+func Test_getConfigurationArgs_PrimaryPods(t *testing.T) {
+	platformMock := pmocks.PlatformService{}
+	gServiceMock := gmock.Interface{}
 
-	path := fmt.Sprintf("%v/%v", spec.GerritDefaultVCSKeyPath, spec.GerritDefaultVCSKeyName)
-	tr := []string{
-		"/bin/sh", "-c",
-		fmt.Sprintf("echo \"%v\" > %v && chmod 600 %v", "", path, path),
-	}
+	gerritInstance := createGerritByStatus(gerritController.StatusReady)
+	gerritInstance.Spec.PrimaryPodSelector = map[string]string{"app": name, "role": "primary"}
 
-	platformMock.On("ExecInPod", namespace, "", tr).Return(nil, nil, errTest)
+	pl := createReadyPodList("primary-0", "primary-1")
+	pl.Items = append(pl.Items, coreV1Api.Pod{ObjectMeta: metaV1.ObjectMeta{Name: "primary-2"}})
+
+	platformMock.On("GetPods", namespace, &metaV1.ListOptions{LabelSelector: "app=name,role=primary"}).Return(pl, nil)
+	gServiceMock.On("GetGerritSSHUrl", gerritInstance).Return("gerrit", nil)
+	gServiceMock.On("GetServicePort", gerritInstance).Return(int32(22), nil)
+	platformMock.On("GetSecret", gerritInstance.Namespace, gerritInstance.Name+"-admin").Return(nil, nil)
+	platformMock.On("GetSecret", gerritInstance.Namespace, spec.GerritDefaultVCSKeyName).Return(nil, nil)
 
 	rg := ReconcileGerritReplicationConfig{
 		platform:         &platformMock,
@@ -581,165 +634,197 @@ func Test_configureReplication_saveSshReplicationKeyErr(t *testing.T) {
 		log:              logr.Discard(),
 	}
 
-	err := rg.configureReplication(instance, gerritInstance)
-	assert.ErrorIs(t, err, errTest)
+	args, err := rg.getConfigurationArgs(gerritInstance)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"primary-0", "primary-1"}, args.gerritPodNames)
 }
 
-func Test_configureReplication_InitNewSshClientErr(t *testing.T) {
+func Test_configureReplication_TemplateErr(t *testing.T) {
 	platformMock := pmocks.PlatformService{}
 	gServiceMock := gmock.Interface{}
 
 	instance := createGerritReplicationConfig("")
 	gerritInstance := createGerritByStatus(gerritController.StatusReady)
 
-	pl := &coreV1Api.PodList{
-		Items: []coreV1Api.Pod{
-			{},
-		},
-	}
+	s := runtime.NewScheme()
+	require.NoError(t, gerritApi.AddToScheme(s))
 
-	platformMock.On("GetPods", namespace, &metaV1.ListOptions{LabelSelector: fmt.Sprintf("deploymentconfig=%v", name)}).Return(pl, nil)
-	gServiceMock.On("GetGerritSSHUrl", gerritInstance).Return("", nil)
-	gServiceMock.On("GetServicePort", gerritInstance).Return(int32(0), nil)
+	platformMock.On("GetPods", namespace, &metaV1.ListOptions{LabelSelector: "app=" + name}).Return(createReadyPodList("gerrit-0"), nil)
+	gServiceMock.On("GetGerritSSHUrl", gerritInstance).Return("testurl", nil)
+	gServiceMock.On("GetServicePort", gerritInstance).Return(int32(80), nil)
 	platformMock.On("GetSecret", gerritInstance.Namespace, gerritInstance.Name+"-admin").Return(nil, nil)
 	platformMock.On("GetSecret", gerritInstance.Namespace, spec.GerritDefaultVCSKeyName).Return(nil, nil)
 
-	path := fmt.Sprintf("%v/%v", spec.GerritDefaultVCSKeyPath, spec.GerritDefaultVCSKeyName)
-	tr := []string{
-		"/bin/sh", "-c",
-		fmt.Sprintf("echo \"%v\" > %v && chmod 600 %v", "", path, path),
-	}
-
-	platformMock.On("ExecInPod", namespace, "", tr).Return(nil, nil, nil)
-
 	rg := ReconcileGerritReplicationConfig{
+		client:           fake.NewClientBuilder().WithScheme(s).WithObjects(instance).Build(),
 		platform:         &platformMock,
 		componentService: &gServiceMock,
 		log:              logr.Discard(),
 	}
 
-	err := rg.configureReplication(instance, gerritInstance)
-	assert.Error(t, err)
+	// the templates are not found outside the operator image, so nothing is written into the pods
+	err := rg.configureReplication(context.Background(), instance, gerritInstance)
+	require.Error(t, err)
+	platformMock.AssertNotCalled(t, "ExecInPod")
 }
 
-func Test_configureReplication_createReplicationConfigErr(t *testing.T) {
+func Test_configurePod_saveSshReplicationKeyErr(t *testing.T) {
 	platformMock := pmocks.PlatformService{}
-	gServiceMock := gmock.Interface{}
 
-	instance := createGerritReplicationConfig("")
-	gerritInstance := createGerritByStatus(gerritController.StatusReady)
+	errTest := errors.New("test")
 
-	pl := &coreV1Api.PodList{
-		Items: []coreV1Api.Pod{
-			{},
-		},
+	path := fmt.Sprintf("%v/%v", spec.GerritDefaultVCSKeyPath, spec.GerritDefaultVCSKeyName)
+	tr := []string{
+		"/bin/sh", "-c",
+		fmt.Sprintf("echo \"%v\" > %v && chmod 600 %v", "key", path, path),
 	}
 
-	pk, err := rsa.GenerateKey(rand.Reader, 2048)
-	require.NoError(t, err)
+	platformMock.On("ExecInPod", namespace, "gerrit-0", tr).Return(nil, nil, errTest)
 
-	privkeyBytes := x509.MarshalPKCS1PrivateKey(pk)
-	privkeyPem := pem.EncodeToMemory(
-		&pem.Block{
-			Type:  "RSA PRIVATE KEY",
-			Bytes: privkeyBytes,
-		},
-	)
-
-	keys := map[string][]byte{
-		"id_rsa": privkeyPem,
+	rg := ReconcileGerritReplicationConfig{
+		platform: &platformMock,
+		log:      logr.Discard(),
 	}
 
-	errTest := errors.New("test")
+	err := rg.configurePod(namespace, "gerrit-0", "key", "", "")
+	assert.ErrorIs(t, err, errTest)
+}
 
-	platformMock.On("GetPods", namespace, &metaV1.ListOptions{LabelSelector: fmt.Sprintf("deploymentconfig=%v", name)}).Return(pl, nil)
-	gServiceMock.On("GetGerritSSHUrl", gerritInstance).Return("testurl", nil)
-	gServiceMock.On("GetServicePort", gerritInstance).Return(int32(80), nil)
-	platformMock.On("GetSecret", gerritInstance.Namespace, gerritInstance.Name+"-admin").Return(keys, nil)
-	platformMock.On("GetSecret", gerritInstance.Namespace, spec.GerritDefaultVCSKeyName).Return(nil, nil)
+func Test_configurePod(t *testing.T) {
+	platformMock := pmocks.PlatformService{}
 
 	path := fmt.Sprintf("%v/%v", spec.GerritDefaultVCSKeyPath, spec.GerritDefaultVCSKeyName)
-	tr := []string{
+
+	platformMock.On("ExecInPod", namespace, "gerrit-0", []string{
+		"/bin/sh", "-c",
+		fmt.Sprintf("echo \"%v\" > %v && chmod 600 %v", "key", path, path),
+	}).Return(nil, nil, nil)
+	platformMock.On("ExecInPod", namespace, "gerrit-0", []string{
 		"/bin/sh", "-c",
-		fmt.Sprintf("echo \"%v\" > %v && chmod 600 %v", "", path, path),
+		`mkdir -p /var/gerrit/review_site/etc && printf '%s\n' '[gerrit]' > /var/gerrit/review_site/etc/replication.config.tmp && ` +
+			`chown gerrit2:gerrit2 /var/gerrit/review_site/etc/replication.config.tmp && ` +
+			`mv -f /var/gerrit/review_site/etc/replication.config.tmp /var/gerrit/review_site/etc/replication.config`,
+	}).Return(nil, nil, nil)
+	platformMock.On("ExecInPod", namespace, "gerrit-0", []string{
+		"/bin/sh", "-c",
+		`mkdir -p /var/gerrit/.ssh && printf '%s\n' 'Host '\''github'\''' > /var/gerrit/.ssh/config.tmp && ` +
+			`chown gerrit2:gerrit2 /var/gerrit/.ssh/config.tmp && mv -f /var/gerrit/.ssh/config.tmp /var/gerrit/.ssh/config`,
+	}).Return(nil, nil, nil)
+
+	rg := ReconcileGerritReplicationConfig{
+		platform: &platformMock,
+		log:      logr.Discard(),
 	}
 
-	platformMock.On("ExecInPod", namespace, "", tr).Return(nil, nil, nil)
+	err := rg.configurePod(namespace, "gerrit-0", "key", "[gerrit]", "Host 'github'")
+	require.NoError(t, err)
+	platformMock.AssertExpectations(t)
+}
 
-	tr2 := []string{
-		"/bin/sh", "-c",
-		fmt.Sprintf("[[ -f %v ]] || printf '%%s\n  %%s\n' '[gerrit]' 'defaultForceUpdate = true' > %v && chown -R gerrit2:gerrit2 %v",
-			spec.DefaultGerritReplicationConfigPath, spec.DefaultGerritReplicationConfigPath, spec.DefaultGerritReplicationConfigPath),
-	}
-	platformMock.On("ExecInPod", namespace, "", tr2).Return(nil, nil, errTest)
+func Test_configurePod_replaceFileErr(t *testing.T) {
+	platformMock := pmocks.PlatformService{}
+
+	errTest := errors.New("test")
+
+	platformMock.On("ExecInPod", namespace, "gerrit-0", mock.Anything).Return(nil, nil, nil).Once()
+	platformMock.On("ExecInPod", namespace, "gerrit-0", mock.Anything).Return(nil, nil, errTest).Once()
 
 	rg := ReconcileGerritReplicationConfig{
-		platform:         &platformMock,
-		componentService: &gServiceMock,
-		log:              logr.Discard(),
+		platform: &platformMock,
+		log:      logr.Discard(),
 	}
 
-	err = rg.configureReplication(instance, gerritInstance)
+	err := rg.configurePod(namespace, "gerrit-0", "key", "[gerrit]", "")
 	assert.ErrorIs(t, err, errTest)
 }
 
-func Test_configureReplication_updateReplicationConfigErr(t *testing.T) {
-	platformMock := pmocks.PlatformService{}
-	gServiceMock := gmock.Interface{}
+func Test_renderReplicationConfig(t *testing.T) {
+	remotes := []gerritApi.GerritReplicationConfig{
+		{ObjectMeta: metaV1.ObjectMeta{Name: "github"}, Spec: gerritApi.GerritReplicationConfigSpec{SSHUrl: "git@github.com:org/${name}.git"}},
+		{ObjectMeta: metaV1.ObjectMeta{Name: "gitlab"}, Spec: gerritApi.GerritReplicationConfigSpec{SSHUrl: "git@gitlab.com:org/${name}.git"}},
+	}
 
-	instance := createGerritReplicationConfig("")
-	gerritInstance := createGerritByStatus(gerritController.StatusReady)
+	replicationConfig, err := renderReplicationConfig(remotes, "../../build/configs/templates")
+	require.NoError(t, err)
+	assert.Equal(t, `[gerrit]
+  defaultForceUpdate = true
+  autoReload = true
+[remote "github"]
+  url = git@github.com:org/${name}.git
+  fetch = +refs/*:refs/*
+  push = +refs/heads/*:refs/heads/*
+  projects = github
+  replicatePermissions = false
+[remote "gitlab"]
+  url = git@gitlab.com:org/${name}.git
+  fetch = +refs/*:refs/*
+  push = +refs/heads/*:refs/heads/*
+  projects = gitlab
+  replicatePermissions = false`, replicationConfig)
+
+	sshConfig, err := renderSshConfig(append(remotes, remotes[0]), "../../build/configs/templates", "/var/gerrit/.ssh/key")
+	require.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(sshConfig, "Host "))
+	assert.Contains(t, sshConfig, "Host github.com\n  HostName github.com\n")
+	assert.Contains(t, sshConfig, "IdentityFile /var/gerrit/.ssh/key")
+}
 
-	pl := &coreV1Api.PodList{
-		Items: []coreV1Api.Pod{
-			{},
-		},
+func Test_renderSshConfig_InvalidUrl(t *testing.T) {
+	remotes := []gerritApi.GerritReplicationConfig{
+		{ObjectMeta: metaV1.ObjectMeta{Name: "github"}, Spec: gerritApi.GerritReplicationConfigSpec{SSHUrl: "github.com"}},
 	}
 
-	pk, err := rsa.GenerateKey(rand.Reader, 2048)
-	require.NoError(t, err)
+	_, err := renderSshConfig(remotes, "../../build/configs/templates", "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to find host")
+}
 
-	privkeyBytes := x509.MarshalPKCS1PrivateKey(pk)
-	privkeyPem := pem.EncodeToMemory(
-		&pem.Block{
-			Type:  "RSA PRIVATE KEY",
-			Bytes: privkeyBytes,
-		},
-	)
+func Test_getReplicationConfigs(t *testing.T) {
+	gerritInstance := createGerritByStatus(gerritController.StatusReady)
+	gerritInstance.Spec.AllowedNamespaces = []string{"tenant"}
 
-	keys := map[string][]byte{
-		"id_rsa": privkeyPem,
-	}
+	instance := createGerritReplicationConfig("")
+	instance.Spec.SSHUrl = "git@changed:org"
 
-	platformMock.On("GetPods", namespace, &metaV1.ListOptions{LabelSelector: fmt.Sprintf("deploymentconfig=%v", name)}).Return(pl, nil)
-	gServiceMock.On("GetGerritSSHUrl", gerritInstance).Return("testurl", nil)
-	gServiceMock.On("GetServicePort", gerritInstance).Return(int32(80), nil)
-	platformMock.On("GetSecret", gerritInstance.Namespace, gerritInstance.Name+"-admin").Return(keys, nil)
-	platformMock.On("GetSecret", gerritInstance.Namespace, spec.GerritDefaultVCSKeyName).Return(nil, nil)
+	stale := createGerritReplicationConfig("")
+	stale.Spec.SSHUrl = "git@stale:org"
 
-	path := fmt.Sprintf("%v/%v", spec.GerritDefaultVCSKeyPath, spec.GerritDefaultVCSKeyName)
-	tr := []string{
-		"/bin/sh", "-c",
-		fmt.Sprintf("echo \"%v\" > %v && chmod 600 %v", "", path, path),
+	owned := &gerritApi.GerritReplicationConfig{
+		ObjectMeta: metaV1.ObjectMeta{Name: "a-owned", Namespace: namespace, OwnerReferences: []metaV1.OwnerReference{
+			{Kind: "Gerrit", Name: name},
+		}},
+	}
+	other := &gerritApi.GerritReplicationConfig{
+		ObjectMeta: metaV1.ObjectMeta{Name: "other", Namespace: namespace},
+		Spec:       gerritApi.GerritReplicationConfigSpec{OwnerName: "another"},
+	}
+	tenant := &gerritApi.GerritReplicationConfig{
+		ObjectMeta: metaV1.ObjectMeta{Name: "remote", Namespace: "tenant"},
+		Spec:       gerritApi.GerritReplicationConfigSpec{OwnerName: namespace + "/" + name},
+	}
+	notAllowed := &gerritApi.GerritReplicationConfig{
+		ObjectMeta: metaV1.ObjectMeta{Name: "remote", Namespace: "not-allowed"},
+		Spec:       gerritApi.GerritReplicationConfigSpec{OwnerName: namespace + "/" + name},
 	}
 
-	platformMock.On("ExecInPod", namespace, "", tr).Return(nil, nil, nil)
+	s := runtime.NewScheme()
+	require.NoError(t, gerritApi.AddToScheme(s))
 
-	tr2 := []string{
-		"/bin/sh", "-c",
-		fmt.Sprintf("[[ -f %v ]] || printf '%%s\n  %%s\n' '[gerrit]' 'defaultForceUpdate = true' > %v && chown -R gerrit2:gerrit2 %v",
-			spec.DefaultGerritReplicationConfigPath, spec.DefaultGerritReplicationConfigPath, spec.DefaultGerritReplicationConfigPath),
+	rg := ReconcileGerritReplicationConfig{
+		client: fake.NewClientBuilder().WithScheme(s).WithObjects(stale, owned, other, tenant, notAllowed).Build(),
+		log:    logr.Discard(),
 	}
-	platformMock.On("ExecInPod", namespace, "", tr2).Return(nil, nil, nil)
 
-	rg := ReconcileGerritReplicationConfig{
-		platform:         &platformMock,
-		componentService: &gServiceMock,
-		log:              logr.Discard(),
+	remotes, err := rg.getReplicationConfigs(context.Background(), instance, gerritInstance)
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(remotes))
+	for i := range remotes {
+		names = append(names, remotes[i].Namespace+"/"+remotes[i].Name)
 	}
 
-	err = rg.configureReplication(instance, gerritInstance)
-	assert.Error(t, err)
+	assert.Equal(t, []string{"namespace/a-owned", "namespace/name", "tenant/remote"}, names)
+	assert.Equal(t, "git@changed:org", remotes[1].Spec.SSHUrl)
 }
 
 func Test_reloadReplicationPluginErr(t *testing.T) {
@@ -770,6 +855,21 @@ func Test_reloadReplicationPlugin(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func createReadyPodList(names ...string) *coreV1Api.PodList {
+	pl := &coreV1Api.PodList{}
+
+	for _, n := range names {
+		pl.Items = append(pl.Items, coreV1Api.Pod{
+			ObjectMeta: metaV1.ObjectMeta{Name: n},
+			Status: coreV1Api.PodStatus{Conditions: []coreV1Api.PodCondition{
+				{Type: coreV1Api.PodReady, Status: coreV1Api.ConditionTrue},
+			}},
+		})
+	}
+
+	return pl
+}
+
 func TestReconcileGerritReplicationConfig_Reconcile(t *testing.T) {
 	err := os.Setenv("PLATFORM_TYPE", platform.Test)
 	require.NoError(t, err)
@@ -784,78 +884,6 @@ func TestReconcileGerritReplicationConfig_Reconcile(t *testing.T) {
 	assert.NoError(t, err)
 }
 
-func Test_createSshConfigErr(t *testing.T) {
-	platformMock := pmocks.PlatformService{}
-
-	errTest := errors.New("test")
-
-	rg := ReconcileGerritReplicationConfig{
-		platform: &platformMock,
-		log:      logr.Discard(),
-	}
-
-	tr := []string{
-		"/bin/sh", "-c",
-		fmt.Sprintf("[[ -f %v ]] || mkdir -p %v && touch %v && chown -R gerrit2:gerrit2 %v",
-			spec.DefaultGerritSSHConfigPath+"/config", spec.DefaultGerritSSHConfigPath,
-			spec.DefaultGerritSSHConfigPath+"/config", spec.DefaultGerritSSHConfigPath+"/config"),
-	}
-
-	platformMock.On("ExecInPod", "", "", tr).Return(nil, nil, errTest)
-
-	err := rg.createSshConfig("", "")
-
-	assert.ErrorIs(t, err, errTest)
-}
-
-func Test_createSshConfig(t *testing.T) {
-	platformMock := pmocks.PlatformService{}
-
-	rg := ReconcileGerritReplicationConfig{
-		platform: &platformMock,
-		log:      logr.Discard(),
-	}
-
-	tr := []string{
-		"/bin/sh", "-c",
-		fmt.Sprintf("[[ -f %v ]] || mkdir -p %v && touch %v && chown -R gerrit2:gerrit2 %v",
-			spec.DefaultGerritSSHConfigPath+"/config", spec.DefaultGerritSSHConfigPath,
-			spec.DefaultGerritSSHConfigPath+"/config", spec.DefaultGerritSSHConfigPath+"/config"),
-	}
-
-	platformMock.On("ExecInPod", "", "", tr).Return(nil, nil, nil)
-
-	err := rg.createSshConfig("", "")
-
-	assert.NoError(t, err)
-}
-
-func Test_updateSshConfig(t *testing.T) {
-	platformMock := pmocks.PlatformService{}
-
-	rg := ReconcileGerritReplicationConfig{
-		platform: &platformMock,
-		log:      logr.Discard(),
-	}
-
-	tr := []string{
-		"/bin/sh", "-c",
-		fmt.Sprintf("[[ -f %v ]] || mkdir -p %v && touch %v && chown -R gerrit2:gerrit2 %v",
-			spec.DefaultGerritSSHConfigPath+"/config", spec.DefaultGerritSSHConfigPath,
-			spec.DefaultGerritSSHConfigPath+"/config", spec.DefaultGerritSSHConfigPath+"/config"),
-	}
-
-	platformMock.On("ExecInPod", name, "", tr).Return(nil, nil, nil)
-
-	config := &gerritApi.GerritReplicationConfig{
-		Spec: gerritApi.GerritReplicationConfigSpec{SSHUrl: "@:"},
-	}
-
-	err := rg.updateSshConfig(name, "", "", "", config)
-
-	assert.Error(t, err)
-}
-
 func Test_updateAvailableStatusErr(t *testing.T) {
 	sw := &mocks.StatusWriter{}
 	mc := mocks.Client{}
@@ -875,3 +903,18 @@ func Test_updateAvailableStatusErr(t *testing.T) {
 	err := rg.updateAvailableStatus(ctx, &gerritApi.GerritReplicationConfig{}, true)
 	assert.ErrorIs(t, err, errTest)
 }
+
+func Test_needsConfiguration(t *testing.T) {
+	gerritInstance := createGerritByStatus(gerritController.StatusReady)
+
+	instance := createGerritReplicationConfig(spec.StatusConfigured)
+	instance.Generation = 2
+	instance.Status.ObservedGeneration = 2
+
+	assert.False(t, needsConfiguration(instance, gerritInstance))
+
+	instance.Generation = 3
+	assert.True(t, needsConfiguration(instance, gerritInstance), "the changed spec is configured again")
+
+	assert.False(t, needsConfiguration(instance, createGerritByStatus("")))
+}
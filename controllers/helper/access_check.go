@@ -0,0 +1,50 @@
+package helper
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	gerritApi "github.com/epam/edp-gerrit-operator/v2/api/v1"
+	gerritClient "github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit"
+)
+
+// CheckAccess checks the effective permission of the account in the project and returns Allowed or Denied.
+func CheckAccess(cl gerritClient.ClientInterface, projectName string, check *gerritApi.AccessCheck) (string, *gerritClient.AccessCheckInfo, error) {
+	if check.Account == "" {
+		return "", nil, errors.New("account of the access check is empty")
+	}
+
+	if check.Permission != "" && check.Ref == "" {
+		return "", nil, errors.Errorf("permission %s of the access check requires the ref", check.Permission)
+	}
+
+	info, err := cl.CheckAccess(projectName, gerritClient.AccessCheck{
+		Account:    check.Account,
+		Ref:        check.Ref,
+		Permission: check.Permission,
+	})
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "unable to check access of %s in project %s", check.Account, projectName)
+	}
+
+	if info.Allowed() {
+		return gerritApi.AccessAllowed, info, nil
+	}
+
+	return gerritApi.AccessDenied, info, nil
+}
+
+// DescribeAccessCheck returns the human-readable access check, e.g. "developer push refs/heads/main".
+func DescribeAccessCheck(check *gerritApi.AccessCheck) string {
+	permission := check.Permission
+	if permission == "" {
+		permission = "read"
+	}
+
+	if check.Ref == "" {
+		return fmt.Sprintf("%s %s", check.Account, permission)
+	}
+
+	return fmt.Sprintf("%s %s %s", check.Account, permission, check.Ref)
+}
@@ -0,0 +1,34 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gerritApi "github.com/epam/edp-gerrit-operator/v2/api/v1"
+	gerritClient "github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit"
+	gerritClientMocks "github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit/mocks"
+)
+
+func TestCheckAccess(t *testing.T) {
+	cl := gerritClientMocks.NewClientInterface(t)
+	cl.On("CheckAccess", "backend", gerritClient.AccessCheck{Account: "developer"}).
+		Return(&gerritClient.AccessCheckInfo{Status: 200}, nil)
+
+	res, _, err := CheckAccess(cl, "backend", &gerritApi.AccessCheck{Account: "developer"})
+	require.NoError(t, err)
+	assert.Equal(t, gerritApi.AccessAllowed, res)
+
+	_, _, err = CheckAccess(cl, "backend", &gerritApi.AccessCheck{Account: "developer", Permission: "push"})
+	assert.EqualError(t, err, "permission push of the access check requires the ref")
+
+	_, _, err = CheckAccess(cl, "backend", &gerritApi.AccessCheck{})
+	assert.EqualError(t, err, "account of the access check is empty")
+}
+
+func TestDescribeAccessCheck(t *testing.T) {
+	assert.Equal(t, "developer read", DescribeAccessCheck(&gerritApi.AccessCheck{Account: "developer"}))
+	assert.Equal(t, "developer push refs/heads/main",
+		DescribeAccessCheck(&gerritApi.AccessCheck{Account: "developer", Ref: "refs/heads/main", Permission: "push"}))
+}
@@ -0,0 +1,145 @@
+package helper
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ConditionDrifted reports whether the object in Gerrit differs from the spec.
+	ConditionDrifted = "Drifted"
+
+	// ReasonInSync and ReasonDriftDetected are the reasons of the Drifted condition,
+	// the corrected drift has the EventReasonDriftCorrected reason.
+	ReasonInSync        = "InSync"
+	ReasonDriftDetected = "DriftDetected"
+
+	// DriftCorrectionAnnotation enables ("true") or disables ("false") the correction of the drift for the object,
+	// it overrides the GERRIT_DRIFT_CORRECTION setting of the operator.
+	DriftCorrectionAnnotation = "edp.epam.com/drift-correction"
+
+	driftCheckIntervalEnv     = "GERRIT_DRIFT_CHECK_INTERVAL"
+	driftCorrectionEnv        = "GERRIT_DRIFT_CORRECTION"
+	defaultDriftCheckInterval = 10 * time.Minute
+
+	// maxDriftMessageLength keeps the condition message within the API limit.
+	maxDriftMessageLength = 32768
+)
+
+// Drift is the list of the field-level differences between the spec and the object in Gerrit.
+type Drift []string
+
+// Compare adds the difference of the field if the live value is not the desired one.
+func (d *Drift) Compare(field string, desired, live interface{}) {
+	if !reflect.DeepEqual(desired, live) {
+		*d = append(*d, fmt.Sprintf("%s: %v, expected %v", field, live, desired))
+	}
+}
+
+// Addf adds the difference described by the format.
+func (d *Drift) Addf(format string, args ...interface{}) {
+	*d = append(*d, fmt.Sprintf(format, args...))
+}
+
+func (d Drift) String() string {
+	msg := strings.Join(d, "; ")
+	if len(msg) > maxDriftMessageLength {
+		msg = msg[:maxDriftMessageLength-3] + "..."
+	}
+
+	return msg
+}
+
+// DriftCheckInterval returns the interval of the drift checks of the reconciled objects, 0 disables the periodic checks.
+func DriftCheckInterval() time.Duration {
+	value, ok := os.LookupEnv(driftCheckIntervalEnv)
+	if !ok {
+		return defaultDriftCheckInterval
+	}
+
+	interval, err := time.ParseDuration(value)
+	if err != nil || interval < 0 {
+		return defaultDriftCheckInterval
+	}
+
+	return interval
+}
+
+// IsDriftCorrectionEnabled reports whether the drift of the object is corrected automatically.
+func IsDriftCorrectionEnabled(obj metaV1.Object) bool {
+	if value, ok := obj.GetAnnotations()[DriftCorrectionAnnotation]; ok {
+		enabled, err := strconv.ParseBool(value)
+
+		return err == nil && enabled
+	}
+
+	enabled, err := strconv.ParseBool(os.Getenv(driftCorrectionEnv))
+
+	return err == nil && enabled
+}
+
+// NeedsApply reports whether the spec is written to Gerrit.
+// It is written when the current generation has not been applied yet, the object is deleted
+// or the object has drifted and the drift correction is enabled.
+// Otherwise the drift is only reported, so the changes made in Gerrit are not overwritten silently.
+func NeedsApply(obj metaV1.Object, conditions []metaV1.Condition, drift Drift) bool {
+	if !isGenerationApplied(obj, conditions) || !obj.GetDeletionTimestamp().IsZero() {
+		return true
+	}
+
+	return len(drift) > 0 && IsDriftCorrectionEnabled(obj)
+}
+
+// SetDriftCondition sets the Drifted condition of the object after the drift check,
+// applied reports whether the spec has been written to Gerrit.
+// The corrected drift and the newly detected drift are recorded as events.
+func SetDriftCondition(recorder record.EventRecorder, obj client.Object, conditions *[]metaV1.Condition, drift Drift, applied bool) {
+	condition := metaV1.Condition{
+		Type:               ConditionDrifted,
+		Status:             metaV1.ConditionFalse,
+		Reason:             ReasonInSync,
+		Message:            "Gerrit matches the spec",
+		ObservedGeneration: obj.GetGeneration(),
+	}
+
+	corrected := false
+
+	switch {
+	case len(drift) == 0:
+	case applied && isGenerationApplied(obj, *conditions):
+		corrected = true
+		condition.Reason = EventReasonDriftCorrected
+		condition.Message = "Drift has been corrected: " + drift.String()
+	case applied:
+		// the changed spec has been applied, the differences are not a drift
+	default:
+		condition.Status = metaV1.ConditionTrue
+		condition.Reason = ReasonDriftDetected
+		condition.Message = drift.String()
+	}
+
+	changed := meta.SetStatusCondition(conditions, condition)
+
+	if corrected {
+		RecordEvent(recorder, obj, EventReasonDriftCorrected, "%s", condition.Message)
+	} else if changed && condition.Status == metaV1.ConditionTrue {
+		RecordWarning(recorder, obj, ReasonDriftDetected, errors.New(condition.Message))
+	}
+}
+
+// isGenerationApplied reports whether the current generation of the object has been written to Gerrit.
+func isGenerationApplied(obj metaV1.Object, conditions []metaV1.Condition) bool {
+	condition := meta.FindStatusCondition(conditions, ConditionDrifted)
+
+	return condition != nil && condition.ObservedGeneration == obj.GetGeneration()
+}
@@ -0,0 +1,99 @@
+package helper
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	gerritApi "github.com/epam/edp-gerrit-operator/v2/api/v1"
+)
+
+func TestDrift(t *testing.T) {
+	t.Parallel()
+
+	var drift Drift
+
+	drift.Compare("description", "new", "new")
+	drift.Compare("description", "new", "old")
+	drift.Addf("%s: missing", "refs/heads/* read devs")
+
+	assert.Equal(t, "description: old, expected new; refs/heads/* read devs: missing", drift.String())
+
+	long := Drift{strings.Repeat("x", maxDriftMessageLength+1)}
+	assert.Len(t, long.String(), maxDriftMessageLength)
+}
+
+func TestDriftCheckInterval(t *testing.T) {
+	t.Setenv(driftCheckIntervalEnv, "1m")
+	assert.Equal(t, "1m0s", DriftCheckInterval().String())
+
+	t.Setenv(driftCheckIntervalEnv, "0")
+	assert.Zero(t, DriftCheckInterval())
+
+	t.Setenv(driftCheckIntervalEnv, "wrong")
+	assert.Equal(t, defaultDriftCheckInterval, DriftCheckInterval())
+}
+
+func TestIsDriftCorrectionEnabled(t *testing.T) {
+	withAnnotation := func(value string) *gerritApi.GerritProject {
+		return &gerritApi.GerritProject{
+			ObjectMeta: metaV1.ObjectMeta{Annotations: map[string]string{DriftCorrectionAnnotation: value}},
+		}
+	}
+
+	t.Setenv(driftCorrectionEnv, "")
+	assert.False(t, IsDriftCorrectionEnabled(&gerritApi.GerritProject{}))
+	assert.True(t, IsDriftCorrectionEnabled(withAnnotation("true")))
+
+	t.Setenv(driftCorrectionEnv, "true")
+	assert.True(t, IsDriftCorrectionEnabled(&gerritApi.GerritProject{}))
+	assert.False(t, IsDriftCorrectionEnabled(withAnnotation("false")))
+}
+
+func TestSetDriftCondition(t *testing.T) {
+	t.Setenv(driftCorrectionEnv, "")
+
+	recorder := record.NewFakeRecorder(10)
+	prj := &gerritApi.GerritProject{ObjectMeta: metaV1.ObjectMeta{Generation: 1}}
+	drift := Drift{"description: old, expected new"}
+
+	// the new generation is applied, the differences are not a drift
+	require.True(t, NeedsApply(prj, prj.Status.Conditions, drift))
+	SetDriftCondition(recorder, prj, &prj.Status.Conditions, drift, true)
+	assert.True(t, meta.IsStatusConditionFalse(prj.Status.Conditions, ConditionDrifted))
+	assert.Empty(t, recorder.Events)
+
+	// the drift of the applied generation is only reported
+	require.False(t, NeedsApply(prj, prj.Status.Conditions, drift))
+	SetDriftCondition(recorder, prj, &prj.Status.Conditions, drift, false)
+
+	condition := meta.FindStatusCondition(prj.Status.Conditions, ConditionDrifted)
+	require.NotNil(t, condition)
+	assert.Equal(t, metaV1.ConditionTrue, condition.Status)
+	assert.Equal(t, ReasonDriftDetected, condition.Reason)
+	assert.Equal(t, "description: old, expected new", condition.Message)
+	assert.Equal(t, "Warning DriftDetected description: old, expected new", <-recorder.Events)
+
+	// the same drift is not recorded twice
+	SetDriftCondition(recorder, prj, &prj.Status.Conditions, drift, false)
+	assert.Empty(t, recorder.Events)
+
+	prj.Annotations = map[string]string{DriftCorrectionAnnotation: "true"}
+	require.True(t, NeedsApply(prj, prj.Status.Conditions, drift))
+	SetDriftCondition(recorder, prj, &prj.Status.Conditions, drift, true)
+
+	condition = meta.FindStatusCondition(prj.Status.Conditions, ConditionDrifted)
+	require.NotNil(t, condition)
+	assert.Equal(t, metaV1.ConditionFalse, condition.Status)
+	assert.Equal(t, EventReasonDriftCorrected, condition.Reason)
+	assert.Equal(t, "Normal DriftCorrected Drift has been corrected: description: old, expected new", <-recorder.Events)
+
+	require.False(t, NeedsApply(prj, prj.Status.Conditions, nil))
+	SetDriftCondition(recorder, prj, &prj.Status.Conditions, nil, false)
+	assert.Equal(t, ReasonInSync, meta.FindStatusCondition(prj.Status.Conditions, ConditionDrifted).Reason)
+}
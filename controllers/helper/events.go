@@ -0,0 +1,44 @@
+package helper
+
+import (
+	coreV1Api "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+// Reasons of the events recorded by the controllers.
+const (
+	EventReasonCreated        = "Created"
+	EventReasonUpdated        = "Updated"
+	EventReasonDeleted        = "Deleted"
+	EventReasonDriftCorrected = "DriftCorrected"
+	EventReasonFailed         = "Failed"
+)
+
+// +kubebuilder:rbac:groups="",namespace=placeholder,resources=events,verbs=create;patch
+
+// RecordEvent records a Normal event on the object.
+// The recorder is optional, the controllers created without a manager have no recorder.
+// The events are not recorded in the dry-run mode, the changes are only planned.
+func RecordEvent(recorder record.EventRecorder, obj runtime.Object, reason, messageFmt string, args ...interface{}) {
+	if recorder == nil {
+		return
+	}
+
+	if metaObj, ok := obj.(metaV1.Object); ok && IsDryRun(metaObj) {
+		return
+	}
+
+	recorder.Eventf(obj, coreV1Api.EventTypeNormal, reason, messageFmt, args...)
+}
+
+// RecordWarning records a Warning event with the error on the object.
+// The recorder is optional, the controllers created without a manager have no recorder.
+func RecordWarning(recorder record.EventRecorder, obj runtime.Object, reason string, err error) {
+	if recorder == nil {
+		return
+	}
+
+	recorder.Event(obj, coreV1Api.EventTypeWarning, reason, err.Error())
+}
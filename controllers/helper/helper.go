@@ -37,6 +37,25 @@ func GetWatchNamespace() (string, error) {
 	return ns, nil
 }
 
+// GetWatchNamespaces returns the namespaces the operator should be watching for changes.
+// WATCH_NAMESPACE is a comma-separated list, the empty list means all namespaces.
+func GetWatchNamespaces() ([]string, error) {
+	value, err := GetWatchNamespace()
+	if err != nil {
+		return nil, err
+	}
+
+	var namespaces []string
+
+	for _, ns := range strings.Split(value, ",") {
+		if ns = strings.TrimSpace(ns); ns != "" {
+			namespaces = append(namespaces, ns)
+		}
+	}
+
+	return namespaces, nil
+}
+
 // GetDebugMode returns the debug mode value.
 func GetDebugMode() (bool, error) {
 	mode, found := os.LookupEnv(debugModeEnvVar)
@@ -155,6 +174,13 @@ func GetGerritOwner(references []metaV1.OwnerReference) *metaV1.OwnerReference {
 
 func GetGerritInstance(ctx context.Context, k8sClient client.Client, ownerName *string,
 	namespace string,
+) (*gerritApi.Gerrit, error) {
+	return getGerritInstance(ctx, k8sClient, ownerName, namespace, false)
+}
+
+// getGerritInstance returns the Gerrit by the owner name, the deleted Gerrit is returned only if allowDeleted is set.
+func getGerritInstance(ctx context.Context, k8sClient client.Client, ownerName *string,
+	namespace string, allowDeleted bool,
 ) (*gerritApi.Gerrit, error) {
 	if ownerName == nil {
 		var list gerritApi.GerritList
@@ -171,14 +197,27 @@ func GetGerritInstance(ctx context.Context, k8sClient client.Client, ownerName *
 		return &list.Items[0], nil
 	}
 
+	key := client.ObjectKey{Namespace: namespace, Name: *ownerName}
+
+	// the Gerrit in another namespace is referenced as namespace/name
+	if ns, name, found := strings.Cut(*ownerName, "/"); found {
+		key = client.ObjectKey{Namespace: ns, Name: name}
+	}
+
 	var gerritInstance gerritApi.Gerrit
-	if err := k8sClient.Get(ctx, client.ObjectKey{
-		Namespace: namespace,
-		Name:      *ownerName,
-	}, &gerritInstance); err != nil {
+	if err := k8sClient.Get(ctx, key, &gerritInstance); err != nil {
 		return nil, errors.Wrap(err, "unable to get gerrit instance")
 	}
 
+	if !gerritInstance.IsNamespaceAllowed(namespace) {
+		return nil, errors.Errorf("namespace %s is not allowed to use gerrit %s/%s", namespace, key.Namespace, key.Name)
+	}
+
+	// the deleted Gerrit orphans its dependent resources, they must not be adopted again
+	if !allowDeleted && !gerritInstance.GetDeletionTimestamp().IsZero() {
+		return nil, errors.Errorf("gerrit %s is being deleted", gerritInstance.Name)
+	}
+
 	return &gerritInstance, nil
 }
 
@@ -204,9 +243,21 @@ func RemoveString(slice []string, s string) (result []string) {
 	return
 }
 
-func GetGerritClient(ctx context.Context, cl client.Client, instance client.Object, ownerName string,
-	service gerritService.Interface,
-) (gerritClient.ClientInterface, error) {
+// ResolveGerritOwner returns the Gerrit the instance belongs to. The Gerrit in the instance namespace
+// is set as the owner reference of the instance. The Gerrit in another namespace is resolved
+// by the owner name every time, since the owner references cannot point to another namespace.
+func ResolveGerritOwner(ctx context.Context, cl client.Client, instance client.Object, ownerName string) (*gerritApi.Gerrit, error) {
+	if ns, _, found := strings.Cut(ownerName, "/"); found && ns != instance.GetNamespace() {
+		// the deleted resource is cleaned up in the Gerrit that is deleted too, e.g. by the cascade deletion
+		gerritInstance, err := getGerritInstance(ctx, cl, FindCROwnerName(ownerName), instance.GetNamespace(),
+			!instance.GetDeletionTimestamp().IsZero())
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to get gerrit instance")
+		}
+
+		return gerritInstance, nil
+	}
+
 	if !IsInstanceOwnerSet(instance) {
 		ownerReference := FindCROwnerName(ownerName)
 
@@ -227,11 +278,48 @@ func GetGerritClient(ctx context.Context, cl client.Client, instance client.Obje
 		return nil, errors.Wrap(err, "unable to get instance owner")
 	}
 
+	return gerritInstance, nil
+}
+
+// ResolveHealthyGerritOwner returns the owner Gerrit of the instance,
+// an error is returned if the health monitor has found the owner unhealthy, so the caller backs off.
+// The deleted instance is not gated, so its finalizer does not block the cascade deletion of the unhealthy Gerrit.
+func ResolveHealthyGerritOwner(ctx context.Context, cl client.Client, instance client.Object, ownerName string,
+) (*gerritApi.Gerrit, error) {
+	gerritInstance, err := ResolveGerritOwner(ctx, cl, instance, ownerName)
+	if err != nil {
+		return nil, err
+	}
+
+	if gerritInstance.IsUnhealthy() && instance.GetDeletionTimestamp().IsZero() {
+		return nil, errors.Errorf("gerrit %s is unhealthy", gerritInstance.Name)
+	}
+
+	return gerritInstance, nil
+}
+
+func GetGerritClient(ctx context.Context, cl client.Client, instance client.Object, ownerName string,
+	service gerritService.Interface,
+) (gerritClient.ClientInterface, error) {
+	gerritInstance, err := ResolveHealthyGerritOwner(ctx, cl, instance, ownerName)
+	if err != nil {
+		return nil, err
+	}
+
 	gerritCl, err := service.GetRestClient(gerritInstance)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to get rest client")
 	}
 
+	// bind the requests to the reconciliation trace
+	if ctxCl, ok := gerritCl.(gerritClient.ContextClient); ok {
+		gerritCl = ctxCl.WithContext(ctx)
+	}
+
+	if IsDryRun(instance) {
+		return gerritClient.NewDryRunClient(gerritCl), nil
+	}
+
 	return gerritCl, nil
 }
 
@@ -256,6 +344,11 @@ func TryToDelete(ctx context.Context, k8sClient client.Client, instance client.O
 		return errors.Wrap(err, "unable to perform delete function")
 	}
 
+	// the object is kept until the resources in Gerrit are really deleted
+	if IsDryRun(instance) {
+		return nil
+	}
+
 	finalizers := instance.GetFinalizers()
 	finalizers = RemoveString(finalizers, finalizerName)
 	instance.SetFinalizers(finalizers)
@@ -9,6 +9,7 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	coreV1 "k8s.io/api/core/v1"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -113,6 +114,89 @@ func TestGetGerritClient(t *testing.T) {
 	gCl.AssertExpectations(t)
 }
 
+func TestGetGerritClient_Failure_Unhealthy(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilRuntime.Must(gerritApi.AddToScheme(scheme))
+	utilRuntime.Must(coreV1.AddToScheme(scheme))
+
+	instance := gerritApi.GerritGroupMember{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      "t1",
+			Namespace: "t2",
+		},
+	}
+
+	g := gerritApi.Gerrit{
+		ObjectMeta: metaV1.ObjectMeta{
+			Namespace: instance.Namespace,
+			Name:      "ger1",
+		},
+		Status: gerritApi.GerritStatus{
+			Conditions: []metaV1.Condition{
+				{Type: gerritApi.ConditionHealthy, Status: metaV1.ConditionFalse, Reason: "ProbeFailed"},
+			},
+		},
+	}
+
+	client := fake.NewClientBuilder().WithStatusSubresource(&gerritApi.Gerrit{}).WithScheme(scheme).WithRuntimeObjects(&instance, &g).Build()
+	gerritService := gmock.Interface{}
+
+	_, err := GetGerritClient(context.Background(), client, &instance, "", &gerritService)
+	if err == nil {
+		t.Fatal("error is not returned")
+	}
+
+	if !strings.Contains(err.Error(), "gerrit ger1 is unhealthy") {
+		t.Log(err)
+		t.Fatal("wrong error returned")
+	}
+
+	gerritService.AssertExpectations(t)
+}
+
+func TestGetGerritClient_UnhealthyDeletedInstance(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilRuntime.Must(gerritApi.AddToScheme(scheme))
+	utilRuntime.Must(coreV1.AddToScheme(scheme))
+
+	// the resource in another namespace is deleted together with the Gerrit it references
+	instance := gerritApi.GerritGroupMember{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:              "t1",
+			Namespace:         "team-a",
+			DeletionTimestamp: &metaV1.Time{Time: time.Now()},
+			Finalizers:        []string{"gerritgroupmember.gerrit.finalizer.name"},
+		},
+	}
+
+	g := gerritApi.Gerrit{
+		ObjectMeta: metaV1.ObjectMeta{
+			Namespace:         "t2",
+			Name:              "ger1",
+			DeletionTimestamp: &metaV1.Time{Time: time.Now()},
+			Finalizers:        []string{"gerrit.gerrit.finalizer.name"},
+		},
+		Spec: gerritApi.GerritSpec{AllowedNamespaces: []string{"team-a"}},
+		Status: gerritApi.GerritStatus{
+			Conditions: []metaV1.Condition{
+				{Type: gerritApi.ConditionHealthy, Status: metaV1.ConditionFalse, Reason: "ProbeFailed"},
+			},
+		},
+	}
+
+	client := fake.NewClientBuilder().WithStatusSubresource(&gerritApi.Gerrit{}).WithScheme(scheme).WithRuntimeObjects(&instance, &g).Build()
+	gerritService := gmock.Interface{}
+	gCl := gerritClientMocks.ClientInterface{}
+
+	gerritService.On("GetRestClient", mock.AnythingOfType("*v1.Gerrit")).Return(&gCl, nil)
+
+	if _, err := GetGerritClient(context.Background(), client, &instance, "t2/ger1", &gerritService); err != nil {
+		t.Fatal(err)
+	}
+
+	gerritService.AssertExpectations(t)
+}
+
 func TestGetGerritClient_Failure_UnableToGetInstanceOwner(t *testing.T) {
 	scheme := runtime.NewScheme()
 	utilRuntime.Must(gerritApi.AddToScheme(scheme))
@@ -241,6 +325,26 @@ func TestGetGerritInstance(t *testing.T) {
 	}
 }
 
+func TestGetGerritInstance_Deleted(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilRuntime.Must(gerritApi.AddToScheme(scheme))
+
+	g := gerritApi.Gerrit{
+		ObjectMeta: metaV1.ObjectMeta{
+			Namespace:         "ns",
+			Name:              "ger1",
+			DeletionTimestamp: &metaV1.Time{Time: time.Now()},
+			Finalizers:        []string{"gerrit.gerrit.finalizer.name"},
+		},
+	}
+
+	client := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(&g).Build()
+
+	_, err := GetGerritInstance(context.Background(), client, &g.Name, g.Namespace)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "gerrit ger1 is being deleted")
+}
+
 func TestGetWatchNamespace(t *testing.T) {
 	ns := "test"
 	err := os.Setenv(watchNamespaceEnvVar, ns)
@@ -254,6 +358,50 @@ func TestGetWatchNamespace(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestGetWatchNamespaces(t *testing.T) {
+	t.Setenv(watchNamespaceEnvVar, "platform, team-a,,team-b")
+
+	namespaces, err := GetWatchNamespaces()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"platform", "team-a", "team-b"}, namespaces)
+
+	t.Setenv(watchNamespaceEnvVar, "")
+
+	namespaces, err = GetWatchNamespaces()
+	require.NoError(t, err)
+	assert.Empty(t, namespaces)
+}
+
+func TestResolveGerritOwner_OtherNamespace(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilRuntime.Must(gerritApi.AddToScheme(scheme))
+
+	g := gerritApi.Gerrit{
+		ObjectMeta: metaV1.ObjectMeta{Namespace: "platform", Name: "gerrit"},
+		Spec:       gerritApi.GerritSpec{AllowedNamespaces: []string{"team-a"}},
+	}
+
+	allowed := gerritApi.GerritProject{
+		ObjectMeta: metaV1.ObjectMeta{Namespace: "team-a", Name: "prj"},
+		Spec:       gerritApi.GerritProjectSpec{OwnerName: "platform/gerrit"},
+	}
+	denied := gerritApi.GerritProject{
+		ObjectMeta: metaV1.ObjectMeta{Namespace: "team-b", Name: "prj"},
+		Spec:       gerritApi.GerritProjectSpec{OwnerName: "platform/gerrit"},
+	}
+
+	client := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(&g, &allowed, &denied).Build()
+
+	owner, err := ResolveGerritOwner(context.Background(), client, &allowed, allowed.Spec.OwnerName)
+	require.NoError(t, err)
+	assert.Equal(t, "platform", owner.Namespace)
+	assert.Empty(t, allowed.OwnerReferences, "the owner reference cannot point to another namespace")
+
+	_, err = ResolveGerritOwner(context.Background(), client, &denied, denied.Spec.OwnerName)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "namespace team-b is not allowed to use gerrit platform/gerrit")
+}
+
 func TestGetWatchNamespaceErr(t *testing.T) {
 	namespace, err := GetWatchNamespace()
 	assert.Error(t, err)
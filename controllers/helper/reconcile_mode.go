@@ -0,0 +1,41 @@
+package helper
+
+import (
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+const (
+	// ReconcileAnnotation sets the reconciliation mode of the object.
+	ReconcileAnnotation = "edp.epam.com/reconcile"
+
+	// ReconcilePaused stops the reconciliation of the object, the operator does not change Gerrit and the status.
+	ReconcilePaused = "paused"
+
+	// ReconcileDryRun makes the operator compare the object with Gerrit and write the planned actions
+	// into the status without changing Gerrit.
+	ReconcileDryRun = "dry-run"
+
+	// StatusDryRun is the status value of the objects reconciled in the dry-run mode.
+	StatusDryRun = "dry-run"
+)
+
+// IsReconcilePaused reports whether the reconciliation of the object is paused.
+func IsReconcilePaused(obj metaV1.Object) bool {
+	return obj.GetAnnotations()[ReconcileAnnotation] == ReconcilePaused
+}
+
+// IsDryRun reports whether the object is reconciled in the dry-run mode.
+func IsDryRun(obj metaV1.Object) bool {
+	return obj.GetAnnotations()[ReconcileAnnotation] == ReconcileDryRun
+}
+
+// IsReconcileModeUpdated reports whether the reconciliation mode annotation of the object is changed.
+// The controllers that filter the update events by the spec use it to resume the reconciliation.
+func IsReconcileModeUpdated(e event.UpdateEvent) bool {
+	if e.ObjectOld == nil || e.ObjectNew == nil {
+		return false
+	}
+
+	return e.ObjectOld.GetAnnotations()[ReconcileAnnotation] != e.ObjectNew.GetAnnotations()[ReconcileAnnotation]
+}
@@ -0,0 +1,30 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	gerritApi "github.com/epam/edp-gerrit-operator/v2/api/v1"
+)
+
+func TestReconcileMode(t *testing.T) {
+	t.Parallel()
+
+	withMode := func(mode string) *gerritApi.GerritProject {
+		return &gerritApi.GerritProject{
+			ObjectMeta: metaV1.ObjectMeta{Annotations: map[string]string{ReconcileAnnotation: mode}},
+		}
+	}
+
+	assert.True(t, IsReconcilePaused(withMode(ReconcilePaused)))
+	assert.False(t, IsDryRun(withMode(ReconcilePaused)))
+	assert.True(t, IsDryRun(withMode(ReconcileDryRun)))
+	assert.False(t, IsReconcilePaused(&gerritApi.GerritProject{}))
+	assert.False(t, IsDryRun(&gerritApi.GerritProject{}))
+
+	assert.True(t, IsReconcileModeUpdated(event.UpdateEvent{ObjectOld: withMode(ReconcilePaused), ObjectNew: &gerritApi.GerritProject{}}))
+	assert.False(t, IsReconcileModeUpdated(event.UpdateEvent{ObjectOld: withMode(ReconcileDryRun), ObjectNew: withMode(ReconcileDryRun)}))
+}
@@ -7,18 +7,23 @@ import (
 	"os"
 	"reflect"
 	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
 	corev1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	ctrlMetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -26,8 +31,10 @@ import (
 	"github.com/epam/edp-gerrit-operator/v2/controllers/helper"
 	gerritClient "github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit"
 	"github.com/epam/edp-gerrit-operator/v2/pkg/client/git"
+	"github.com/epam/edp-gerrit-operator/v2/pkg/metrics"
 	"github.com/epam/edp-gerrit-operator/v2/pkg/service/gerrit"
 	"github.com/epam/edp-gerrit-operator/v2/pkg/service/platform"
+	"github.com/epam/edp-gerrit-operator/v2/pkg/tracing"
 )
 
 const (
@@ -46,6 +53,7 @@ type Reconcile struct {
 	getGitClient    func(ctx context.Context, child gerrit.Child, workDir string) (GitClient, error)
 	getGerritClient func(ctx context.Context, child *gerritApi.GerritMergeRequest) (GerritClient, error)
 	gitWorkDir      string
+	recorder        record.EventRecorder
 }
 
 type GitClient interface {
@@ -131,13 +139,21 @@ func (r *Reconcile) SetupWithManager(mgr ctrl.Manager) error {
 		UpdateFunc: isSpecUpdated,
 	}
 
+	r.recorder = mgr.GetEventRecorderFor("gerrit-merge-request")
+
 	err := ctrl.NewControllerManagedBy(mgr).
 		For(&gerritApi.GerritMergeRequest{}, builder.WithPredicates(pred)).
-		Complete(r)
+		Complete(tracing.NewReconciler("GerritMergeRequest", r))
 	if err != nil {
 		return fmt.Errorf("failed to setup GerritMergeRequest controller: %w", err)
 	}
 
+	collector := metrics.NewMergeRequestCollector(mgr.GetClient(), r.log, StatusNew, StatusAbandoned, StatusMerged,
+		helper.StatusDryRun)
+	if err := ctrlMetrics.Registry.Register(collector); err != nil && !errors.As(err, &prometheus.AlreadyRegisteredError{}) {
+		return fmt.Errorf("failed to register GerritMergeRequest metrics: %w", err)
+	}
+
 	return nil
 }
 
@@ -152,7 +168,7 @@ func isSpecUpdated(e event.UpdateEvent) bool {
 		return false
 	}
 
-	return !reflect.DeepEqual(oo.Spec, no.Spec) ||
+	return !reflect.DeepEqual(oo.Spec, no.Spec) || helper.IsReconcileModeUpdated(e) ||
 		(oo.GetDeletionTimestamp().IsZero() && !no.GetDeletionTimestamp().IsZero())
 }
 
@@ -174,16 +190,33 @@ func (r *Reconcile) Reconcile(ctx context.Context, request reconcile.Request) (r
 		return reconcile.Result{}, errors.Wrap(err, "unable to get GerritMergeRequest instance")
 	}
 
-	if requeue, err := r.tryReconcile(ctx, &instance); err != nil {
+	tracing.SetAttributes(ctx, tracing.GerritInstance.String(instance.OwnerName()),
+		tracing.GerritProject.String(instance.Spec.ProjectName))
+
+	if helper.IsReconcilePaused(&instance) {
+		reqLogger.Info("Reconciliation of GerritMergeRequest is paused")
+		return
+	}
+
+	status := instance.Status.DeepCopy()
+
+	requeue, err := r.tryReconcile(ctx, &instance)
+	if err != nil {
 		instance.Status.Value = err.Error()
 		result.RequeueAfter = time.Second * helper.DefaultRequeueTime
 
+		helper.RecordWarning(r.recorder, &instance, helper.EventReasonFailed, err)
+
 		reqLogger.Error(err, "an error has occurred while handling GerritMergeRequest", "name",
 			request.Name)
 	} else if requeue {
 		result.RequeueAfter = time.Second * helper.DefaultRequeueTime
 	}
 
+	if helper.IsDryRun(&instance) {
+		setDryRunStatus(&instance, status, err)
+	}
+
 	if err := r.k8sClient.Status().Update(ctx, &instance); err != nil {
 		resError = err
 	}
@@ -195,26 +228,46 @@ func (r *Reconcile) Reconcile(ctx context.Context, request reconcile.Request) (r
 
 func (r *Reconcile) tryReconcile(ctx context.Context, instance *gerritApi.GerritMergeRequest) (bool, error) {
 	requeue := false
+	instance.Status.PlannedActions = nil
 
 	if instance.Status.ChangeID == "" {
 		if instance.Spec.SourceBranch == "" && instance.Spec.ChangesConfigMap == "" {
 			return false, errors.New("sourceBranch or changesConfigMap must be specified")
 		}
 
-		status, err := r.createChange(ctx, instance)
-		if err != nil {
-			return false, errors.Wrap(err, "unable to create change")
-		}
+		options := pushOptions(instance)
 
-		instance.Status = *status
-		requeue = true
+		if helper.IsDryRun(instance) {
+			instance.Status.PlannedActions = []string{plannedChange(instance, options)}
+		} else {
+			status, err := r.createChange(ctx, instance, options)
+			if err != nil {
+				return false, errors.Wrap(err, "unable to create change")
+			}
+
+			instance.Status = *status
+			requeue = true
+
+			helper.RecordEvent(r.recorder, instance, helper.EventReasonCreated, "Change %s has been created", status.ChangeID)
+		}
 	} else {
-		status, err := r.getChangeStatus(ctx, instance)
+		change, err := r.getChange(ctx, instance)
 		if err != nil {
 			return false, errors.Wrap(err, "unable to get change status")
 		}
 
+		status := change.Status
+		if status != instance.Status.Value && (status == StatusMerged || status == StatusAbandoned) {
+			helper.RecordEvent(r.recorder, instance, helper.EventReasonUpdated, "Change %s is %s", instance.Status.ChangeID, status)
+		}
+
 		instance.Status.Value = status
+		instance.Status.Topic = change.Topic
+		instance.Status.Hashtags = change.Hashtags
+		instance.Status.Reviewers = accountNames(change.Reviewers[gerritClient.ReviewerStateReviewer])
+		instance.Status.CC = accountNames(change.Reviewers[gerritClient.ReviewerStateCC])
+		instance.Status.WorkInProgress = change.WorkInProgress
+		instance.Status.Private = change.IsPrivate
 		requeue = status == StatusNew
 	}
 
@@ -224,7 +277,7 @@ func (r *Reconcile) tryReconcile(ctx context.Context, instance *gerritApi.Gerrit
 	statusSnapshot := instance.Status
 
 	if err := helper.TryToDelete(ctx, r.k8sClient, instance, finalizerName,
-		r.makeDeletionFunc(ctx, instance)); err != nil {
+		r.makeDeletionFunc(ctx, instance, &statusSnapshot.PlannedActions)); err != nil {
 		return false, errors.Wrap(err, "unable to delete resource")
 	}
 
@@ -234,7 +287,7 @@ func (r *Reconcile) tryReconcile(ctx context.Context, instance *gerritApi.Gerrit
 }
 
 func (r *Reconcile) createChange(ctx context.Context,
-	instance *gerritApi.GerritMergeRequest,
+	instance *gerritApi.GerritMergeRequest, options []string,
 ) (status *gerritApi.GerritMergeRequestStatus, retErr error) {
 	// init git client
 	gitClient, err := r.getGitClient(ctx, instance, r.gitWorkDir)
@@ -276,6 +329,9 @@ func (r *Reconcile) createChange(ctx context.Context,
 
 	// push changes for review
 	refSpec := fmt.Sprintf("HEAD:refs/for/%s", instance.TargetBranch())
+	if len(options) > 0 {
+		refSpec = fmt.Sprintf("%s%%%s", refSpec, strings.Join(options, ","))
+	}
 
 	pushMessage, err := gitClient.Push(instance.Spec.ProjectName, "origin", refSpec)
 	if err != nil {
@@ -283,9 +339,13 @@ func (r *Reconcile) createChange(ctx context.Context,
 	}
 
 	return &gerritApi.GerritMergeRequestStatus{
-		ChangeID:  changeID,
-		ChangeURL: extractMrURL(pushMessage),
-		Value:     StatusNew,
+		ChangeID:       changeID,
+		ChangeURL:      extractMrURL(pushMessage),
+		Value:          StatusNew,
+		Topic:          instance.Spec.Topic,
+		Hashtags:       instance.Spec.Hashtags,
+		WorkInProgress: instance.Spec.WorkInProgress,
+		Private:        instance.Spec.Private,
 	}, nil
 }
 
@@ -369,18 +429,77 @@ func commitMessage(commitMessage, changeID string) string {
 	return fmt.Sprintf("%s\n\nChange-Id: %s", commitMessage, changeID)
 }
 
-func (r *Reconcile) getChangeStatus(ctx context.Context, instance *gerritApi.GerritMergeRequest) (string, error) {
+// pushOptions returns the Gerrit push options of the reviewers, topic, hashtags and flags of the change,
+// e.g. [r=john.doe@example.com topic=release%3A%201.2 wip].
+func pushOptions(instance *gerritApi.GerritMergeRequest) []string {
+	var options []string
+
+	add := func(option string, values ...string) {
+		for _, v := range values {
+			options = append(options, fmt.Sprintf("%s=%s", option, encodePushOptionValue(v)))
+		}
+	}
+
+	add("r", instance.Spec.Reviewers...)
+	add("cc", instance.Spec.CC...)
+
+	if instance.Spec.Topic != "" {
+		add("topic", instance.Spec.Topic)
+	}
+
+	add("t", instance.Spec.Hashtags...)
+
+	if instance.Spec.WorkInProgress {
+		options = append(options, "wip")
+	}
+
+	if instance.Spec.Private {
+		options = append(options, "private")
+	}
+
+	if instance.Spec.Notify != "" {
+		options = append(options, fmt.Sprintf("notify=%s", instance.Spec.Notify))
+	}
+
+	return options
+}
+
+// encodePushOptionValue percent-encodes the push option value, Gerrit decodes the values of the refs/for options.
+// So whitespaces, commas and the characters that are not allowed in git ref names, e.g. "release: 1.2", can be pushed.
+func encodePushOptionValue(value string) string {
+	var b strings.Builder
+
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+
+		// ".." is not allowed in git ref names either
+		if isPushOptionChar(c) && (c != '.' || i == 0 || value[i-1] != '.') {
+			b.WriteByte(c)
+			continue
+		}
+
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+
+	return b.String()
+}
+
+func isPushOptionChar(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '-' || c == '_' || c == '.' || c == '@'
+}
+
+func (r *Reconcile) getChange(ctx context.Context, instance *gerritApi.GerritMergeRequest) (*gerritClient.Change, error) {
 	gClient, err := r.getGerritClient(ctx, instance)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to get gerrit client")
+		return nil, errors.Wrap(err, "unable to get gerrit client")
 	}
 
 	change, err := gClient.ChangeGet(instance.Status.ChangeID)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to get change id")
+		return nil, errors.Wrap(err, "unable to get change id")
 	}
 
-	return change.Status, nil
+	return change, nil
 }
 
 func extractMrURL(pushMessage string) string {
@@ -389,7 +508,10 @@ func extractMrURL(pushMessage string) string {
 		FindString(pushMessage)
 }
 
-func (r *Reconcile) makeDeletionFunc(ctx context.Context, instance *gerritApi.GerritMergeRequest) func() error {
+// makeDeletionFunc returns the function that abandons the change, in the dry-run mode the abandon is added to plannedActions.
+func (r *Reconcile) makeDeletionFunc(ctx context.Context, instance *gerritApi.GerritMergeRequest,
+	plannedActions *[]string,
+) func() error {
 	return func() error {
 		gClient, err := r.getGerritClient(ctx, instance)
 		if err != nil {
@@ -402,11 +524,74 @@ func (r *Reconcile) makeDeletionFunc(ctx context.Context, instance *gerritApi.Ge
 		}
 
 		if change.Status == StatusNew {
+			if helper.IsDryRun(instance) {
+				*plannedActions = append(*plannedActions, fmt.Sprintf("abandon change %s", instance.Status.ChangeID))
+
+				return nil
+			}
+
 			if err := gClient.ChangeAbandon(instance.Status.ChangeID); err != nil {
 				return errors.Wrap(err, "unable to abandon change")
 			}
+
+			helper.RecordEvent(r.recorder, instance, helper.EventReasonDeleted, "Change %s has been abandoned", instance.Status.ChangeID)
+		}
+
+		return nil
+	}
+}
+
+// accountNames returns the usernames of the accounts, the email or the name is used for the accounts without username.
+func accountNames(accounts []gerritClient.Account) []string {
+	names := make([]string, 0, len(accounts))
+
+	for _, a := range accounts {
+		switch {
+		case a.Username != "":
+			names = append(names, a.Username)
+		case a.Email != "":
+			names = append(names, a.Email)
+		case a.Name != "":
+			names = append(names, a.Name)
+		default:
+			names = append(names, strconv.Itoa(a.AccountID))
 		}
+	}
 
+	if len(names) == 0 {
 		return nil
 	}
+
+	return names
+}
+
+// plannedChange describes the change that would be pushed for review in the dry-run mode.
+func plannedChange(instance *gerritApi.GerritMergeRequest, options []string) string {
+	var change string
+	if instance.Spec.SourceBranch != "" {
+		change = fmt.Sprintf("push change to project %s: merge %s into %s", instance.Spec.ProjectName,
+			instance.Spec.SourceBranch, instance.TargetBranch())
+	} else {
+		change = fmt.Sprintf("push change to project %s: commit files from ConfigMap %s into %s", instance.Spec.ProjectName,
+			instance.Spec.ChangesConfigMap, instance.TargetBranch())
+	}
+
+	if len(options) > 0 {
+		change = fmt.Sprintf("%s with options %s", change, strings.Join(options, ","))
+	}
+
+	return change
+}
+
+// setDryRunStatus restores the status changed by the dry-run reconciliation, only the planned actions and the result are kept.
+// The state of the existing change is read from Gerrit, so it is kept as well.
+func setDryRunStatus(instance *gerritApi.GerritMergeRequest, status *gerritApi.GerritMergeRequestStatus, err error) {
+	status.PlannedActions = instance.Status.PlannedActions
+	status.Value = instance.Status.Value
+
+	if err == nil && status.ChangeID == "" {
+		status.Value = helper.StatusDryRun
+	}
+
+	instance.Status = *status
 }
@@ -189,7 +189,12 @@ func (s *ControllerTestSuite) TestReconcileCheckStatus() {
 	fakeClient := fake.NewClientBuilder().WithStatusSubresource(&gerritApi.GerritMergeRequest{}).WithScheme(s.scheme).WithRuntimeObjects(s.rootGerrit, checkStatusRequest).Build()
 
 	s.gerritClient.On("ChangeGet", checkStatusRequest.Status.ChangeID).
-		Return(&gerritClient.Change{Status: StatusAbandoned}, nil).Once()
+		Return(&gerritClient.Change{Status: StatusAbandoned, Topic: "update", Hashtags: []string{"deps"}, WorkInProgress: true,
+			Reviewers: map[string][]gerritClient.Account{
+				gerritClient.ReviewerStateReviewer: {{AccountID: 1000096, Username: "john"}, {AccountID: 1000097, Email: "jane@example.com"}},
+				gerritClient.ReviewerStateCC:       {{AccountID: 1000098, Name: "Team Lead"}},
+				"REMOVED":                          {{AccountID: 1000099, Username: "bob"}},
+			}}, nil).Once()
 
 	rec := Reconcile{
 		k8sClient: fakeClient,
@@ -220,6 +225,60 @@ func (s *ControllerTestSuite) TestReconcileCheckStatus() {
 	assert.NoError(s.T(), err)
 	assert.Equal(s.T(), updatedMergeRequest.Status.Value,
 		StatusAbandoned)
+	assert.Equal(s.T(), "update", updatedMergeRequest.Status.Topic)
+	assert.Equal(s.T(), []string{"deps"}, updatedMergeRequest.Status.Hashtags)
+	assert.Equal(s.T(), []string{"john", "jane@example.com"}, updatedMergeRequest.Status.Reviewers)
+	assert.Equal(s.T(), []string{"Team Lead"}, updatedMergeRequest.Status.CC)
+	assert.True(s.T(), updatedMergeRequest.Status.WorkInProgress)
+	assert.False(s.T(), updatedMergeRequest.Status.Private)
+}
+
+func (s *ControllerTestSuite) TestReconcilePushOptions() {
+	s.mergeRequest.Spec.Reviewers = []string{"john.doe@example.com"}
+	s.mergeRequest.Spec.CC = []string{"jane"}
+	s.mergeRequest.Spec.Topic = "update"
+	s.mergeRequest.Spec.Hashtags = []string{"deps", "bot"}
+	s.mergeRequest.Spec.WorkInProgress = true
+	s.mergeRequest.Spec.Notify = "OWNER_REVIEWERS"
+
+	fakeClient := fake.NewClientBuilder().WithStatusSubresource(&gerritApi.GerritMergeRequest{}).WithScheme(s.scheme).WithRuntimeObjects(s.rootGerrit, s.mergeRequest).Build()
+	changeID := "change123"
+
+	s.gitClient.On("Clone", s.mergeRequest.Spec.ProjectName).Return("path", nil)
+	s.gitClient.On("GenerateChangeID").Return(changeID, nil)
+	s.gitClient.On("SetProjectUser", s.mergeRequest.Spec.ProjectName,
+		&git.User{Name: s.mergeRequest.Spec.AuthorName, Email: s.mergeRequest.Spec.AuthorEmail}).
+		Return(nil)
+	s.gitClient.On("Merge", s.mergeRequest.Spec.ProjectName, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+		mock.Anything, mock.Anything).Return(nil)
+	s.gitClient.On("Push", s.mergeRequest.Spec.ProjectName, "origin",
+		"HEAD:refs/for/master%r=john.doe@example.com,cc=jane,topic=update,t=deps,t=bot,wip,notify=OWNER_REVIEWERS").
+		Return("http://gerrit.com/merge/1", nil)
+
+	rec := Reconcile{
+		k8sClient: fakeClient,
+		service:   s.gerritService,
+		log:       s.logger,
+		getGitClient: func(ctx context.Context, child gerrit.Child, workDir string) (GitClient, error) {
+			return s.gitClient, nil
+		},
+	}
+
+	nn := types.NamespacedName{Name: s.mergeRequest.Name, Namespace: s.mergeRequest.Namespace}
+
+	_, err := rec.Reconcile(context.Background(), reconcile.Request{NamespacedName: nn})
+	require.NoError(s.T(), err)
+
+	var updatedMergeRequest gerritApi.GerritMergeRequest
+	require.NoError(s.T(), rec.k8sClient.Get(context.Background(), nn, &updatedMergeRequest))
+
+	assert.Equal(s.T(), StatusNew, updatedMergeRequest.Status.Value)
+	assert.Nil(s.T(), updatedMergeRequest.Status.Reviewers, "the reviewers are read from Gerrit")
+	assert.Nil(s.T(), updatedMergeRequest.Status.CC)
+	assert.Equal(s.T(), "update", updatedMergeRequest.Status.Topic)
+	assert.Equal(s.T(), []string{"deps", "bot"}, updatedMergeRequest.Status.Hashtags)
+	assert.True(s.T(), updatedMergeRequest.Status.WorkInProgress)
+	assert.False(s.T(), updatedMergeRequest.Status.Private)
 }
 
 func (s *ControllerTestSuite) TestConfigMap() {
@@ -329,3 +388,52 @@ func (s *ControllerTestSuite) TestReconcileCheckStatusFailure() {
 func TestController(t *testing.T) {
 	suite.Run(t, new(ControllerTestSuite))
 }
+
+func TestPushOptions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		spec gerritApi.GerritMergeRequestSpec
+		want []string
+	}{
+		{
+			name: "no options",
+			want: nil,
+		},
+		{
+			name: "all options",
+			spec: gerritApi.GerritMergeRequestSpec{
+				Reviewers:      []string{"john", "jane@example.com"},
+				CC:             []string{"team-lead"},
+				Topic:          "release-1.0",
+				Hashtags:       []string{"automated"},
+				WorkInProgress: true,
+				Private:        true,
+				Notify:         "NONE",
+			},
+			want: []string{
+				"r=john", "r=jane@example.com", "cc=team-lead", "topic=release-1.0", "t=automated", "wip", "private",
+				"notify=NONE",
+			},
+		},
+		{
+			name: "encoded values",
+			spec: gerritApi.GerritMergeRequestSpec{
+				Reviewers: []string{"john,jane"},
+				Topic:     "release: 1.2",
+				Hashtags:  []string{"a..b", "50%~^?*[\\"},
+			},
+			want: []string{"r=john%2Cjane", "topic=release%3A%201.2", "t=a.%2Eb", "t=50%25%7E%5E%3F%2A%5B%5C"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, pushOptions(&gerritApi.GerritMergeRequest{Spec: tt.spec}))
+		})
+	}
+}
@@ -119,3 +119,40 @@ Create the name of the secretstore to use
     {{- end }}
   {{- end }}
 {{- end }}
+
+{{/*
+Namespaces watched by the operator, the empty value watches all namespaces
+*/}}
+{{- define "gerrit-operator.watchNamespace" -}}
+{{- if not .Values.clusterWide -}}
+{{- prepend .Values.watchNamespaces .Release.Namespace | uniq | join "," -}}
+{{- end -}}
+{{- end }}
+
+{{/*
+Rules of the operator in the namespaces of the tenant teams
+*/}}
+{{- define "gerrit-operator.tenantRules" -}}
+- apiGroups:
+    - v2.edp.epam.com
+  resources:
+    - '*'
+  verbs:
+    - '*'
+- apiGroups:
+    - ""
+  resources:
+    - configmaps
+    - secrets
+  verbs:
+    - get
+    - list
+    - watch
+- apiGroups:
+    - ""
+  resources:
+    - events
+  verbs:
+    - create
+    - patch
+{{- end }}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"os"
 
@@ -21,13 +22,17 @@ import (
 
 	gerritApi "github.com/epam/edp-gerrit-operator/v2/api/v1"
 	gerritContr "github.com/epam/edp-gerrit-operator/v2/controllers/gerrit"
+	"github.com/epam/edp-gerrit-operator/v2/controllers/gerritaccesscheck"
 	"github.com/epam/edp-gerrit-operator/v2/controllers/gerritgroup"
 	"github.com/epam/edp-gerrit-operator/v2/controllers/gerritgroupmember"
+	"github.com/epam/edp-gerrit-operator/v2/controllers/gerritgroupsync"
+	"github.com/epam/edp-gerrit-operator/v2/controllers/gerritplugin"
 	"github.com/epam/edp-gerrit-operator/v2/controllers/gerritproject"
 	"github.com/epam/edp-gerrit-operator/v2/controllers/gerritprojectaccess"
 	"github.com/epam/edp-gerrit-operator/v2/controllers/gerritreplicationconfig"
 	"github.com/epam/edp-gerrit-operator/v2/controllers/helper"
 	mergerequest "github.com/epam/edp-gerrit-operator/v2/controllers/merge_request"
+	"github.com/epam/edp-gerrit-operator/v2/pkg/tracing"
 )
 
 var (
@@ -73,6 +78,12 @@ func main() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(gerritApi.AddToScheme(scheme))
 
+	shutdownTracing, err := tracing.Setup(context.Background())
+	if err != nil {
+		setupLog.Error(err, "unable to set up tracing")
+		os.Exit(1)
+	}
+
 	mgr, err := initManager(metricsAddr, probeAddr, enableLeaderElection)
 	if err != nil {
 		setupLog.Error(err, "unable to init manager")
@@ -97,6 +108,11 @@ func main() {
 	setupLog.Info("starting manager")
 
 	err = mgr.Start(ctrl.SetupSignalHandler())
+
+	if shutdownErr := shutdownTracing(context.Background()); shutdownErr != nil {
+		setupLog.Error(shutdownErr, "unable to flush traces")
+	}
+
 	if err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
@@ -104,11 +120,21 @@ func main() {
 }
 
 func initManager(metricsAddr, probeAddr string, enableLeaderElection bool) (ctrl.Manager, error) {
-	ns, err := helper.GetWatchNamespace()
+	namespaces, err := helper.GetWatchNamespaces()
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to get watch namespace")
 	}
 
+	// the empty list of namespaces makes the cache watch all namespaces
+	cacheOptions := cache.Options{}
+	if len(namespaces) > 0 {
+		cacheOptions.DefaultNamespaces = make(map[string]cache.Config, len(namespaces))
+
+		for _, ns := range namespaces {
+			cacheOptions.DefaultNamespaces[ns] = cache.Config{}
+		}
+	}
+
 	cfg := ctrl.GetConfigOrDie()
 
 	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
@@ -117,9 +143,7 @@ func initManager(metricsAddr, probeAddr string, enableLeaderElection bool) (ctrl
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       gerritOperatorLock,
-		Cache: cache.Options{
-			DefaultNamespaces: map[string]cache.Config{ns: {}},
-		},
+		Cache:                  cacheOptions,
 	})
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to start manager")
@@ -175,6 +199,18 @@ func controllerConstructors() []helper.InitFunc {
 			Func:           gerritgroupmember.NewReconcile,
 			ControllerName: "gerrit-group-member",
 		},
+		{
+			Func:           gerritgroupsync.NewReconcile,
+			ControllerName: "gerrit-group-sync",
+		},
+		{
+			Func:           gerritaccesscheck.NewReconcile,
+			ControllerName: "gerrit-access-check",
+		},
+		{
+			Func:           gerritplugin.NewReconcile,
+			ControllerName: "gerrit-plugin",
+		},
 	}
 }
 
@@ -20,6 +20,27 @@ type Interface struct {
 	mock.Mock
 }
 
+// ApplySiteConfig provides a mock function with given fields: ctx, instance
+func (_m *Interface) ApplySiteConfig(ctx context.Context, instance *v1.Gerrit) (bool, error) {
+	ret := _m.Called(ctx, instance)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.Gerrit) bool); ok {
+		r0 = rf(ctx, instance)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *v1.Gerrit) error); ok {
+		r1 = rf(ctx, instance)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Configure provides a mock function with given fields: instance
 func (_m *Interface) Configure(instance *v1.Gerrit) (*v1.Gerrit, bool, error) {
 	ret := _m.Called(instance)
@@ -117,6 +138,29 @@ func (_m *Interface) GetGitClient(ctx context.Context, child gerrit.Child, workD
 	return r0, r1
 }
 
+// NewAdminClient provides a mock function with given fields: gerritInstance
+func (_m *Interface) NewAdminClient(gerritInstance *v1.Gerrit) (clientgerrit.ClientInterface, error) {
+	ret := _m.Called(gerritInstance)
+
+	var r0 clientgerrit.ClientInterface
+	if rf, ok := ret.Get(0).(func(*v1.Gerrit) clientgerrit.ClientInterface); ok {
+		r0 = rf(gerritInstance)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(clientgerrit.ClientInterface)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*v1.Gerrit) error); ok {
+		r1 = rf(gerritInstance)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetRestClient provides a mock function with given fields: gerritInstance
 func (_m *Interface) GetRestClient(gerritInstance *v1.Gerrit) (clientgerrit.ClientInterface, error) {
 	ret := _m.Called(gerritInstance)
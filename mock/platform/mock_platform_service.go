@@ -323,6 +323,24 @@ func (_m *PlatformService) PatchDeploymentEnv(gerrit *v1.Gerrit, env []corev1.En
 	return r0
 }
 
+// PatchDeploymentTemplateAnnotations provides a mock function with given fields: gerrit, annotations
+func (_m *PlatformService) PatchDeploymentTemplateAnnotations(gerrit *v1.Gerrit, annotations map[string]string) error {
+	ret := _m.Called(gerrit, annotations)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchDeploymentTemplateAnnotations")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*v1.Gerrit, map[string]string) error); ok {
+		r0 = rf(gerrit, annotations)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateService provides a mock function with given fields: svc, port
 func (_m *PlatformService) UpdateService(svc *corev1.Service, port int32) error {
 	ret := _m.Called(svc, port)
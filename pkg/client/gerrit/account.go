@@ -0,0 +1,104 @@
+package gerrit
+
+import (
+	"fmt"
+	"net/http"
+	neturl "net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+type AmbiguousError string
+
+func (e AmbiguousError) Error() string {
+	return string(e)
+}
+
+func IsErrAmbiguous(err error) bool {
+	var ambiguousError AmbiguousError
+	return errors.As(err, &ambiguousError)
+}
+
+type Account struct {
+	AccountID int    `json:"_account_id"`
+	Name      string `json:"name,omitempty"`
+	Email     string `json:"email,omitempty"`
+	Username  string `json:"username,omitempty"`
+}
+
+// QueryAccounts searches accounts with the given Gerrit account query.
+func (gc *Client) QueryAccounts(query string) ([]Account, error) {
+	resp, err := gc.request().
+		SetHeader(acceptHeader, applicationJson).
+		SetQueryParam("q", query).
+		SetQueryParam("o", "DETAILS").
+		Get("accounts/")
+	if err = parseRestyResponse(resp, err); err != nil {
+		return nil, errors.Wrap(err, "unable to query accounts")
+	}
+
+	var accounts []Account
+	if err := decodeGerritResponse(resp.String(), &accounts); err != nil {
+		return nil, errors.Wrap(err, "unable to unmarshal accounts response")
+	}
+
+	return accounts, nil
+}
+
+// ResolveAccount finds exactly one account by its username, email or numeric account ID.
+// It returns DoesNotExistError if nothing is found and AmbiguousError if several accounts match.
+func (gc *Client) ResolveAccount(account string) (*Account, error) {
+	query := fmt.Sprintf("username:%s", account)
+
+	accountID, convErr := strconv.Atoi(account)
+
+	switch {
+	case convErr == nil:
+		query = account
+	case strings.Contains(account, "@"):
+		query = fmt.Sprintf("email:%s", account)
+	}
+
+	accounts, err := gc.QueryAccounts(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var exact []Account
+
+	for i := range accounts {
+		if (convErr == nil && accounts[i].AccountID == accountID) ||
+			accounts[i].Username == account ||
+			strings.EqualFold(accounts[i].Email, account) {
+			exact = append(exact, accounts[i])
+		}
+	}
+
+	switch {
+	case len(exact) == 1:
+		return &exact[0], nil
+	case len(exact) == 0 && len(accounts) == 1:
+		return &accounts[0], nil
+	case len(accounts) == 0:
+		return nil, DoesNotExistError(fmt.Sprintf("account %s does not exist", account))
+	default:
+		return nil, AmbiguousError(fmt.Sprintf("account %s matches %d accounts", account, len(accounts)))
+	}
+}
+
+// DeactivateAccount marks the account as inactive, the missing and already inactive accounts are skipped.
+func (gc *Client) DeactivateAccount(username string) error {
+	resp, err := gc.request().
+		Delete(fmt.Sprintf("accounts/%s/active", neturl.PathEscape(username)))
+	if err == nil && (resp.StatusCode() == http.StatusNotFound || resp.StatusCode() == http.StatusConflict) {
+		return nil
+	}
+
+	if err = parseRestyResponse(resp, err); err != nil {
+		return errors.Wrapf(err, "unable to deactivate account %s", username)
+	}
+
+	return nil
+}
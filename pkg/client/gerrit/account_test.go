@@ -0,0 +1,141 @@
+package gerrit
+
+import (
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsErrAmbiguous(t *testing.T) {
+	assert.True(t, IsErrAmbiguous(errors.Wrap(AmbiguousError("ambiguous"), "wrap")))
+	assert.False(t, IsErrAmbiguous(errors.New("fatal")))
+}
+
+func TestClient_QueryAccounts(t *testing.T) {
+	restyClient := CreateMockResty()
+	cl := Client{
+		resty: restyClient,
+	}
+
+	httpmock.RegisterResponder("GET", "/accounts/?o=DETAILS&q=username%3Ajohn",
+		httpmock.NewStringResponder(200, `)]}'
+[{"_account_id": 1000096, "name": "John Doe", "username": "john", "email": "john@example.com"}]`))
+
+	accounts, err := cl.QueryAccounts("username:john")
+	require.NoError(t, err)
+
+	require.Len(t, accounts, 1)
+	assert.Equal(t, 1000096, accounts[0].AccountID)
+	assert.Equal(t, "john@example.com", accounts[0].Email)
+}
+
+func TestClient_QueryAccounts_RespErr(t *testing.T) {
+	restyClient := CreateMockResty()
+	cl := Client{
+		resty: restyClient,
+	}
+
+	httpmock.RegisterResponder("GET", "/accounts/",
+		httpmock.NewStringResponder(400, "bad query"))
+
+	_, err := cl.QueryAccounts("username:")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to query accounts")
+}
+
+func TestClient_ResolveAccount(t *testing.T) {
+	tests := []struct {
+		name    string
+		account string
+		query   string
+		body    string
+		wantID  int
+		wantErr func(err error) bool
+	}{
+		{
+			name:    "by username",
+			account: "john",
+			query:   "username%3Ajohn",
+			body:    `[{"_account_id": 1000096, "username": "john"}]`,
+			wantID:  1000096,
+		},
+		{
+			name:    "by email with several partial matches",
+			account: "John@example.com",
+			query:   "email%3AJohn%40example.com",
+			body:    `[{"_account_id": 1000096, "email": "john@example.com"}, {"_account_id": 1000097, "email": "john@example.com.ua"}]`,
+			wantID:  1000096,
+		},
+		{
+			name:    "by account id",
+			account: "1000096",
+			query:   "1000096",
+			body:    `[{"_account_id": 1000096, "username": "john"}]`,
+			wantID:  1000096,
+		},
+		{
+			name:    "not found",
+			account: "john",
+			query:   "username%3Ajohn",
+			body:    `[]`,
+			wantErr: IsErrDoesNotExist,
+		},
+		{
+			name:    "ambiguous",
+			account: "john@example.com",
+			query:   "email%3Ajohn%40example.com",
+			body:    `[{"_account_id": 1000096, "email": "john@example.com"}, {"_account_id": 1000097, "email": "john@example.com"}]`,
+			wantErr: IsErrAmbiguous,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			restyClient := CreateMockResty()
+			cl := Client{
+				resty: restyClient,
+			}
+
+			httpmock.RegisterResponder("GET", "/accounts/?o=DETAILS&q="+tt.query,
+				httpmock.NewStringResponder(200, ")]}'\n"+tt.body))
+
+			acc, err := cl.ResolveAccount(tt.account)
+			if tt.wantErr != nil {
+				require.Error(t, err)
+				assert.True(t, tt.wantErr(err), err.Error())
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantID, acc.AccountID)
+		})
+	}
+}
+
+func TestClient_DeactivateAccount(t *testing.T) {
+	restyClient := CreateMockResty()
+	cl := Client{
+		resty: restyClient,
+	}
+
+	httpmock.RegisterResponder("DELETE", "/accounts/edp-ci/active",
+		httpmock.NewStringResponder(204, ""))
+	httpmock.RegisterResponder("DELETE", "/accounts/inactive/active",
+		httpmock.NewStringResponder(409, "account not active"))
+	httpmock.RegisterResponder("DELETE", "/accounts/missing/active",
+		httpmock.NewStringResponder(404, "Not found"))
+	httpmock.RegisterResponder("DELETE", "/accounts/forbidden/active",
+		httpmock.NewStringResponder(403, "forbidden"))
+
+	require.NoError(t, cl.DeactivateAccount("edp-ci"))
+	require.NoError(t, cl.DeactivateAccount("inactive"))
+	require.NoError(t, cl.DeactivateAccount("missing"))
+
+	err := cl.DeactivateAccount("forbidden")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to deactivate account forbidden")
+}
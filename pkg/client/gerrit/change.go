@@ -2,17 +2,35 @@ package gerrit
 
 import (
 	"fmt"
+	"net/url"
 
 	"github.com/pkg/errors"
 )
 
+const (
+	ChangeStatusNew       = "NEW"
+	ChangeStatusMerged    = "MERGED"
+	ChangeStatusAbandoned = "ABANDONED"
+
+	ReviewerStateReviewer = "REVIEWER"
+	ReviewerStateCC       = "CC"
+)
+
 type Change struct {
-	ID     string `json:"id"`
-	Status string `json:"status"`
+	ID             string   `json:"id"`
+	Number         int      `json:"_number,omitempty"`
+	Status         string   `json:"status"`
+	Topic          string   `json:"topic,omitempty"`
+	Hashtags       []string `json:"hashtags,omitempty"`
+	WorkInProgress bool     `json:"work_in_progress,omitempty"`
+	IsPrivate      bool     `json:"is_private,omitempty"`
+
+	// Reviewers maps the reviewer states, e.g. REVIEWER and CC, to the accounts of the change.
+	Reviewers map[string][]Account `json:"reviewers,omitempty"`
 }
 
 func (gc *Client) ChangeAbandon(changeID string) error {
-	rsp, err := gc.resty.R().Post(fmt.Sprintf("changes/%s/abandon", changeID))
+	rsp, err := gc.request().Post(fmt.Sprintf("changes/%s/abandon", changeID))
 	if err = parseRestyResponse(rsp, err); err != nil {
 		return errors.Wrap(err, "unable to abandon change")
 	}
@@ -21,7 +39,10 @@ func (gc *Client) ChangeAbandon(changeID string) error {
 }
 
 func (gc *Client) ChangeGet(changeID string) (*Change, error) {
-	rsp, err := gc.resty.R().Get(fmt.Sprintf("changes/%s", changeID))
+	// the reviewers are returned only with the detailed labels
+	rsp, err := gc.request().
+		SetQueryString(url.Values{"o": {"DETAILED_LABELS", "DETAILED_ACCOUNTS"}}.Encode()).
+		Get(fmt.Sprintf("changes/%s", changeID))
 	if err = parseRestyResponse(rsp, err); err != nil {
 		return nil, errors.Wrap(err, "unable to get change")
 	}
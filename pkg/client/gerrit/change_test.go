@@ -16,10 +16,24 @@ func TestClient_ChangeGet(t *testing.T) {
 	assert.Error(t, err)
 
 	httpmock.RegisterResponder("GET", "/changes/ch1",
-		httpmock.NewStringResponder(200, ")]}' {}"))
+		httpmock.NewStringResponder(200, `)]}' {"id": "prj~master~ch1", "_number": 7, "status": "NEW", "topic": "update",
+"hashtags": ["deps"], "work_in_progress": true, "is_private": true,
+"reviewers": {"REVIEWER": [{"_account_id": 1000096, "username": "john"}]}}`))
 
-	_, err = cl.ChangeGet("ch1")
+	change, err := cl.ChangeGet("ch1")
 	assert.NoError(t, err)
+	assert.Equal(t, &Change{
+		ID:             "prj~master~ch1",
+		Number:         7,
+		Status:         ChangeStatusNew,
+		Topic:          "update",
+		Hashtags:       []string{"deps"},
+		WorkInProgress: true,
+		IsPrivate:      true,
+		Reviewers: map[string][]Account{
+			ReviewerStateReviewer: {{AccountID: 1000096, Username: "john"}},
+		},
+	}, change)
 }
 
 func TestDecodeGerritResponse(t *testing.T) {
@@ -0,0 +1,261 @@
+package gerrit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// DryRunClient passes the read requests to the Gerrit client and records the changes instead of making them.
+// The changes are compared with the current state of Gerrit where it is possible, so the no-op changes are not planned.
+type DryRunClient struct {
+	ClientInterface
+
+	actions []string
+}
+
+func NewDryRunClient(cl ClientInterface) *DryRunClient {
+	return &DryRunClient{ClientInterface: cl}
+}
+
+// PlannedActions returns the changes that would be made in Gerrit.
+func (c *DryRunClient) PlannedActions() []string {
+	return c.actions
+}
+
+// PlannedActions returns the changes recorded by the dry-run client and nil for other clients.
+func PlannedActions(cl ClientInterface) []string {
+	if dryRun, ok := cl.(*DryRunClient); ok {
+		return dryRun.PlannedActions()
+	}
+
+	return nil
+}
+
+func (c *DryRunClient) plan(format string, args ...interface{}) {
+	c.actions = append(c.actions, fmt.Sprintf(format, args...))
+}
+
+func (c *DryRunClient) SetProjectParent(projectName, parentName string) error {
+	prj, err := c.ClientInterface.GetProject(projectName)
+	if err != nil && !IsErrDoesNotExist(err) {
+		return errors.Wrap(err, "unable to get project")
+	}
+
+	if prj == nil || prj.Parent != parentName {
+		c.plan("set parent of project %s to %s", projectName, parentName)
+	}
+
+	return nil
+}
+
+func (c *DryRunClient) DeleteAccessRights(projectName string, permissions []AccessInfo) error {
+	c.plan("delete %d access rights of project %s", len(permissions), projectName)
+
+	return nil
+}
+
+func (c *DryRunClient) UpdateAccessRights(projectName string, permissions []AccessInfo) error {
+	c.plan("update %d access rights of project %s", len(permissions), projectName)
+
+	return nil
+}
+
+func (c *DryRunClient) AddAccessRights(projectName string, permissions []AccessInfo) error {
+	c.plan("add %d access rights to project %s", len(permissions), projectName)
+
+	return nil
+}
+
+func (c *DryRunClient) ReviewAccessRights(projectName, _ string, add, remove []AccessInfo) (*Change, error) {
+	c.plan("create review change adding %d and removing %d access rights of project %s", len(add), len(remove), projectName)
+
+	return &Change{Status: ChangeStatusNew}, nil
+}
+
+func (c *DryRunClient) CreateGroup(name, description string, visibleToAll bool) (*Group, error) {
+	_, err := c.ClientInterface.GetGroup(name)
+	if err == nil {
+		return nil, AlreadyExistsError("already exists")
+	}
+
+	if !IsErrDoesNotExist(err) {
+		return nil, errors.Wrap(err, "unable to get group")
+	}
+
+	c.plan("create group %s", name)
+
+	// the group does not exist yet, so the name is used as its ID in the next planned actions
+	return &Group{ID: name, Name: name, Description: description, Options: GroupOptions{VisibleToAll: visibleToAll}}, nil
+}
+
+func (c *DryRunClient) UpdateGroup(groupID, description string, visibleToAll bool) error {
+	gr, err := c.ClientInterface.GetGroup(groupID)
+	if err != nil && !IsErrDoesNotExist(err) {
+		return errors.Wrap(err, "unable to get group")
+	}
+
+	if gr == nil || gr.Description != description || gr.Options.VisibleToAll != visibleToAll {
+		c.plan("update group %s", groupID)
+	}
+
+	return nil
+}
+
+func (c *DryRunClient) SetGroupOwner(groupID, ownerGroupID string) error {
+	gr, err := c.ClientInterface.GetGroup(groupID)
+	if err != nil && !IsErrDoesNotExist(err) {
+		return errors.Wrap(err, "unable to get group")
+	}
+
+	if gr == nil || gr.OwnerID != ownerGroupID {
+		c.plan("set owner of group %s to %s", groupID, ownerGroupID)
+	}
+
+	return nil
+}
+
+func (c *DryRunClient) AddIncludedGroups(groupID string, groups []string) error {
+	c.plan("include groups %s into group %s", strings.Join(groups, ", "), groupID)
+
+	return nil
+}
+
+func (c *DryRunClient) DeleteIncludedGroups(groupID string, groups []string) error {
+	c.plan("exclude groups %s from group %s", strings.Join(groups, ", "), groupID)
+
+	return nil
+}
+
+func (c *DryRunClient) AddUserToGroup(groupName, username string) error {
+	members, err := c.ClientInterface.ListGroupMembers(groupName)
+	if err != nil && !IsErrDoesNotExist(err) {
+		return errors.Wrap(err, "unable to list group members")
+	}
+
+	for i := range members {
+		if isGroupMember(&members[i], username) {
+			return nil
+		}
+	}
+
+	c.plan("add %s to group %s", username, groupName)
+
+	return nil
+}
+
+func (c *DryRunClient) DeleteUserFromGroup(groupName, username string) error {
+	c.plan("delete %s from group %s", username, groupName)
+
+	return nil
+}
+
+func (c *DryRunClient) CreateProject(prj *Project) error {
+	c.plan("create project %s", prj.Name)
+
+	return nil
+}
+
+func (c *DryRunClient) UpdateProject(prj *Project) error {
+	current, err := c.ClientInterface.GetProject(prj.Name)
+	if err != nil && !IsErrDoesNotExist(err) {
+		return errors.Wrap(err, "unable to get project")
+	}
+
+	if current == nil || current.Description != prj.Description || (prj.Parent != "" && current.Parent != prj.Parent) {
+		c.plan("update project %s", prj.Name)
+	}
+
+	return nil
+}
+
+func (c *DryRunClient) DeleteProject(name string) error {
+	c.plan("delete project %s", name)
+
+	return nil
+}
+
+func (c *DryRunClient) ReloadPlugin(plugin string) error {
+	c.plan("reload plugin %s", plugin)
+
+	return nil
+}
+
+func (c *DryRunClient) InstallPlugin(pluginID, jarURL string) error {
+	c.plan("install plugin %s from %s", pluginID, jarURL)
+
+	return nil
+}
+
+func (c *DryRunClient) UploadPlugin(pluginID string, jar []byte) error {
+	c.plan("upload plugin %s (%d bytes)", pluginID, len(jar))
+
+	return nil
+}
+
+func (c *DryRunClient) EnablePlugin(pluginID string) error {
+	c.plan("enable plugin %s", pluginID)
+
+	return nil
+}
+
+func (c *DryRunClient) DisablePlugin(pluginID string) error {
+	c.plan("disable plugin %s", pluginID)
+
+	return nil
+}
+
+func (c *DryRunClient) RestartPlugin(pluginID string) error {
+	c.plan("reload plugin %s", pluginID)
+
+	return nil
+}
+
+func (c *DryRunClient) ChangeAbandon(changeID string) error {
+	c.plan("abandon change %s", changeID)
+
+	return nil
+}
+
+func (c *DryRunClient) UpdateMetaConfig(projectName, _ string, _ map[string]MetaConfigFile) error {
+	c.plan("update %s of project %s", metaConfigRef, projectName)
+
+	return nil
+}
+
+func (c *DryRunClient) CreateUser(username, _, _, _ string) error {
+	c.plan("create user %s", username)
+
+	return nil
+}
+
+func (c *DryRunClient) ChangePassword(username, _ string) error {
+	c.plan("change password of user %s", username)
+
+	return nil
+}
+
+func (c *DryRunClient) AddSSHKey(username, _ string) error {
+	c.plan("add SSH key of user %s", username)
+
+	return nil
+}
+
+func (c *DryRunClient) DeactivateAccount(username string) error {
+	c.plan("deactivate account %s", username)
+
+	return nil
+}
+
+func (c *DryRunClient) AddUserToGroups(userName string, groupNames []string) error {
+	c.plan("add %s to groups %s", userName, strings.Join(groupNames, ", "))
+
+	return nil
+}
+
+// isGroupMember reports whether the member is the account given by the username, email or account ID.
+func isGroupMember(member *GroupMember, account string) bool {
+	return member.Username == account || member.Email == account || strconv.Itoa(member.AccountID) == account
+}
@@ -0,0 +1,53 @@
+package gerrit
+
+import (
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDryRunClient(t *testing.T) {
+	cl := NewDryRunClient(&Client{resty: CreateMockResty()})
+
+	// only the read requests are registered, the changes would fail
+	httpmock.RegisterResponder("GET", "/projects/team%2Fbackend", httpmock.NewStringResponder(200, `)]}'
+{"name": "team/backend", "parent": "All-Projects", "description": "old"}`))
+	httpmock.RegisterResponder("GET", "/projects/team%2Ffrontend", httpmock.NewStringResponder(200, `)]}'
+{"name": "team/frontend", "parent": "All-Projects", "description": "frontend"}`))
+	httpmock.RegisterResponder("GET", "/groups/devs", httpmock.NewStringResponder(200, `)]}'
+{"id": "devs-uuid", "name": "devs", "group_id": 3}`))
+	httpmock.RegisterResponder("GET", "/groups/qa", httpmock.NewStringResponder(404, "Not found"))
+	httpmock.RegisterResponder("GET", "/groups/devs/members/", httpmock.NewStringResponder(200, `)]}'
+[{"_account_id": 1000096, "username": "john", "email": "john@example.com"}]`))
+
+	require.NoError(t, cl.UpdateProject(&Project{Name: "team/backend", Description: "new"}))
+	require.NoError(t, cl.UpdateProject(&Project{Name: "team/frontend", Description: "frontend"}))
+	require.NoError(t, cl.SetProjectParent("team/frontend", "All-Projects"))
+
+	_, err := cl.CreateGroup("devs", "", false)
+	assert.True(t, IsErrAlreadyExists(err))
+
+	gr, err := cl.CreateGroup("qa", "", false)
+	require.NoError(t, err)
+	assert.Equal(t, "qa", gr.ID)
+
+	require.NoError(t, cl.AddUserToGroup("devs", "john@example.com"))
+	require.NoError(t, cl.AddUserToGroup("devs", "jane"))
+	require.NoError(t, cl.DeleteProject("team/legacy"))
+
+	change, err := cl.ReviewAccessRights("team/backend", "", []AccessInfo{{RefPattern: "refs/*", PermissionName: "read"}}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, ChangeStatusNew, change.Status)
+
+	assert.Equal(t, []string{
+		"update project team/backend",
+		"create group qa",
+		"add jane to group devs",
+		"delete project team/legacy",
+		"create review change adding 1 and removing 0 access rights of project team/backend",
+	}, PlannedActions(cl))
+
+	assert.Nil(t, PlannedActions(&Client{}))
+}
@@ -2,12 +2,14 @@ package gerrit
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	neturl "net/url"
 	"os"
-	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -16,16 +18,14 @@ import (
 
 	gerritApi "github.com/epam/edp-gerrit-operator/v2/api/v1"
 	"github.com/epam/edp-gerrit-operator/v2/pkg/client/ssh"
-	"github.com/epam/edp-gerrit-operator/v2/pkg/service/gerrit/spec"
-	"github.com/epam/edp-gerrit-operator/v2/pkg/service/platform"
+	"github.com/epam/edp-gerrit-operator/v2/pkg/metrics"
+	"github.com/epam/edp-gerrit-operator/v2/pkg/tracing"
 )
 
 const (
 	acceptHeader    = "Accept"
 	applicationJson = "application/json"
-	path            = "/bin/sh"
 	minBodyLength   = 5
-	containerFlag   = "-c"
 )
 
 var log = ctrl.Log.WithName("client_gerrit")
@@ -34,6 +34,13 @@ type Client struct {
 	instance  *gerritApi.Gerrit // TODO: remove this
 	resty     *resty.Client
 	sshClient ssh.SSHClientInterface
+	// ctx is the context of the REST and SSH requests, it carries the trace of the caller.
+	ctx context.Context
+}
+
+// ContextClient is implemented by the clients that can bind the requests to a context.
+type ContextClient interface {
+	WithContext(ctx context.Context) ClientInterface
 }
 
 func NewClient(instance *gerritApi.Gerrit, restyClient *resty.Client, sshClient ssh.SSHClientInterface) Client {
@@ -50,11 +57,36 @@ func (gc *Client) Resty() *resty.Client {
 	return gc.resty
 }
 
+// WithContext returns a copy of the client that sends the requests with the given context.
+func (gc *Client) WithContext(ctx context.Context) ClientInterface {
+	cl := *gc
+	cl.ctx = ctx
+
+	return &cl
+}
+
+func (gc *Client) request() *resty.Request {
+	req := gc.resty.R()
+	if gc.ctx != nil {
+		req.SetContext(gc.ctx)
+	}
+
+	return req
+}
+
 // InitNewRestClient performs initialization of Gerrit connection.
 func (gc *Client) InitNewRestClient(instance *gerritApi.Gerrit, url, user, password string) error {
-	gc.resty = resty.SetHostURL(url).SetBasicAuth(user, password).SetDisableWarn(true)
+	gc.resty = resty.New().SetHostURL(url).SetBasicAuth(user, password).SetDisableWarn(true)
 	gc.instance = instance
 
+	basePath := ""
+	if u, err := neturl.Parse(url); err == nil {
+		basePath = u.Path
+	}
+
+	gc.resty.SetTransport(tracing.NewRoundTripper(metrics.NewRoundTripper(nil, gc.instanceName(), basePath),
+		gc.instanceName(), basePath))
+
 	return nil
 }
 
@@ -64,20 +96,35 @@ func (gc *Client) InitNewSshClient(userName string, privateKey []byte, host stri
 		return errors.Wrap(err, "err while initializing new ssh client")
 	}
 
+	client.Gerrit = gc.instanceName()
 	gc.sshClient = &client
 
 	return nil
 }
 
+func (gc *Client) instanceName() string {
+	if gc.instance == nil {
+		return ""
+	}
+
+	return gc.instance.Name
+}
+
 // CheckCredentials checks whether provided creds are correct.
 func (gc *Client) CheckCredentials() (int, error) {
-	resp, err := gc.resty.R().
+	resp, err := gc.request().
 		SetHeader(acceptHeader, applicationJson).
 		Get("config/server/summary")
 	if err != nil {
+		metrics.IncCredentialCheckFailures(gc.instanceName(), metrics.CodeError)
+
 		return 0, errors.Wrapf(err, "Unable to verify Gerrit credentials")
 	}
 
+	if resp.IsError() {
+		metrics.IncCredentialCheckFailures(gc.instanceName(), strconv.Itoa(resp.StatusCode()))
+	}
+
 	return resp.StatusCode(), nil
 }
 
@@ -98,7 +145,7 @@ func (gc *Client) CheckGroup(groupName string) (*int, error) {
 		return &statusNotFound, nil
 	}
 
-	resp, err := gc.resty.R().
+	resp, err := gc.request().
 		SetHeader(acceptHeader, applicationJson).
 		Get(fmt.Sprintf("groups/%v", uuid))
 	if err != nil {
@@ -112,7 +159,7 @@ func (gc *Client) CheckGroup(groupName string) (*int, error) {
 
 // GetUser checks gerrit user.
 func (gc *Client) GetUser(username string) (*int, error) {
-	resp, err := gc.resty.R().
+	resp, err := gc.request().
 		SetHeader(acceptHeader, applicationJson).
 		Get(fmt.Sprintf("accounts/%v", username))
 	if err != nil {
@@ -124,45 +171,27 @@ func (gc *Client) GetUser(username string) (*int, error) {
 	return &status, nil
 }
 
-func (*Client) InitAdminUser(instance *gerritApi.Gerrit, k8sService platform.PlatformService, gerritScriptsPath, podName, gerritAdminPublicKey string) (*gerritApi.Gerrit, error) {
-	addInitialAdminUserScript, err := os.ReadFile(filepath.FromSlash(fmt.Sprintf("%v/add-initial-admin-user.sh", gerritScriptsPath)))
-	if err != nil {
-		return instance, errors.Wrapf(err, "Failed to read add-initial-admin-user.sh script")
-	}
-
-	_, _, err = k8sService.ExecInPod(instance.Namespace, podName,
-		[]string{path, containerFlag, "mkdir -p /tmp/scripts && touch /tmp/scripts/add-initial-admin-user.sh && chmod +x /tmp/scripts/add-initial-admin-user.sh"})
-	if err != nil {
-		return instance, errors.Wrapf(err, "Failed to create add-initial-admin-user.sh script inside gerrit pod")
-	}
-
-	_, _, err = k8sService.ExecInPod(instance.Namespace, podName,
-		[]string{path, containerFlag, fmt.Sprintf("echo \"%v\" > /tmp/scripts/add-initial-admin-user.sh", string(addInitialAdminUserScript))})
-	if err != nil {
-		return instance, errors.Wrapf(err, "Failed to add content to add-initial-admin-user.sh script inside gerrit pod")
-	}
-
-	_, _, err = k8sService.ExecInPod(instance.Namespace, podName,
-		[]string{path, containerFlag, fmt.Sprintf("sh /tmp/scripts/add-initial-admin-user.sh \"%v\"", gerritAdminPublicKey)})
-	if err != nil {
-		return instance, errors.Wrapf(err, "Failed to execute add-initial-admin-user.sh script inside gerrit pod")
+// ChangePassword sets the HTTP password of the account, the username "self" is the account of the client.
+func (gc *Client) ChangePassword(username, password string) error {
+	resp, err := gc.request().
+		SetHeader(contentType, applicationJson).
+		SetBody(map[string]string{"http_password": password}).
+		Put(fmt.Sprintf("accounts/%s/password.http", neturl.PathEscape(username)))
+	if err = parseRestyResponse(resp, err); err != nil {
+		return errors.Wrapf(err, "Changing %v password failed", username)
 	}
 
-	return instance, nil
+	return nil
 }
 
-func (gc *Client) ChangePassword(username, password string) error {
-	cmd := &ssh.SSHCommand{
-		Path:   fmt.Sprintf("gerrit set-account --http-password \"%v\" \"%v\"", password, username),
-		Env:    []string{},
-		Stdin:  os.Stdin,
-		Stdout: os.Stdout,
-		Stderr: os.Stderr,
-	}
-
-	out, err := gc.sshClient.RunCommand(cmd)
-	if err != nil {
-		return errors.Wrapf(err, "Changing %v password failed. %v", username, bytes.NewBuffer(out).String())
+// AddSSHKey adds the public SSH key to the account, the username "self" is the account of the client.
+func (gc *Client) AddSSHKey(username, publicKey string) error {
+	resp, err := gc.request().
+		SetHeader(contentType, "text/plain").
+		SetBody(publicKey).
+		Post(fmt.Sprintf("accounts/%s/sshkeys", neturl.PathEscape(username)))
+	if err = parseRestyResponse(resp, err); err != nil {
+		return errors.Wrapf(err, "Adding SSH key of %v failed", username)
 	}
 
 	return nil
@@ -170,11 +199,12 @@ func (gc *Client) ChangePassword(username, password string) error {
 
 func (gc *Client) ReloadPlugin(plugin string) error {
 	cmd := &ssh.SSHCommand{
-		Path:   fmt.Sprintf("gerrit plugin reload \"%v\"", plugin),
-		Env:    []string{},
-		Stdin:  os.Stdin,
-		Stdout: os.Stdout,
-		Stderr: os.Stderr,
+		Path:    fmt.Sprintf("gerrit plugin reload \"%v\"", plugin),
+		Env:     []string{},
+		Context: gc.ctx,
+		Stdin:   os.Stdin,
+		Stdout:  os.Stdout,
+		Stderr:  os.Stderr,
 	}
 
 	_, err := gc.sshClient.RunCommand(cmd)
@@ -197,10 +227,11 @@ func (gc *Client) CreateUser(username, password, fullName, publicKey string) err
 		cmd := &ssh.SSHCommand{
 			Path: fmt.Sprintf("gerrit create-account --full-name \"%v\" --http-password \"%v\" --ssh-key \"%v\" \"%v\"",
 				fullName, password, publicKey, username),
-			Env:    []string{},
-			Stdin:  os.Stdin,
-			Stdout: os.Stdout,
-			Stderr: os.Stderr,
+			Env:     []string{},
+			Context: gc.ctx,
+			Stdin:   os.Stdin,
+			Stdout:  os.Stdout,
+			Stderr:  os.Stderr,
 		}
 
 		_, err = gc.sshClient.RunCommand(cmd)
@@ -225,11 +256,12 @@ func (gc *Client) AddUserToGroups(userName string, groupNames []string) error {
 			log.Info(fmt.Sprintf("Group %v not found in Gerrit", group))
 		} else {
 			cmd := &ssh.SSHCommand{
-				Path:   fmt.Sprintf("gerrit set-members --add \"%v\" \"%v\"", userName, group),
-				Env:    []string{},
-				Stdin:  os.Stdin,
-				Stdout: os.Stdout,
-				Stderr: os.Stderr,
+				Path:    fmt.Sprintf("gerrit set-members --add \"%v\" \"%v\"", userName, group),
+				Env:     []string{},
+				Context: gc.ctx,
+				Stdin:   os.Stdin,
+				Stdout:  os.Stdout,
+				Stderr:  os.Stderr,
 			}
 
 			_, err := gc.sshClient.RunCommand(cmd)
@@ -245,11 +277,12 @@ func (gc *Client) AddUserToGroups(userName string, groupNames []string) error {
 func (gc *Client) getGroupUuid(groupName string) (string, error) {
 	re := regexp.MustCompile(fmt.Sprintf(`%v\t[A-Za-z0-9_]{40}`, groupName))
 	cmd := &ssh.SSHCommand{
-		Path:   "gerrit ls-groups -v",
-		Env:    []string{},
-		Stdin:  os.Stdin,
-		Stdout: os.Stdout,
-		Stderr: os.Stderr,
+		Path:    "gerrit ls-groups -v",
+		Env:     []string{},
+		Context: gc.ctx,
+		Stdin:   os.Stdin,
+		Stdout:  os.Stdout,
+		Stderr:  os.Stderr,
 	}
 
 	out, err := gc.sshClient.RunCommand(cmd)
@@ -269,91 +302,6 @@ func (gc *Client) getGroupUuid(groupName string) (string, error) {
 	return uuid, nil
 }
 
-func (gc *Client) InitAllProjects(instance *gerritApi.Gerrit, k8sService platform.PlatformService,
-	gerritScriptsPath string, podName string, _ string,
-) error {
-	initAllProjectsScript, err := os.ReadFile(filepath.FromSlash(fmt.Sprintf("%v/init-all-projects.sh", gerritScriptsPath)))
-	if err != nil {
-		return errors.Wrapf(err, "Failed to read init-all-projects.sh script")
-	}
-
-	gerritConfig, err := os.ReadFile(filepath.FromSlash(fmt.Sprintf("%v/../gerrit.config", gerritScriptsPath)))
-	if err != nil {
-		return errors.Wrapf(err, "Failed to read init-all-projects.sh script")
-	}
-
-	ciToolsGroupUuid, err := gc.getGroupUuid(spec.GerritCIToolsGroupName)
-	if err != nil {
-		return errors.Wrapf(err, "Failed to get %v group ID", spec.GerritCIToolsGroupName)
-	}
-
-	projectBootstrappersGroupUuid, err := gc.getGroupUuid(spec.GerritProjectBootstrappersGroupName)
-	if err != nil {
-		return errors.Wrapf(err, "Failed to get %v group ID", spec.GerritCIToolsGroupName)
-	}
-
-	developersGroupUuid, err := gc.getGroupUuid(spec.GerritProjectDevelopersGroupName)
-	if err != nil {
-		return errors.Wrapf(err, "Failed to get %v group ID", spec.GerritCIToolsGroupName)
-	}
-
-	readOnlyGroupUuid, err := gc.getGroupUuid(spec.GerritReadOnlyGroupName)
-	if err != nil {
-		return errors.Wrapf(err, "Failed to get %s group ID", spec.GerritReadOnlyGroupName)
-	}
-
-	_, _, err = k8sService.ExecInPod(instance.Namespace, podName,
-		[]string{path, containerFlag, "mkdir -p /tmp/scripts && touch /tmp/scripts/init-all-projects.sh && chmod +x /tmp/scripts/init-all-projects.sh"})
-	if err != nil {
-		return errors.Wrapf(err, "Failed to create init-all-projects.sh script inside gerrit pod")
-	}
-
-	_, _, err = k8sService.ExecInPod(instance.Namespace, podName,
-		[]string{path, containerFlag, fmt.Sprintf("echo \"%v\" > /tmp/scripts/init-all-projects.sh", string(initAllProjectsScript))})
-	if err != nil {
-		return errors.Wrapf(err, "Failed to create init-all-projects.sh script inside gerrit pod")
-	}
-
-	_, _, err = k8sService.ExecInPod(instance.Namespace, podName,
-		[]string{
-			path, containerFlag,
-			fmt.Sprintf("sh /tmp/scripts/init-all-projects.sh \"%v\" \"%v\" \"%v\" \"%v\" \"%v\"",
-				string(gerritConfig), ciToolsGroupUuid, projectBootstrappersGroupUuid, developersGroupUuid, readOnlyGroupUuid),
-		})
-	if err != nil {
-		return errors.Wrapf(err, "Failed to execute init-all-projects.sh script inside gerrit pod")
-	}
-
-	// The script pushes refs/meta/config straight into the bare All-Projects repository on the
-	// pod filesystem, bypassing Gerrit's receive path. The running daemon therefore keeps serving
-	// the ACL it loaded at start-up until its project cache happens to re-read the ref, and until
-	// then edp-ci has no push permission - branch creation is rejected with
-	// "Unable to resolve object ... or get update permission to create new commit objects".
-	if err = gc.flushCaches(); err != nil {
-		return err
-	}
-
-	return nil
-}
-
-// flushCaches makes Gerrit drop its in-memory caches so that configuration written directly to
-// the site repositories takes effect immediately.
-func (gc *Client) flushCaches() error {
-	cmd := &ssh.SSHCommand{
-		Path:   "gerrit flush-caches --all",
-		Env:    []string{},
-		Stdin:  os.Stdin,
-		Stdout: os.Stdout,
-		Stderr: os.Stderr,
-	}
-
-	if _, err := gc.sshClient.RunCommand(cmd); err != nil {
-		return errors.Wrap(err, "Failed to flush Gerrit caches")
-	}
-
-	return nil
-}
-
 func decodeGerritResponse(body string, v interface{}) error {
 	if len(body) < minBodyLength {
 		return errors.New("wrong gerrit body format")
@@ -1,12 +1,14 @@
 package gerrit
 
 import (
+	"context"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"strings"
@@ -14,12 +16,10 @@ import (
 
 	"github.com/jarcoal/httpmock"
 	"github.com/stretchr/testify/assert"
-	testifyMock "github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/resty.v1"
 
 	gerritApi "github.com/epam/edp-gerrit-operator/v2/api/v1"
-	platformMock "github.com/epam/edp-gerrit-operator/v2/mock/platform"
 	mock "github.com/epam/edp-gerrit-operator/v2/mock/ssh"
 	"github.com/epam/edp-gerrit-operator/v2/pkg/client/ssh"
 )
@@ -79,6 +79,20 @@ func TestClient_InitNewRestClient(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestClient_WithContext(t *testing.T) {
+	cl := Client{resty: resty.New()}
+
+	type ctxKey struct{}
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "reconcile")
+
+	ctxCl, ok := cl.WithContext(ctx).(*Client)
+	require.True(t, ok)
+
+	assert.Equal(t, ctx, ctxCl.request().Context())
+	assert.Nil(t, cl.ctx, "the original client should not be changed")
+}
+
 func TestClient_CheckCredentials(t *testing.T) {
 	restyClient := CreateMockResty()
 
@@ -246,49 +260,65 @@ func TestClient_GetUserErr(t *testing.T) {
 }
 
 func TestClient_ChangePassword(t *testing.T) {
-	sshCl := mock.SSHClientInterface{}
 	cl := Client{
-		sshClient: &sshCl,
+		resty: CreateMockResty(),
 	}
 
-	password := "1234"
-	username := "name"
-	cmd := &ssh.SSHCommand{
-		Path:   fmt.Sprintf("gerrit set-account --http-password \"%v\" \"%v\"", password, username),
-		Env:    []string{},
-		Stdin:  os.Stdin,
-		Stdout: os.Stdout,
-		Stderr: os.Stderr,
-	}
+	httpmock.RegisterResponder("PUT", "/accounts/name/password.http",
+		func(req *http.Request) (*http.Response, error) {
+			body, err := io.ReadAll(req.Body)
+			if err != nil {
+				return nil, err
+			}
 
-	sshCl.On("RunCommand", cmd).Return(nil, nil)
+			assert.JSONEq(t, `{"http_password": "1234"}`, string(body))
+
+			return httpmock.NewStringResponse(http.StatusOK, ""), nil
+		})
 
-	err := cl.ChangePassword(username, password)
+	err := cl.ChangePassword("name", "1234")
 	assert.NoError(t, err)
 }
 
 func TestClient_ChangePassword_Err(t *testing.T) {
-	sshCl := mock.SSHClientInterface{}
 	cl := Client{
-		sshClient: &sshCl,
+		resty: CreateMockResty(),
 	}
 
-	password := "1234"
-	username := "name"
-	cmd := &ssh.SSHCommand{
-		Path:   fmt.Sprintf("gerrit set-account --http-password \"%v\" \"%v\"", password, username),
-		Env:    []string{},
-		Stdin:  os.Stdin,
-		Stdout: os.Stdout,
-		Stderr: os.Stderr,
+	httpmock.RegisterResponder("PUT", "/accounts/name/password.http",
+		httpmock.NewStringResponder(http.StatusForbidden, "forbidden"))
+
+	err := cl.ChangePassword("name", "1234")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Changing name password failed")
+}
+
+func TestClient_AddSSHKey(t *testing.T) {
+	cl := Client{
+		resty: CreateMockResty(),
 	}
 
-	errTest := errors.New("test")
-	sshCl.On("RunCommand", cmd).Return(nil, errTest)
+	httpmock.RegisterResponder("POST", "/accounts/self/sshkeys",
+		func(req *http.Request) (*http.Response, error) {
+			body, err := io.ReadAll(req.Body)
+			if err != nil {
+				return nil, err
+			}
 
-	err := cl.ChangePassword(username, password)
+			assert.Equal(t, "ssh-rsa AAAA admin", string(body))
 
-	assert.Error(t, err)
+			return httpmock.NewStringResponse(http.StatusCreated, ""), nil
+		})
+
+	require.NoError(t, cl.AddSSHKey("self", "ssh-rsa AAAA admin"))
+
+	httpmock.RegisterResponder("POST", "/accounts/self/sshkeys",
+		httpmock.NewStringResponder(http.StatusBadRequest, "invalid key"))
+
+	err := cl.AddSSHKey("self", "invalid")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid key")
 }
 
 func TestClient_ReloadPlugin(t *testing.T) {
@@ -528,37 +558,3 @@ func TestNewClient(t *testing.T) {
 
 	assert.Equal(t, accept[0], "application/json")
 }
-
-func TestClient_InitAllProjects(t *testing.T) {
-	sshClient := mock.SSHClientInterface{}
-	gc := Client{
-		sshClient: &sshClient,
-	}
-	ps := platformMock.PlatformService{}
-
-	assert.NoError(t, os.MkdirAll("/tmp/test", 0o777))
-
-	fp, err := os.Create("/tmp/test/init-all-projects.sh")
-	assert.NoError(t, err)
-	_, err = fp.WriteString("demo")
-	assert.NoError(t, err)
-	assert.NoError(t, fp.Close())
-
-	fp, err = os.Create("/tmp/gerrit.config")
-	assert.NoError(t, err)
-	_, err = fp.WriteString("demo")
-	assert.NoError(t, err)
-	assert.NoError(t, fp.Close())
-
-	defer func() {
-		assert.NoError(t, os.RemoveAll("/tmp/test"))
-	}()
-
-	sshClient.On("RunCommand", testifyMock.Anything).
-		Return([]byte(`Continuous Integration Tools\t25`), nil)
-	ps.On("ExecInPod", "", "", testifyMock.Anything).
-		Return(nil, nil, nil)
-
-	err = gc.InitAllProjects(&gerritApi.Gerrit{}, &ps, "/tmp/test", "", "")
-	assert.NoError(t, err)
-}
@@ -3,7 +3,11 @@ package gerrit
 import (
 	"fmt"
 	"net/http"
+	"net/url"
+	"sort"
+	"strings"
 
+	"github.com/gosimple/slug"
 	"github.com/pkg/errors"
 )
 
@@ -34,20 +38,40 @@ func IsErrDoesNotExist(err error) bool {
 }
 
 type Group struct {
-	ID      string        `json:"id"`
-	GroupID int           `json:"group_id"`
-	Members []GroupMember `json:"members"`
+	ID          string        `json:"id"`
+	Name        string        `json:"name,omitempty"`
+	Description string        `json:"description,omitempty"`
+	GroupID     int           `json:"group_id"`
+	OwnerID     string        `json:"owner_id,omitempty"`
+	Options     GroupOptions  `json:"options"`
+	Members     []GroupMember `json:"members"`
+	Includes    []Group       `json:"includes,omitempty"`
+}
+
+type GroupOptions struct {
+	VisibleToAll bool `json:"visible_to_all,omitempty"`
+}
+
+// IsInternal reports whether the group is an internal Gerrit group.
+// System groups (e.g. global:Registered-Users) and external groups (e.g. ldap:) have a scheme prefix in the UUID.
+func (g *Group) IsInternal() bool {
+	return !strings.Contains(g.ID, ":")
+}
+
+func (g *Group) SlugifyName() string {
+	return slug.Make(g.Name)
 }
 
 type GroupMember struct {
-	Email    string `json:"email"`
-	Username string `json:"username"`
+	AccountID int    `json:"_account_id,omitempty"`
+	Email     string `json:"email"`
+	Username  string `json:"username"`
 }
 
 func (gc *Client) DeleteUserFromGroup(groupName, username string) error {
-	resp, err := gc.resty.R().
+	resp, err := gc.request().
 		SetHeader(acceptHeader, applicationJson).
-		Delete(fmt.Sprintf("groups/%s/members/%s", groupName, username))
+		Delete(fmt.Sprintf("groups/%s/members/%s", url.PathEscape(groupName), url.PathEscape(username)))
 	if err != nil {
 		return errors.Wrapf(err, "Unable to get Gerrit groups")
 	}
@@ -60,18 +84,18 @@ func (gc *Client) DeleteUserFromGroup(groupName, username string) error {
 }
 
 func (gc *Client) AddUserToGroup(groupName, username string) error {
-	resp, err := gc.resty.R().Put(fmt.Sprintf("groups/%s/members/%s", groupName, username))
+	resp, err := gc.request().Put(fmt.Sprintf("groups/%s/members/%s", url.PathEscape(groupName), url.PathEscape(username)))
 	return parseRestyResponse(resp, err)
 }
 
 func (gc *Client) UpdateGroup(groupID, description string, visibleToAll bool) error {
-	resp, err := gc.resty.R().
+	resp, err := gc.request().
 		SetHeader(acceptHeader, applicationJson).
 		SetHeader(contentType, applicationJson).
 		SetBody(map[string]interface{}{
 			"description": description,
 		}).
-		Put(fmt.Sprintf("groups/%s/description", groupID))
+		Put(fmt.Sprintf("groups/%s/description", url.PathEscape(groupID)))
 	if err != nil {
 		return errors.Wrap(err, "unable to update group")
 	}
@@ -80,13 +104,13 @@ func (gc *Client) UpdateGroup(groupID, description string, visibleToAll bool) er
 		return errors.Errorf("status: %s, body: %s", resp.Status(), resp.String())
 	}
 
-	resp, err = gc.resty.R().
+	resp, err = gc.request().
 		SetHeader(acceptHeader, applicationJson).
 		SetHeader(contentType, applicationJson).
 		SetBody(map[string]interface{}{
 			"visible_to_all": visibleToAll,
 		}).
-		Put(fmt.Sprintf("groups/%s/options", groupID))
+		Put(fmt.Sprintf("groups/%s/options", url.PathEscape(groupID)))
 
 	if err != nil {
 		return errors.Wrap(err, "unable to update group")
@@ -100,7 +124,7 @@ func (gc *Client) UpdateGroup(groupID, description string, visibleToAll bool) er
 }
 
 func (gc *Client) CreateGroup(name, description string, visibleToAll bool) (*Group, error) {
-	resp, err := gc.resty.R().
+	resp, err := gc.request().
 		SetHeader(acceptHeader, applicationJson).
 		SetHeader(contentType, applicationJson).
 		SetBody(map[string]interface{}{
@@ -128,3 +152,155 @@ func (gc *Client) CreateGroup(name, description string, visibleToAll bool) (*Gro
 
 	return &gr, nil
 }
+
+// GetGroup returns group info by the group name, UUID or legacy numeric ID.
+func (gc *Client) GetGroup(groupID string) (*Group, error) {
+	resp, err := gc.request().
+		SetHeader(acceptHeader, applicationJson).
+		Get(fmt.Sprintf("groups/%s", url.PathEscape(groupID)))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get group")
+	}
+
+	if resp.IsError() {
+		if resp.StatusCode() == http.StatusNotFound {
+			return nil, DoesNotExistError("group does not exist")
+		}
+
+		return nil, errors.Errorf("status: %s, body: %s", resp.Status(), resp.String())
+	}
+
+	var gr Group
+	if err := decodeGerritResponse(resp.String(), &gr); err != nil {
+		return nil, errors.Wrap(err, "unable to unmarshal group response")
+	}
+
+	return &gr, nil
+}
+
+// ResolveGroupUUID returns the UUID of the group referenced by name or UUID.
+// The UUIDs of the system and external groups, e.g. global:Registered-Users, are returned as they are.
+func ResolveGroupUUID(cl ClientInterface, group string) (string, error) {
+	if strings.Contains(group, ":") {
+		return group, nil
+	}
+
+	gr, err := cl.GetGroup(group)
+	if err != nil {
+		if IsErrDoesNotExist(err) {
+			return "", DoesNotExistError(fmt.Sprintf("group %s does not exist", group))
+		}
+
+		return "", errors.Wrapf(err, "unable to resolve group %s", group)
+	}
+
+	return gr.ID, nil
+}
+
+// SetGroupOwner makes ownerGroupID the owner group of groupID.
+func (gc *Client) SetGroupOwner(groupID, ownerGroupID string) error {
+	resp, err := gc.request().
+		SetHeader(acceptHeader, applicationJson).
+		SetHeader(contentType, applicationJson).
+		SetBody(map[string]interface{}{
+			"owner": ownerGroupID,
+		}).
+		Put(fmt.Sprintf("groups/%s/owner", url.PathEscape(groupID)))
+	if err = parseRestyResponse(resp, err); err != nil {
+		return errors.Wrap(err, "unable to set group owner")
+	}
+
+	return nil
+}
+
+// ListIncludedGroups returns the groups that are directly included into groupID.
+func (gc *Client) ListIncludedGroups(groupID string) ([]Group, error) {
+	resp, err := gc.request().
+		SetHeader(acceptHeader, applicationJson).
+		Get(fmt.Sprintf("groups/%s/groups/", url.PathEscape(groupID)))
+	if err = parseRestyResponse(resp, err); err != nil {
+		return nil, errors.Wrap(err, "unable to list included groups")
+	}
+
+	var groups []Group
+	if err := decodeGerritResponse(resp.String(), &groups); err != nil {
+		return nil, errors.Wrap(err, "unable to unmarshal included groups response")
+	}
+
+	return groups, nil
+}
+
+// ListGroups returns all groups visible to the user together with their direct members and subgroups.
+func (gc *Client) ListGroups() ([]Group, error) {
+	resp, err := gc.request().
+		SetHeader(acceptHeader, applicationJson).
+		SetMultiValueQueryParams(url.Values{"o": {"MEMBERS", "INCLUDES"}}).
+		Get("groups/")
+	if err = parseRestyResponse(resp, err); err != nil {
+		return nil, errors.Wrap(err, "unable to list groups")
+	}
+
+	var preGroups map[string]Group
+	if err := decodeGerritResponse(resp.String(), &preGroups); err != nil {
+		return nil, errors.Wrap(err, "unable to unmarshal groups response")
+	}
+
+	groups := make([]Group, 0, len(preGroups))
+
+	for k, v := range preGroups {
+		v.Name = k
+		groups = append(groups, v)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Name < groups[j].Name
+	})
+
+	return groups, nil
+}
+
+// ListGroupMembers returns the accounts that are direct members of groupID.
+func (gc *Client) ListGroupMembers(groupID string) ([]GroupMember, error) {
+	resp, err := gc.request().
+		SetHeader(acceptHeader, applicationJson).
+		Get(fmt.Sprintf("groups/%s/members/", url.PathEscape(groupID)))
+	if err = parseRestyResponse(resp, err); err != nil {
+		return nil, errors.Wrap(err, "unable to list group members")
+	}
+
+	var members []GroupMember
+	if err := decodeGerritResponse(resp.String(), &members); err != nil {
+		return nil, errors.Wrap(err, "unable to unmarshal group members response")
+	}
+
+	return members, nil
+}
+
+func (gc *Client) AddIncludedGroups(groupID string, groups []string) error {
+	resp, err := gc.request().
+		SetHeader(acceptHeader, applicationJson).
+		SetHeader(contentType, applicationJson).
+		SetBody(map[string]interface{}{
+			"groups": groups,
+		}).
+		Post(fmt.Sprintf("groups/%s/groups.add", url.PathEscape(groupID)))
+	if err = parseRestyResponse(resp, err); err != nil {
+		return errors.Wrap(err, "unable to add included groups")
+	}
+
+	return nil
+}
+
+func (gc *Client) DeleteIncludedGroups(groupID string, groups []string) error {
+	resp, err := gc.request().
+		SetHeader(contentType, applicationJson).
+		SetBody(map[string]interface{}{
+			"groups": groups,
+		}).
+		Post(fmt.Sprintf("groups/%s/groups.delete", url.PathEscape(groupID)))
+	if err = parseRestyResponse(resp, err); err != nil {
+		return errors.Wrap(err, "unable to delete included groups")
+	}
+
+	return nil
+}
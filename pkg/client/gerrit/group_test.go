@@ -96,6 +96,19 @@ func TestClient_AddUserToGroup(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestClient_GroupMembers_Escaped(t *testing.T) {
+	restyClient := CreateMockResty()
+	cl := Client{
+		resty: restyClient,
+	}
+
+	httpmock.RegisterResponder("PUT", "/groups/team%2Fdevs/members/john%2Fdoe", httpmock.NewStringResponder(200, ""))
+	httpmock.RegisterResponder("DELETE", "/groups/team%2Fdevs/members/john%2Fdoe", httpmock.NewStringResponder(204, ""))
+
+	require.NoError(t, cl.AddUserToGroup("team/devs", "john/doe"))
+	require.NoError(t, cl.DeleteUserFromGroup("team/devs", "john/doe"))
+}
+
 func TestClient_DeleteUserFromGroup(t *testing.T) {
 	restyClient := CreateMockResty()
 	cl := Client{
@@ -302,3 +315,193 @@ func TestClient_CreateGroup_RespErr(t *testing.T) {
 
 	assert.Equal(t, errors.Errorf("status: %s, body: %s", "404", "").Error(), err.Error())
 }
+
+func TestClient_GetGroup(t *testing.T) {
+	restyClient := CreateMockResty()
+	cl := Client{
+		resty: restyClient,
+	}
+
+	httpmock.RegisterResponder("GET", "/groups/"+groupName,
+		httpmock.NewStringResponder(200, `)]}'
+{"id": "6a1e70e1a88782771a91808c8af9bbb7a9871389", "name": "gr1", "group_id": 3, "owner_id": "owner-uuid"}`))
+
+	gr, err := cl.GetGroup(groupName)
+	require.NoError(t, err)
+
+	assert.Equal(t, "6a1e70e1a88782771a91808c8af9bbb7a9871389", gr.ID)
+	assert.Equal(t, groupName, gr.Name)
+	assert.Equal(t, 3, gr.GroupID)
+	assert.Equal(t, "owner-uuid", gr.OwnerID)
+}
+
+func TestClient_GetGroup_NotFound(t *testing.T) {
+	restyClient := CreateMockResty()
+	cl := Client{
+		resty: restyClient,
+	}
+
+	httpmock.RegisterResponder("GET", "/groups/"+groupName, httpmock.NewStringResponder(404, "Not found"))
+
+	_, err := cl.GetGroup(groupName)
+
+	assert.Error(t, err)
+	assert.True(t, IsErrDoesNotExist(err))
+}
+
+func TestResolveGroupUUID(t *testing.T) {
+	restyClient := CreateMockResty()
+	cl := Client{
+		resty: restyClient,
+	}
+
+	httpmock.RegisterResponder("GET", "/groups/"+groupName,
+		httpmock.NewStringResponder(200, `)]}'
+{"id": "6a1e70e1a88782771a91808c8af9bbb7a9871389", "name": "gr1"}`))
+	httpmock.RegisterResponder("GET", "/groups/missing", httpmock.NewStringResponder(404, "Not found"))
+
+	id, err := ResolveGroupUUID(&cl, groupName)
+	require.NoError(t, err)
+	assert.Equal(t, "6a1e70e1a88782771a91808c8af9bbb7a9871389", id)
+
+	id, err = ResolveGroupUUID(&cl, "global:Registered-Users")
+	require.NoError(t, err)
+	assert.Equal(t, "global:Registered-Users", id)
+
+	_, err = ResolveGroupUUID(&cl, "missing")
+	assert.True(t, IsErrDoesNotExist(err))
+	assert.EqualError(t, err, "group missing does not exist")
+}
+
+func TestClient_SetGroupOwner(t *testing.T) {
+	restyClient := CreateMockResty()
+	cl := Client{
+		resty: restyClient,
+	}
+
+	httpmock.RegisterResponder("PUT", "/groups/"+gid+"/owner", httpmock.NewStringResponder(200, ""))
+
+	assert.NoError(t, cl.SetGroupOwner(gid, "owner"))
+}
+
+func TestClient_SetGroupOwner_RespErr(t *testing.T) {
+	restyClient := CreateMockResty()
+	cl := Client{
+		resty: restyClient,
+	}
+
+	httpmock.RegisterResponder("PUT", "/groups/"+gid+"/owner", httpmock.NewStringResponder(422, "group not found"))
+
+	err := cl.SetGroupOwner(gid, "owner")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to set group owner")
+}
+
+func TestClient_ListIncludedGroups(t *testing.T) {
+	restyClient := CreateMockResty()
+	cl := Client{
+		resty: restyClient,
+	}
+
+	httpmock.RegisterResponder("GET", "/groups/"+gid+"/groups/",
+		httpmock.NewStringResponder(200, `)]}'
+[{"id": "sub1", "name": "subgroup1"}, {"id": "sub2", "name": "subgroup2"}]`))
+
+	groups, err := cl.ListIncludedGroups(gid)
+	require.NoError(t, err)
+
+	require.Len(t, groups, 2)
+	assert.Equal(t, "sub1", groups[0].ID)
+	assert.Equal(t, "subgroup2", groups[1].Name)
+}
+
+func TestClient_ListGroups(t *testing.T) {
+	restyClient := CreateMockResty()
+	cl := Client{
+		resty: restyClient,
+	}
+
+	httpmock.RegisterResponder("GET", "/groups/",
+		httpmock.NewStringResponder(200, `)]}'
+{
+  "Registered Users": {"id": "global:Registered-Users"},
+  "Developers": {
+    "id": "uuid-devs",
+    "owner_id": "uuid-admins",
+    "options": {"visible_to_all": true},
+    "members": [{"_account_id": 1000096, "username": "john"}],
+    "includes": [{"id": "uuid-contractors", "name": "Contractors"}]
+  }
+}`))
+
+	groups, err := cl.ListGroups()
+	require.NoError(t, err)
+
+	require.Len(t, groups, 2)
+	assert.Equal(t, "Developers", groups[0].Name)
+	assert.True(t, groups[0].IsInternal())
+	assert.True(t, groups[0].Options.VisibleToAll)
+	assert.Equal(t, "john", groups[0].Members[0].Username)
+	assert.Equal(t, "uuid-contractors", groups[0].Includes[0].ID)
+	assert.Equal(t, "Registered Users", groups[1].Name)
+	assert.False(t, groups[1].IsInternal())
+}
+
+func TestClient_ListGroupMembers(t *testing.T) {
+	restyClient := CreateMockResty()
+	cl := Client{
+		resty: restyClient,
+	}
+
+	httpmock.RegisterResponder("GET", "/groups/"+gid+"/members/",
+		httpmock.NewStringResponder(200, `)]}'
+[{"_account_id": 1000096, "username": "john", "email": "john@example.com"}]`))
+
+	members, err := cl.ListGroupMembers(gid)
+	require.NoError(t, err)
+
+	require.Len(t, members, 1)
+	assert.Equal(t, 1000096, members[0].AccountID)
+	assert.Equal(t, "john", members[0].Username)
+}
+
+func TestClient_ListGroupMembers_RespErr(t *testing.T) {
+	restyClient := CreateMockResty()
+	cl := Client{
+		resty: restyClient,
+	}
+
+	httpmock.RegisterResponder("GET", "/groups/"+gid+"/members/",
+		httpmock.NewStringResponder(404, "Not found"))
+
+	_, err := cl.ListGroupMembers(gid)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to list group members")
+}
+
+func TestClient_AddIncludedGroups(t *testing.T) {
+	restyClient := CreateMockResty()
+	cl := Client{
+		resty: restyClient,
+	}
+
+	httpmock.RegisterResponder("POST", "/groups/"+gid+"/groups.add", httpmock.NewStringResponder(200, ""))
+
+	assert.NoError(t, cl.AddIncludedGroups(gid, []string{"sub1"}))
+}
+
+func TestClient_DeleteIncludedGroups(t *testing.T) {
+	restyClient := CreateMockResty()
+	cl := Client{
+		resty: restyClient,
+	}
+
+	httpmock.RegisterResponder("POST", "/groups/"+gid+"/groups.delete", httpmock.NewStringResponder(204, ""))
+
+	assert.NoError(t, cl.DeleteIncludedGroups(gid, []string{"sub1"}))
+
+	httpmock.RegisterResponder("POST", "/groups/"+gid+"/groups.delete", httpmock.NewStringResponder(500, ""))
+
+	assert.Error(t, cl.DeleteIncludedGroups(gid, []string{"sub1"}))
+}
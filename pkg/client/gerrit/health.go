@@ -0,0 +1,93 @@
+package gerrit
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/epam/edp-gerrit-operator/v2/pkg/client/ssh"
+)
+
+// replicationQueuePrefix is the prefix of the work queues of the replication plugin, e.g. "ReplicateTo-origin".
+const replicationQueuePrefix = "ReplicateTo-"
+
+var queueTasksRe = regexp.MustCompile(`^\s*(\d+) tasks`)
+
+// SSHVersion returns the Gerrit version reported by the "gerrit version" SSH command.
+func (gc *Client) SSHVersion() (string, error) {
+	out, err := gc.runSSHCommand("gerrit version")
+	if err != nil {
+		return "", errors.Wrap(err, "unable to get version with SSH")
+	}
+
+	return strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(string(out)), "gerrit version")), nil
+}
+
+// CheckChangeIndex queries the change index, the query fails if the index is not available.
+func (gc *Client) CheckChangeIndex() error {
+	rsp, err := gc.request().SetHeader(acceptHeader, applicationJson).
+		SetQueryParams(map[string]string{"q": "status:open", "n": "1"}).
+		Get("changes/")
+	if err = parseRestyResponse(rsp, err); err != nil {
+		return errors.Wrap(err, "unable to query changes")
+	}
+
+	return nil
+}
+
+// ReplicationQueueSize returns the number of the tasks in the replication queues.
+func (gc *Client) ReplicationQueueSize() (int, error) {
+	out, err := gc.runSSHCommand("gerrit show-queue --wide --by-queue")
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to show queue")
+	}
+
+	size := 0
+	queue := ""
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if name, ok := strings.CutPrefix(line, "Queue: "); ok {
+			queue = strings.TrimSpace(name)
+
+			continue
+		}
+
+		m := queueTasksRe.FindStringSubmatch(line)
+		if m == nil || !strings.HasPrefix(queue, replicationQueuePrefix) {
+			continue
+		}
+
+		tasks, _ := strconv.Atoi(m[1])
+		size += tasks
+	}
+
+	return size, nil
+}
+
+func (gc *Client) runSSHCommand(command string) ([]byte, error) {
+	if gc.sshClient == nil {
+		return nil, errors.New("ssh client is not initialized")
+	}
+
+	out, err := gc.sshClient.RunCommand(&ssh.SSHCommand{
+		Path:    command,
+		Env:     []string{},
+		Context: gc.ctx,
+		Stdin:   os.Stdin,
+		Stdout:  os.Stdout,
+		Stderr:  os.Stderr,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to run %q", command)
+	}
+
+	return out, nil
+}
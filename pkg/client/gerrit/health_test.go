@@ -0,0 +1,97 @@
+package gerrit
+
+import (
+	"os"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mock "github.com/epam/edp-gerrit-operator/v2/mock/ssh"
+	"github.com/epam/edp-gerrit-operator/v2/pkg/client/ssh"
+)
+
+func sshCommand(path string) *ssh.SSHCommand {
+	return &ssh.SSHCommand{
+		Path:   path,
+		Env:    []string{},
+		Stdin:  os.Stdin,
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+	}
+}
+
+func TestClient_SSHVersion(t *testing.T) {
+	sshCl := mock.SSHClientInterface{}
+	cl := Client{
+		sshClient: &sshCl,
+	}
+
+	sshCl.On("RunCommand", sshCommand("gerrit version")).Return([]byte("gerrit version 3.9.1\n"), nil)
+
+	version, err := cl.SSHVersion()
+	require.NoError(t, err)
+	assert.Equal(t, "3.9.1", version)
+}
+
+func TestClient_SSHVersion_NoClient(t *testing.T) {
+	cl := Client{}
+
+	_, err := cl.SSHVersion()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ssh client is not initialized")
+}
+
+func TestClient_ReplicationQueueSize(t *testing.T) {
+	sshCl := mock.SSHClientInterface{}
+	cl := Client{
+		sshClient: &sshCl,
+	}
+
+	out := `Queue: SSH-Interactive-Worker
+Task     State        StartTime         Command
+------------------------------------------------------------------------------
+a1b2c3d4              14:10:11.000      gerrit show-queue --wide --by-queue
+------------------------------------------------------------------------------
+  1 tasks, 4 worker threads
+
+Queue: ReplicateTo-origin
+Task     State        StartTime         Command
+------------------------------------------------------------------------------
+2e4c7a01              14:10:11.000      push ssh://git@github.com/org/repo.git [repo]
+3e4c7a01              14:10:12.000      push ssh://git@github.com/org/other.git [other]
+------------------------------------------------------------------------------
+  2 tasks, 1 worker threads
+
+Queue: ReplicateTo-mirror
+------------------------------------------------------------------------------
+  3 tasks, 1 worker threads
+`
+
+	sshCl.On("RunCommand", sshCommand("gerrit show-queue --wide --by-queue")).Return([]byte(out), nil)
+
+	size, err := cl.ReplicationQueueSize()
+	require.NoError(t, err)
+	assert.Equal(t, 5, size)
+}
+
+func TestClient_CheckChangeIndex(t *testing.T) {
+	restyClient := CreateMockResty()
+	cl := Client{
+		resty: restyClient,
+	}
+
+	httpmock.RegisterResponder("GET", "/changes/?n=1&q=status%3Aopen",
+		httpmock.NewStringResponder(200, `)]}'
+[]`))
+
+	require.NoError(t, cl.CheckChangeIndex())
+
+	httpmock.RegisterResponder("GET", "/changes/?n=1&q=status%3Aopen",
+		httpmock.NewStringResponder(500, "index is not ready"))
+
+	err := cl.CheckChangeIndex()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "index is not ready")
+}
@@ -4,7 +4,6 @@ import (
 	"gopkg.in/resty.v1"
 
 	gerritApi "github.com/epam/edp-gerrit-operator/v2/api/v1"
-	"github.com/epam/edp-gerrit-operator/v2/pkg/service/platform"
 )
 
 type ClientInterface interface {
@@ -13,8 +12,21 @@ type ClientInterface interface {
 	DeleteAccessRights(projectName string, permissions []AccessInfo) error
 	UpdateAccessRights(projectName string, permissions []AccessInfo) error
 	AddAccessRights(projectName string, permissions []AccessInfo) error
+	ReviewAccessRights(projectName, parent string, add, remove []AccessInfo) (*Change, error)
+	GetAccessRights(projectName string) (*ProjectAccess, error)
+	CheckAccess(projectName string, check AccessCheck) (*AccessCheckInfo, error)
 	CreateGroup(name, description string, visibleToAll bool) (*Group, error)
 	UpdateGroup(groupID, description string, visibleToAll bool) error
+	GetGroup(groupID string) (*Group, error)
+	ListGroups() ([]Group, error)
+	SetGroupOwner(groupID, ownerGroupID string) error
+	ListIncludedGroups(groupID string) ([]Group, error)
+	AddIncludedGroups(groupID string, groups []string) error
+	DeleteIncludedGroups(groupID string, groups []string) error
+	ListGroupMembers(groupID string) ([]GroupMember, error)
+	QueryAccounts(query string) ([]Account, error)
+	ResolveAccount(account string) (*Account, error)
+	DeactivateAccount(username string) error
 	AddUserToGroup(groupName, username string) error
 	DeleteUserFromGroup(groupName, username string) error
 	CreateProject(prj *Project) error
@@ -24,16 +36,26 @@ type ClientInterface interface {
 	ListProjects(_type string) ([]Project, error)
 	ListProjectBranches(projectName string) ([]Branch, error)
 	ReloadPlugin(plugin string) error
+	GetPlugin(pluginID string) (*Plugin, error)
+	ListPlugins() ([]Plugin, error)
+	InstallPlugin(pluginID, jarURL string) error
+	UploadPlugin(pluginID string, jar []byte) error
+	EnablePlugin(pluginID string) error
+	DisablePlugin(pluginID string) error
+	RestartPlugin(pluginID string) error
 	ChangeAbandon(changeID string) error
 	ChangeGet(changeID string) (*Change, error)
 	InitNewRestClient(instance *gerritApi.Gerrit, url string, user string, password string) error
 	CheckCredentials() (int, error)
-	InitAdminUser(instance *gerritApi.Gerrit, platformService platform.PlatformService, GerritScriptsPath string, podName string, gerritAdminPublicKey string) (*gerritApi.Gerrit, error)
+	GetVersion() (string, error)
+	SSHVersion() (string, error)
+	CheckChangeIndex() error
+	ReplicationQueueSize() (int, error)
 	InitNewSshClient(userName string, privateKey []byte, host string, port int32) error
 	CheckGroup(groupName string) (*int, error)
-	InitAllProjects(instance *gerritApi.Gerrit, platformService platform.PlatformService, GerritScriptsPath string,
-		podName string, gerritAdminPublicKey string) error
+	UpdateMetaConfig(projectName, message string, files map[string]MetaConfigFile) error
 	CreateUser(username string, password string, fullName string, publicKey string) error
 	ChangePassword(username string, password string) error
+	AddSSHKey(username, publicKey string) error
 	AddUserToGroups(userName string, groupNames []string) error
 }
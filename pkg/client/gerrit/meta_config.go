@@ -0,0 +1,154 @@
+package gerrit
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/pkg/errors"
+)
+
+const (
+	metaConfigRef    = "refs/meta/config"
+	metaConfigBranch = "refs/heads/meta/config"
+	metaConfigRemote = "origin"
+)
+
+// MetaConfigFile returns the new content of a refs/meta/config file from its current content,
+// the current content of a new file is empty.
+type MetaConfigFile func(current string) string
+
+// UpdateMetaConfig commits the files into refs/meta/config of the project and pushes the commit through
+// the Gerrit receive path, so Gerrit validates the configuration and reloads it right away.
+// Nothing is pushed if the files are not changed.
+func (gc *Client) UpdateMetaConfig(projectName, message string, files map[string]MetaConfigFile) error {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		return errors.Wrap(err, "unable to init repository")
+	}
+
+	if _, err = repo.CreateRemote(&config.RemoteConfig{
+		Name: metaConfigRemote,
+		URLs: []string{fmt.Sprintf("%s/%s", strings.TrimSuffix(gc.resty.HostURL, "/"), projectName)},
+	}); err != nil {
+		return errors.Wrap(err, "unable to create remote")
+	}
+
+	auth := gc.gitAuth()
+
+	if err = repo.FetchContext(gc.context(), &git.FetchOptions{
+		RemoteName: metaConfigRemote,
+		RefSpecs:   []config.RefSpec{config.RefSpec("+" + metaConfigRef + ":" + metaConfigBranch)},
+		Auth:       auth,
+	}); err != nil {
+		return errors.Wrapf(err, "unable to fetch %s of project %s", metaConfigRef, projectName)
+	}
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		return errors.Wrap(err, "unable to get worktree")
+	}
+
+	if err = wt.Checkout(&git.CheckoutOptions{Branch: plumbing.ReferenceName(metaConfigBranch)}); err != nil {
+		return errors.Wrapf(err, "unable to checkout %s of project %s", metaConfigRef, projectName)
+	}
+
+	for name, update := range files {
+		if err = updateWorktreeFile(wt, name, update); err != nil {
+			return err
+		}
+	}
+
+	status, err := wt.Status()
+	if err != nil {
+		return errors.Wrap(err, "unable to get worktree status")
+	}
+
+	if status.IsClean() {
+		return nil
+	}
+
+	username := ""
+	if gc.resty.UserInfo != nil {
+		username = gc.resty.UserInfo.Username
+	}
+
+	if _, err = wt.Commit(message, &git.CommitOptions{
+		Author: &object.Signature{Name: username, Email: username + "@gerrit-operator", When: time.Now()},
+	}); err != nil {
+		return errors.Wrap(err, "unable to commit")
+	}
+
+	if err = repo.PushContext(gc.context(), &git.PushOptions{
+		RemoteName: metaConfigRemote,
+		RefSpecs:   []config.RefSpec{config.RefSpec(metaConfigBranch + ":" + metaConfigRef)},
+		Auth:       auth,
+	}); err != nil {
+		return errors.Wrapf(err, "unable to push %s of project %s", metaConfigRef, projectName)
+	}
+
+	return nil
+}
+
+func updateWorktreeFile(wt *git.Worktree, name string, update MetaConfigFile) error {
+	current := ""
+
+	f, err := wt.Filesystem.Open(name)
+	if err == nil {
+		content, readErr := io.ReadAll(f)
+		_ = f.Close()
+
+		if readErr != nil {
+			return errors.Wrapf(readErr, "unable to read %s", name)
+		}
+
+		current = string(content)
+	}
+
+	f, err = wt.Filesystem.Create(name)
+	if err != nil {
+		return errors.Wrapf(err, "unable to create %s", name)
+	}
+
+	if _, err = f.Write([]byte(update(current))); err != nil {
+		_ = f.Close()
+
+		return errors.Wrapf(err, "unable to write %s", name)
+	}
+
+	if err = f.Close(); err != nil {
+		return errors.Wrapf(err, "unable to close %s", name)
+	}
+
+	if _, err = wt.Add(name); err != nil {
+		return errors.Wrapf(err, "unable to add %s", name)
+	}
+
+	return nil
+}
+
+func (gc *Client) gitAuth() transport.AuthMethod {
+	if gc.resty.UserInfo == nil {
+		return nil
+	}
+
+	return &http.BasicAuth{Username: gc.resty.UserInfo.Username, Password: gc.resty.UserInfo.Password}
+}
+
+func (gc *Client) context() context.Context {
+	if gc.ctx == nil {
+		return context.Background()
+	}
+
+	return gc.ctx
+}
@@ -0,0 +1,108 @@
+package gerrit
+
+import (
+	"io"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/resty.v1"
+)
+
+func TestClient_UpdateMetaConfig(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	remote := filepath.Join(dir, "All-Projects")
+
+	_, err := git.PlainInit(remote, true)
+	require.NoError(t, err)
+
+	seed, err := git.PlainInit(filepath.Join(dir, "seed"), false)
+	require.NoError(t, err)
+
+	wt, err := seed.Worktree()
+	require.NoError(t, err)
+
+	f, err := wt.Filesystem.Create("groups")
+	require.NoError(t, err)
+	_, err = f.Write([]byte("# UUID\tGroup Name\nadmins\tAdministrators\n"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	_, err = wt.Add("groups")
+	require.NoError(t, err)
+
+	_, err = wt.Commit("init", &git.CommitOptions{Author: &object.Signature{Name: "test", When: time.Now()}})
+	require.NoError(t, err)
+
+	_, err = seed.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{remote}})
+	require.NoError(t, err)
+	require.NoError(t, seed.Push(&git.PushOptions{RefSpecs: []config.RefSpec{"refs/heads/master:refs/meta/config"}}))
+
+	gc := Client{resty: resty.New().SetHostURL("file://" + dir)}
+
+	files := map[string]MetaConfigFile{
+		"project.config": func(string) string { return "[receive]\n\trequireChangeId = true\n" },
+		"groups": func(current string) string {
+			return current + "dev\tDevelopers\n"
+		},
+	}
+
+	require.NoError(t, gc.UpdateMetaConfig("All-Projects", "Update config", files))
+
+	repo, err := git.PlainOpen(remote)
+	require.NoError(t, err)
+
+	ref, err := repo.Reference(plumbing.ReferenceName("refs/meta/config"), true)
+	require.NoError(t, err)
+
+	commit, err := repo.CommitObject(ref.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, "Update config", commit.Message)
+
+	assert.Equal(t, "# UUID\tGroup Name\nadmins\tAdministrators\ndev\tDevelopers\n", readCommitFile(t, commit, "groups"))
+	assert.Equal(t, "[receive]\n\trequireChangeId = true\n", readCommitFile(t, commit, "project.config"))
+
+	// the unchanged files are not pushed again
+	require.NoError(t, gc.UpdateMetaConfig("All-Projects", "Update config again", map[string]MetaConfigFile{
+		"project.config": func(current string) string { return current },
+	}))
+
+	again, err := repo.Reference(plumbing.ReferenceName("refs/meta/config"), true)
+	require.NoError(t, err)
+	assert.Equal(t, ref.Hash(), again.Hash())
+}
+
+func TestClient_UpdateMetaConfig_FetchErr(t *testing.T) {
+	t.Parallel()
+
+	gc := Client{resty: resty.New().SetHostURL("file://" + t.TempDir())}
+
+	err := gc.UpdateMetaConfig("All-Projects", "Update config", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to fetch refs/meta/config of project All-Projects")
+}
+
+func readCommitFile(t *testing.T, commit *object.Commit, name string) string {
+	t.Helper()
+
+	file, err := commit.File(name)
+	require.NoError(t, err)
+
+	r, err := file.Reader()
+	require.NoError(t, err)
+
+	defer r.Close()
+
+	content, err := io.ReadAll(r)
+	require.NoError(t, err)
+
+	return string(content)
+}
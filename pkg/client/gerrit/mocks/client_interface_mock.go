@@ -7,8 +7,6 @@ import (
 	gerrit "github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit"
 	mock "github.com/stretchr/testify/mock"
 
-	platform "github.com/epam/edp-gerrit-operator/v2/pkg/service/platform"
-
 	resty "gopkg.in/resty.v1"
 )
 
@@ -31,6 +29,34 @@ func (_m *ClientInterface) AddAccessRights(projectName string, permissions []ger
 	return r0
 }
 
+// AddIncludedGroups provides a mock function with given fields: groupID, groups
+func (_m *ClientInterface) AddIncludedGroups(groupID string, groups []string) error {
+	ret := _m.Called(groupID, groups)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []string) error); ok {
+		r0 = rf(groupID, groups)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddSSHKey provides a mock function with given fields: username, publicKey
+func (_m *ClientInterface) AddSSHKey(username string, publicKey string) error {
+	ret := _m.Called(username, publicKey)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(username, publicKey)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // AddUserToGroup provides a mock function with given fields: groupName, username
 func (_m *ClientInterface) AddUserToGroup(groupName string, username string) error {
 	ret := _m.Called(groupName, username)
@@ -110,6 +136,29 @@ func (_m *ClientInterface) ChangePassword(username string, password string) erro
 	return r0
 }
 
+// CheckAccess provides a mock function with given fields: projectName, check
+func (_m *ClientInterface) CheckAccess(projectName string, check gerrit.AccessCheck) (*gerrit.AccessCheckInfo, error) {
+	ret := _m.Called(projectName, check)
+
+	var r0 *gerrit.AccessCheckInfo
+	if rf, ok := ret.Get(0).(func(string, gerrit.AccessCheck) *gerrit.AccessCheckInfo); ok {
+		r0 = rf(projectName, check)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gerrit.AccessCheckInfo)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, gerrit.AccessCheck) error); ok {
+		r1 = rf(projectName, check)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CheckCredentials provides a mock function with given fields:
 func (_m *ClientInterface) CheckCredentials() (int, error) {
 	ret := _m.Called()
@@ -205,6 +254,20 @@ func (_m *ClientInterface) CreateUser(username string, password string, fullName
 	return r0
 }
 
+// DeactivateAccount provides a mock function with given fields: username
+func (_m *ClientInterface) DeactivateAccount(username string) error {
+	ret := _m.Called(username)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(username)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteAccessRights provides a mock function with given fields: projectName, permissions
 func (_m *ClientInterface) DeleteAccessRights(projectName string, permissions []gerrit.AccessInfo) error {
 	ret := _m.Called(projectName, permissions)
@@ -219,6 +282,20 @@ func (_m *ClientInterface) DeleteAccessRights(projectName string, permissions []
 	return r0
 }
 
+// DeleteIncludedGroups provides a mock function with given fields: groupID, groups
+func (_m *ClientInterface) DeleteIncludedGroups(groupID string, groups []string) error {
+	ret := _m.Called(groupID, groups)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []string) error); ok {
+		r0 = rf(groupID, groups)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteProject provides a mock function with given fields: name
 func (_m *ClientInterface) DeleteProject(name string) error {
 	ret := _m.Called(name)
@@ -247,22 +324,73 @@ func (_m *ClientInterface) DeleteUserFromGroup(groupName string, username string
 	return r0
 }
 
-// GetProject provides a mock function with given fields: name
-func (_m *ClientInterface) GetProject(name string) (*gerrit.Project, error) {
-	ret := _m.Called(name)
+// DisablePlugin provides a mock function with given fields: pluginID
+func (_m *ClientInterface) DisablePlugin(pluginID string) error {
+	ret := _m.Called(pluginID)
 
-	var r0 *gerrit.Project
-	if rf, ok := ret.Get(0).(func(string) *gerrit.Project); ok {
-		r0 = rf(name)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(pluginID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnablePlugin provides a mock function with given fields: pluginID
+func (_m *ClientInterface) EnablePlugin(pluginID string) error {
+	ret := _m.Called(pluginID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(pluginID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAccessRights provides a mock function with given fields: projectName
+func (_m *ClientInterface) GetAccessRights(projectName string) (*gerrit.ProjectAccess, error) {
+	ret := _m.Called(projectName)
+
+	var r0 *gerrit.ProjectAccess
+	if rf, ok := ret.Get(0).(func(string) *gerrit.ProjectAccess); ok {
+		r0 = rf(projectName)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gerrit.Project)
+			r0 = ret.Get(0).(*gerrit.ProjectAccess)
 		}
 	}
 
 	var r1 error
 	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(name)
+		r1 = rf(projectName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGroup provides a mock function with given fields: groupID
+func (_m *ClientInterface) GetGroup(groupID string) (*gerrit.Group, error) {
+	ret := _m.Called(groupID)
+
+	var r0 *gerrit.Group
+	if rf, ok := ret.Get(0).(func(string) *gerrit.Group); ok {
+		r0 = rf(groupID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gerrit.Group)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(groupID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -270,22 +398,22 @@ func (_m *ClientInterface) GetProject(name string) (*gerrit.Project, error) {
 	return r0, r1
 }
 
-// InitAdminUser provides a mock function with given fields: instance, _a1, GerritScriptsPath, podName, gerritAdminPublicKey
-func (_m *ClientInterface) InitAdminUser(instance *v1.Gerrit, _a1 platform.PlatformService, GerritScriptsPath string, podName string, gerritAdminPublicKey string) (*v1.Gerrit, error) {
-	ret := _m.Called(instance, _a1, GerritScriptsPath, podName, gerritAdminPublicKey)
+// GetPlugin provides a mock function with given fields: pluginID
+func (_m *ClientInterface) GetPlugin(pluginID string) (*gerrit.Plugin, error) {
+	ret := _m.Called(pluginID)
 
-	var r0 *v1.Gerrit
-	if rf, ok := ret.Get(0).(func(*v1.Gerrit, platform.PlatformService, string, string, string) *v1.Gerrit); ok {
-		r0 = rf(instance, _a1, GerritScriptsPath, podName, gerritAdminPublicKey)
+	var r0 *gerrit.Plugin
+	if rf, ok := ret.Get(0).(func(string) *gerrit.Plugin); ok {
+		r0 = rf(pluginID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*v1.Gerrit)
+			r0 = ret.Get(0).(*gerrit.Plugin)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*v1.Gerrit, platform.PlatformService, string, string, string) error); ok {
-		r1 = rf(instance, _a1, GerritScriptsPath, podName, gerritAdminPublicKey)
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(pluginID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -293,13 +421,55 @@ func (_m *ClientInterface) InitAdminUser(instance *v1.Gerrit, _a1 platform.Platf
 	return r0, r1
 }
 
-// InitAllProjects provides a mock function with given fields: instance, _a1, GerritScriptsPath, podName, gerritAdminPublicKey
-func (_m *ClientInterface) InitAllProjects(instance *v1.Gerrit, _a1 platform.PlatformService, GerritScriptsPath string, podName string, gerritAdminPublicKey string) error {
-	ret := _m.Called(instance, _a1, GerritScriptsPath, podName, gerritAdminPublicKey)
+// GetVersion provides a mock function with given fields:
+func (_m *ClientInterface) GetVersion() (string, error) {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SSHVersion provides a mock function with given fields:
+func (_m *ClientInterface) SSHVersion() (string, error) {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CheckChangeIndex provides a mock function with given fields:
+func (_m *ClientInterface) CheckChangeIndex() error {
+	ret := _m.Called()
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*v1.Gerrit, platform.PlatformService, string, string, string) error); ok {
-		r0 = rf(instance, _a1, GerritScriptsPath, podName, gerritAdminPublicKey)
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -307,6 +477,73 @@ func (_m *ClientInterface) InitAllProjects(instance *v1.Gerrit, _a1 platform.Pla
 	return r0
 }
 
+// ReplicationQueueSize provides a mock function with given fields:
+func (_m *ClientInterface) ReplicationQueueSize() (int, error) {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListPlugins provides a mock function with given fields:
+func (_m *ClientInterface) ListPlugins() ([]gerrit.Plugin, error) {
+	ret := _m.Called()
+
+	var r0 []gerrit.Plugin
+	if rf, ok := ret.Get(0).(func() []gerrit.Plugin); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]gerrit.Plugin)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProject provides a mock function with given fields: name
+func (_m *ClientInterface) GetProject(name string) (*gerrit.Project, error) {
+	ret := _m.Called(name)
+
+	var r0 *gerrit.Project
+	if rf, ok := ret.Get(0).(func(string) *gerrit.Project); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gerrit.Project)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // InitNewRestClient provides a mock function with given fields: instance, url, user, password
 func (_m *ClientInterface) InitNewRestClient(instance *v1.Gerrit, url string, user string, password string) error {
 	ret := _m.Called(instance, url, user, password)
@@ -335,6 +572,89 @@ func (_m *ClientInterface) InitNewSshClient(userName string, privateKey []byte,
 	return r0
 }
 
+// InstallPlugin provides a mock function with given fields: pluginID, jarURL
+func (_m *ClientInterface) InstallPlugin(pluginID string, jarURL string) error {
+	ret := _m.Called(pluginID, jarURL)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(pluginID, jarURL)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListGroupMembers provides a mock function with given fields: groupID
+func (_m *ClientInterface) ListGroupMembers(groupID string) ([]gerrit.GroupMember, error) {
+	ret := _m.Called(groupID)
+
+	var r0 []gerrit.GroupMember
+	if rf, ok := ret.Get(0).(func(string) []gerrit.GroupMember); ok {
+		r0 = rf(groupID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]gerrit.GroupMember)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(groupID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListGroups provides a mock function with given fields:
+func (_m *ClientInterface) ListGroups() ([]gerrit.Group, error) {
+	ret := _m.Called()
+
+	var r0 []gerrit.Group
+	if rf, ok := ret.Get(0).(func() []gerrit.Group); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]gerrit.Group)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListIncludedGroups provides a mock function with given fields: groupID
+func (_m *ClientInterface) ListIncludedGroups(groupID string) ([]gerrit.Group, error) {
+	ret := _m.Called(groupID)
+
+	var r0 []gerrit.Group
+	if rf, ok := ret.Get(0).(func(string) []gerrit.Group); ok {
+		r0 = rf(groupID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]gerrit.Group)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(groupID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListProjectBranches provides a mock function with given fields: projectName
 func (_m *ClientInterface) ListProjectBranches(projectName string) ([]gerrit.Branch, error) {
 	ret := _m.Called(projectName)
@@ -381,6 +701,29 @@ func (_m *ClientInterface) ListProjects(_type string) ([]gerrit.Project, error)
 	return r0, r1
 }
 
+// QueryAccounts provides a mock function with given fields: query
+func (_m *ClientInterface) QueryAccounts(query string) ([]gerrit.Account, error) {
+	ret := _m.Called(query)
+
+	var r0 []gerrit.Account
+	if rf, ok := ret.Get(0).(func(string) []gerrit.Account); ok {
+		r0 = rf(query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]gerrit.Account)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ReloadPlugin provides a mock function with given fields: plugin
 func (_m *ClientInterface) ReloadPlugin(plugin string) error {
 	ret := _m.Called(plugin)
@@ -395,6 +738,43 @@ func (_m *ClientInterface) ReloadPlugin(plugin string) error {
 	return r0
 }
 
+// ResolveAccount provides a mock function with given fields: account
+func (_m *ClientInterface) ResolveAccount(account string) (*gerrit.Account, error) {
+	ret := _m.Called(account)
+
+	var r0 *gerrit.Account
+	if rf, ok := ret.Get(0).(func(string) *gerrit.Account); ok {
+		r0 = rf(account)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gerrit.Account)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(account)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestartPlugin provides a mock function with given fields: pluginID
+func (_m *ClientInterface) RestartPlugin(pluginID string) error {
+	ret := _m.Called(pluginID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(pluginID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Resty provides a mock function with given fields:
 func (_m *ClientInterface) Resty() *resty.Client {
 	ret := _m.Called()
@@ -411,6 +791,43 @@ func (_m *ClientInterface) Resty() *resty.Client {
 	return r0
 }
 
+// ReviewAccessRights provides a mock function with given fields: projectName, parent, add, remove
+func (_m *ClientInterface) ReviewAccessRights(projectName string, parent string, add []gerrit.AccessInfo, remove []gerrit.AccessInfo) (*gerrit.Change, error) {
+	ret := _m.Called(projectName, parent, add, remove)
+
+	var r0 *gerrit.Change
+	if rf, ok := ret.Get(0).(func(string, string, []gerrit.AccessInfo, []gerrit.AccessInfo) *gerrit.Change); ok {
+		r0 = rf(projectName, parent, add, remove)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gerrit.Change)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, []gerrit.AccessInfo, []gerrit.AccessInfo) error); ok {
+		r1 = rf(projectName, parent, add, remove)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetGroupOwner provides a mock function with given fields: groupID, ownerGroupID
+func (_m *ClientInterface) SetGroupOwner(groupID string, ownerGroupID string) error {
+	ret := _m.Called(groupID, ownerGroupID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(groupID, ownerGroupID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetProjectParent provides a mock function with given fields: projectName, parentName
 func (_m *ClientInterface) SetProjectParent(projectName string, parentName string) error {
 	ret := _m.Called(projectName, parentName)
@@ -453,6 +870,20 @@ func (_m *ClientInterface) UpdateGroup(groupID string, description string, visib
 	return r0
 }
 
+// UpdateMetaConfig provides a mock function with given fields: projectName, message, files
+func (_m *ClientInterface) UpdateMetaConfig(projectName string, message string, files map[string]gerrit.MetaConfigFile) error {
+	ret := _m.Called(projectName, message, files)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, map[string]gerrit.MetaConfigFile) error); ok {
+		r0 = rf(projectName, message, files)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateProject provides a mock function with given fields: prj
 func (_m *ClientInterface) UpdateProject(prj *gerrit.Project) error {
 	ret := _m.Called(prj)
@@ -467,6 +898,20 @@ func (_m *ClientInterface) UpdateProject(prj *gerrit.Project) error {
 	return r0
 }
 
+// UploadPlugin provides a mock function with given fields: pluginID, jar
+func (_m *ClientInterface) UploadPlugin(pluginID string, jar []byte) error {
+	ret := _m.Called(pluginID, jar)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []byte) error); ok {
+		r0 = rf(pluginID, jar)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewClientInterface interface {
 	mock.TestingT
 	Cleanup(func())
@@ -0,0 +1,127 @@
+package gerrit
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// Plugin is the plugin info returned by Gerrit.
+type Plugin struct {
+	ID       string `json:"id"`
+	Version  string `json:"version,omitempty"`
+	IndexURL string `json:"index_url,omitempty"`
+	Filename string `json:"filename,omitempty"`
+	Disabled bool   `json:"disabled,omitempty"`
+}
+
+// GetPlugin returns the installed plugin, the disabled plugins are returned too.
+// The plugin administration requires plugins.allowRemoteAdmin to be enabled in gerrit.config.
+func (gc *Client) GetPlugin(pluginID string) (*Plugin, error) {
+	rsp, err := gc.request().SetHeader(acceptHeader, applicationJson).
+		SetQueryParams(map[string]string{"all": "", "p": pluginID}).
+		Get("plugins/")
+	if err = parseRestyResponse(rsp, err); err != nil {
+		return nil, errors.Wrap(err, "unable to list plugins")
+	}
+
+	var plugins map[string]Plugin
+	if err := decodeGerritResponse(rsp.String(), &plugins); err != nil {
+		return nil, errors.Wrap(err, "unable to unmarshal plugins response")
+	}
+
+	plugin, ok := plugins[pluginID]
+	if !ok {
+		return nil, DoesNotExistError("plugin does not exist")
+	}
+
+	plugin.ID = pluginID
+
+	return &plugin, nil
+}
+
+// ListPlugins returns the enabled plugins sorted by ID.
+func (gc *Client) ListPlugins() ([]Plugin, error) {
+	rsp, err := gc.request().SetHeader(acceptHeader, applicationJson).
+		Get("plugins/")
+	if err = parseRestyResponse(rsp, err); err != nil {
+		return nil, errors.Wrap(err, "unable to list plugins")
+	}
+
+	var plugins map[string]Plugin
+	if err := decodeGerritResponse(rsp.String(), &plugins); err != nil {
+		return nil, errors.Wrap(err, "unable to unmarshal plugins response")
+	}
+
+	list := make([]Plugin, 0, len(plugins))
+
+	for id, plugin := range plugins {
+		plugin.ID = id
+		list = append(list, plugin)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].ID < list[j].ID
+	})
+
+	return list, nil
+}
+
+// InstallPlugin installs or upgrades the plugin from the URL, Gerrit downloads the jar itself.
+// The jar on the Gerrit server is installed with the file:// URL.
+func (gc *Client) InstallPlugin(pluginID, jarURL string) error {
+	rsp, err := gc.request().SetHeader(contentType, applicationJson).
+		SetBody(map[string]string{"url": jarURL}).
+		Put(fmt.Sprintf("plugins/%s.jar", url.PathEscape(pluginID)))
+	if err = parseRestyResponse(rsp, err); err != nil {
+		return errors.Wrap(err, "unable to install plugin")
+	}
+
+	return nil
+}
+
+// UploadPlugin installs or upgrades the plugin from the jar content.
+func (gc *Client) UploadPlugin(pluginID string, jar []byte) error {
+	rsp, err := gc.request().SetHeader(contentType, "application/octet-stream").
+		SetBody(jar).
+		Put(fmt.Sprintf("plugins/%s.jar", url.PathEscape(pluginID)))
+	if err = parseRestyResponse(rsp, err); err != nil {
+		return errors.Wrap(err, "unable to upload plugin")
+	}
+
+	return nil
+}
+
+func (gc *Client) EnablePlugin(pluginID string) error {
+	return gc.pluginAction(pluginID, "enable")
+}
+
+func (gc *Client) DisablePlugin(pluginID string) error {
+	return gc.pluginAction(pluginID, "disable")
+}
+
+// RestartPlugin reloads the plugin with the REST API, so the plugin re-reads its configuration.
+// Unlike ReloadPlugin, it does not need the SSH connection.
+func (gc *Client) RestartPlugin(pluginID string) error {
+	return gc.pluginAction(pluginID, "reload")
+}
+
+func (gc *Client) pluginAction(pluginID, action string) error {
+	rsp, err := gc.request().Post(fmt.Sprintf("plugins/%s/gerrit~%s", url.PathEscape(pluginID), action))
+	if err != nil {
+		return errors.Wrapf(err, "unable to %s plugin", action)
+	}
+
+	if rsp.StatusCode() == http.StatusNotFound {
+		return DoesNotExistError("plugin does not exist")
+	}
+
+	if rsp.IsError() {
+		return errors.Errorf("unable to %s plugin, status: %s, body: %s", action, rsp.Status(), rsp.String())
+	}
+
+	return nil
+}
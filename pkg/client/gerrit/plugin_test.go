@@ -0,0 +1,103 @@
+package gerrit
+
+import (
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetPlugin(t *testing.T) {
+	restyClient := CreateMockResty()
+	cl := Client{
+		resty: restyClient,
+	}
+
+	httpmock.RegisterResponder("GET", "/plugins/?all=&p=replication",
+		httpmock.NewStringResponder(200, `)]}'
+{"replication": {"version": "3.9.1", "index_url": "plugins/replication/", "filename": "replication.jar", "disabled": true},
+"replication-status": {"version": "1.0"}}`))
+
+	plugin, err := cl.GetPlugin("replication")
+	require.NoError(t, err)
+
+	assert.Equal(t, &Plugin{
+		ID:       "replication",
+		Version:  "3.9.1",
+		IndexURL: "plugins/replication/",
+		Filename: "replication.jar",
+		Disabled: true,
+	}, plugin)
+
+	httpmock.RegisterResponder("GET", "/plugins/?all=&p=gitiles",
+		httpmock.NewStringResponder(200, `)]}'
+{}`))
+
+	_, err = cl.GetPlugin("gitiles")
+	require.Error(t, err)
+	assert.True(t, IsErrDoesNotExist(err))
+}
+
+func TestClient_ListPlugins(t *testing.T) {
+	restyClient := CreateMockResty()
+	cl := Client{
+		resty: restyClient,
+	}
+
+	httpmock.RegisterResponder("GET", "/plugins/",
+		httpmock.NewStringResponder(200, `)]}'
+{"replication": {"version": "3.9.1"}, "delete-project": {"version": "v3.9.0"}}`))
+
+	plugins, err := cl.ListPlugins()
+	require.NoError(t, err)
+
+	assert.Equal(t, []Plugin{
+		{ID: "delete-project", Version: "v3.9.0"},
+		{ID: "replication", Version: "3.9.1"},
+	}, plugins)
+}
+
+func TestClient_InstallPlugin(t *testing.T) {
+	restyClient := CreateMockResty()
+	cl := Client{
+		resty: restyClient,
+	}
+
+	httpmock.RegisterResponder("PUT", "/plugins/gitiles.jar",
+		httpmock.NewStringResponder(201, ""))
+
+	require.NoError(t, cl.InstallPlugin("gitiles", "https://example.com/gitiles.jar"))
+	require.NoError(t, cl.UploadPlugin("gitiles", []byte("jar")))
+
+	httpmock.RegisterResponder("PUT", "/plugins/webhooks.jar",
+		httpmock.NewStringResponder(403, "remote plugin administration is disabled"))
+
+	err := cl.InstallPlugin("webhooks", "https://example.com/webhooks.jar")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to install plugin")
+}
+
+func TestClient_pluginAction(t *testing.T) {
+	restyClient := CreateMockResty()
+	cl := Client{
+		resty: restyClient,
+	}
+
+	httpmock.RegisterResponder("POST", "/plugins/gitiles/gerrit~enable",
+		httpmock.NewStringResponder(200, ""))
+	httpmock.RegisterResponder("POST", "/plugins/gitiles/gerrit~disable",
+		httpmock.NewStringResponder(404, "Not found"))
+	httpmock.RegisterResponder("POST", "/plugins/gitiles/gerrit~reload",
+		httpmock.NewStringResponder(500, "fatal"))
+
+	require.NoError(t, cl.EnablePlugin("gitiles"))
+
+	err := cl.DisablePlugin("gitiles")
+	require.Error(t, err)
+	assert.True(t, IsErrDoesNotExist(err))
+
+	err = cl.RestartPlugin("gitiles")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to reload plugin")
+}
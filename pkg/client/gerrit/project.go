@@ -4,11 +4,15 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"sort"
 
 	"github.com/gosimple/slug"
 	"github.com/pkg/errors"
 )
 
+// projectsPageSize is the number of projects requested from Gerrit at once.
+const projectsPageSize = 500
+
 type Project struct {
 	Name              string `json:"name"`
 	Parent            string `json:"parent,omitempty"`
@@ -19,6 +23,9 @@ type Project struct {
 	Branches          string `json:"branches,omitempty"`
 	Owners            string `json:"owners,omitempty"`
 	RejectEmptyCommit string `json:"reject_empty_commit,omitempty"`
+
+	// MoreProjects is set by Gerrit on the last project of a page if there are more projects to list.
+	MoreProjects bool `json:"_more_projects,omitempty"`
 }
 
 func (p *Project) SlugifyName() string {
@@ -40,14 +47,14 @@ type WebLink struct {
 }
 
 func (gc *Client) CreateProject(prj *Project) error {
-	rsp, err := gc.resty.R().SetBody(prj).SetHeader(contentType, applicationJson).
+	rsp, err := gc.request().SetBody(prj).SetHeader(contentType, applicationJson).
 		Put(fmt.Sprintf("/projects/%s", url.QueryEscape(prj.Name)))
 
 	return parseRestyResponse(rsp, err)
 }
 
 func (gc *Client) GetProject(name string) (*Project, error) {
-	rsp, err := gc.resty.R().
+	rsp, err := gc.request().
 		SetHeader(acceptHeader, applicationJson).
 		Get(fmt.Sprintf("/projects/%s", url.QueryEscape(name)))
 	if err != nil {
@@ -71,7 +78,7 @@ func (gc *Client) GetProject(name string) (*Project, error) {
 }
 
 func (gc *Client) UpdateProject(prj *Project) error {
-	rsp, err := gc.resty.R().SetHeader(contentType, applicationJson).
+	rsp, err := gc.request().SetHeader(contentType, applicationJson).
 		SetBody(map[string]string{
 			"description":    prj.Description,
 			"commit_message": "Update the project description",
@@ -82,7 +89,7 @@ func (gc *Client) UpdateProject(prj *Project) error {
 		return errors.Wrap(err, "unable to update project description")
 	}
 
-	rsp, err = gc.resty.R().SetHeader(contentType, applicationJson).
+	rsp, err = gc.request().SetHeader(contentType, applicationJson).
 		SetBody(map[string]string{
 			"parent":         prj.Parent,
 			"commit_message": "Update the project parent",
@@ -92,7 +99,7 @@ func (gc *Client) UpdateProject(prj *Project) error {
 }
 
 func (gc *Client) DeleteProject(name string) error {
-	rsp, err := gc.resty.R().SetHeader(contentType, applicationJson).
+	rsp, err := gc.request().SetHeader(contentType, applicationJson).
 		SetBody(map[string]bool{
 			"force":    false,
 			"preserve": false,
@@ -101,38 +108,66 @@ func (gc *Client) DeleteProject(name string) error {
 	return parseRestyResponse(rsp, err)
 }
 
+// ListProjects returns all projects of the given type.
+// Projects are requested page by page, so large instances are listed without hitting server limits.
 func (gc *Client) ListProjects(_type string) ([]Project, error) {
-	rsp, err := gc.resty.R().SetHeader(acceptHeader, applicationJson).
-		Get(fmt.Sprintf("/projects/?type=%s&d=1&t=1", _type))
+	var projects []Project
+
+	for start := 0; ; start += projectsPageSize {
+		page, more, err := gc.listProjectsPage(_type, start, projectsPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		projects = append(projects, page...)
+
+		if !more {
+			break
+		}
+	}
+
+	sort.Slice(projects, func(i, j int) bool {
+		return projects[i].Name < projects[j].Name
+	})
+
+	return projects, nil
+}
+
+func (gc *Client) listProjectsPage(_type string, start, limit int) (projects []Project, more bool, err error) {
+	rsp, err := gc.request().SetHeader(acceptHeader, applicationJson).
+		Get(fmt.Sprintf("/projects/?type=%s&d=1&t=1&n=%d&S=%d", _type, limit, start))
 	if err != nil {
-		return nil, errors.Wrapf(err, "Unable to get Gerrit project")
+		return nil, false, errors.Wrapf(err, "Unable to get Gerrit project")
 	}
 
 	if rsp.IsError() {
-		return nil, errors.Errorf("wrong response code: %d, body: %s", rsp.StatusCode(), rsp.String())
+		return nil, false, errors.Errorf("wrong response code: %d, body: %s", rsp.StatusCode(), rsp.String())
 	}
 
 	var preProjects map[string]Project
 	if err := decodeGerritResponse(rsp.String(), &preProjects); err != nil {
-		return nil, errors.Wrapf(err, "unable to unmarshal project response, body: %s", rsp.String())
+		return nil, false, errors.Wrapf(err, "unable to unmarshal project response, body: %s", rsp.String())
 	}
 
-	delete(preProjects, "All-Projects")
+	more = len(preProjects) >= limit
+
 	delete(preProjects, "All-Projects")
 	delete(preProjects, "All-Users")
 
-	projects := make([]Project, 0, len(preProjects))
+	projects = make([]Project, 0, len(preProjects))
 
 	for k, v := range preProjects {
+		more = more || v.MoreProjects
 		v.Name = k
+		v.MoreProjects = false
 		projects = append(projects, v)
 	}
 
-	return projects, nil
+	return projects, more, nil
 }
 
 func (gc *Client) ListProjectBranches(projectName string) ([]Branch, error) {
-	rsp, err := gc.resty.R().SetHeader(acceptHeader, applicationJson).
+	rsp, err := gc.request().SetHeader(acceptHeader, applicationJson).
 		Get(fmt.Sprintf("/projects/%s/branches/", url.QueryEscape(projectName)))
 	if err != nil {
 		return nil, errors.Wrapf(err, "Unable to get Gerrit project branches")
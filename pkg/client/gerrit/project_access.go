@@ -2,6 +2,10 @@ package gerrit
 
 import (
 	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
 
 	"github.com/pkg/errors"
 	"gopkg.in/resty.v1"
@@ -16,6 +20,68 @@ type AccessInfo struct {
 	Force           bool   `json:"force"`
 	Min             int    `json:"min"`
 	Max             int    `json:"max"`
+	// Exclusive is the flag of the permission on the ref pattern, it ignores the inherited rules of the permission.
+	Exclusive bool `json:"exclusive,omitempty"`
+}
+
+const (
+	ActionAllow       = "ALLOW"
+	ActionDeny        = "DENY"
+	ActionBlock       = "BLOCK"
+	ActionInteractive = "INTERACTIVE"
+	ActionBatch       = "BATCH"
+)
+
+// labelPermissionPrefixes are the prefixes of the permissions that are granted on a label with a range of values.
+var labelPermissionPrefixes = []string{"label-", "labelAs-", "removeLabel-"}
+
+// PermissionLabel returns the label of the label permission, e.g. Verified for label-Verified,
+// and the empty string for other permissions.
+func PermissionLabel(permissionName string) string {
+	for _, prefix := range labelPermissionPrefixes {
+		if label, found := strings.CutPrefix(permissionName, prefix); found {
+			return label
+		}
+	}
+
+	return ""
+}
+
+// NormalizeAction returns the action as Gerrit reports it, the empty action is ALLOW.
+func NormalizeAction(action string) string {
+	if action == "" {
+		return ActionAllow
+	}
+
+	return strings.ToUpper(action)
+}
+
+// Key returns the key of the rule, the group is identified as it is set in the rule.
+func (a *AccessInfo) Key() string {
+	return fmt.Sprintf("%s %s %s", a.RefPattern, a.PermissionName, a.GroupName)
+}
+
+// Diff returns the fields of the live rule that differ from the rule.
+func (a *AccessInfo) Diff(live *AccessInfo) []string {
+	var diff []string
+
+	if NormalizeAction(a.Action) != NormalizeAction(live.Action) {
+		diff = append(diff, fmt.Sprintf("action: %s, expected %s", live.Action, NormalizeAction(a.Action)))
+	}
+
+	if a.Force != live.Force {
+		diff = append(diff, fmt.Sprintf("force: %t, expected %t", live.Force, a.Force))
+	}
+
+	if a.Min != live.Min || a.Max != live.Max {
+		diff = append(diff, fmt.Sprintf("range: %d..%d, expected %d..%d", live.Min, live.Max, a.Min, a.Max))
+	}
+
+	if a.Exclusive != live.Exclusive {
+		diff = append(diff, fmt.Sprintf("exclusive: %t, expected %t", live.Exclusive, a.Exclusive))
+	}
+
+	return diff
 }
 
 type groupPermissions struct {
@@ -28,40 +94,181 @@ type groupPermissions struct {
 }
 
 type permission struct {
-	Label string                      `json:"label"`
-	Rules map[string]groupPermissions `json:"rules"`
+	Label     string                      `json:"label"`
+	Exclusive bool                        `json:"exclusive,omitempty"`
+	Rules     map[string]groupPermissions `json:"rules"`
 }
 
 type reference struct {
 	Permissions map[string]permission `json:"permissions"`
 }
 
+type projectAccessInfo struct {
+	InheritsFrom *Project             `json:"inherits_from,omitempty"`
+	Local        map[string]reference `json:"local"`
+}
+
+// ProjectAccess is the access configuration that is defined locally in a project.
+type ProjectAccess struct {
+	Parent      string
+	Permissions []AccessInfo
+}
+
+// GetAccessRights returns the local access rights of the project.
+// Rules are keyed by group UUID, as returned by Gerrit.
+func (gc *Client) GetAccessRights(projectName string) (*ProjectAccess, error) {
+	rsp, err := gc.request().SetHeader(acceptHeader, applicationJson).
+		Get(fmt.Sprintf("/projects/%s/access", url.PathEscape(projectName)))
+	if err = parseRestyResponse(rsp, err); err != nil {
+		return nil, errors.Wrap(err, "unable to get access rights")
+	}
+
+	return decodeProjectAccess(rsp.String())
+}
+
+func decodeProjectAccess(body string) (*ProjectAccess, error) {
+	var info projectAccessInfo
+	if err := decodeGerritResponse(body, &info); err != nil {
+		return nil, errors.Wrap(err, "unable to unmarshal access rights response")
+	}
+
+	access := ProjectAccess{}
+	if info.InheritsFrom != nil {
+		access.Parent = info.InheritsFrom.Name
+	}
+
+	for refPattern, ref := range info.Local {
+		for permName, perm := range ref.Permissions {
+			for groupID, rule := range perm.Rules {
+				access.Permissions = append(access.Permissions, AccessInfo{
+					RefPattern:      refPattern,
+					PermissionName:  permName,
+					PermissionLabel: perm.Label,
+					GroupName:       groupID,
+					Action:          rule.Action,
+					Force:           rule.Force,
+					Min:             rule.Min,
+					Max:             rule.Max,
+					Exclusive:       perm.Exclusive,
+				})
+			}
+		}
+	}
+
+	sort.Slice(access.Permissions, func(i, j int) bool {
+		a, b := access.Permissions[i], access.Permissions[j]
+		if a.RefPattern != b.RefPattern {
+			return a.RefPattern < b.RefPattern
+		}
+
+		if a.PermissionName != b.PermissionName {
+			return a.PermissionName < b.PermissionName
+		}
+
+		return a.GroupName < b.GroupName
+	})
+
+	return &access, nil
+}
+
+// AddAccessRights adds the rules to the project and verifies them against the access rights returned by Gerrit.
 func (gc *Client) AddAccessRights(projectName string, permissions []AccessInfo) error {
 	accessInfo := generateSetAccessRequest(permissions, true, false)
 	addRequest := map[string]map[string]reference{"add": accessInfo}
 
-	rsp, err := gc.resty.R().SetBody(addRequest).SetHeader(contentType, applicationJson).
-		Post(fmt.Sprintf("/projects/%s/access", projectName))
+	rsp, err := gc.request().SetBody(addRequest).SetHeader(contentType, applicationJson).
+		Post(fmt.Sprintf("/projects/%s/access", url.PathEscape(projectName)))
+	if err = parseRestyResponse(rsp, err); err != nil {
+		return err
+	}
 
-	return parseRestyResponse(rsp, err)
+	return verifyAccessRights(rsp.String(), permissions)
 }
 
+// UpdateAccessRights replaces the rules of the groups in the project and verifies them
+// against the access rights returned by Gerrit.
 func (gc *Client) UpdateAccessRights(projectName string, permissions []AccessInfo) error {
 	accessInfo := generateSetAccessRequest(permissions, false, true)
 	addRequest := map[string]map[string]reference{"add": accessInfo, "remove": accessInfo}
 
-	rsp, err := gc.resty.R().SetBody(addRequest).SetHeader(contentType, applicationJson).
-		Post(fmt.Sprintf("/projects/%s/access", projectName))
+	rsp, err := gc.request().SetBody(addRequest).SetHeader(contentType, applicationJson).
+		Post(fmt.Sprintf("/projects/%s/access", url.PathEscape(projectName)))
+	if err = parseRestyResponse(rsp, err); err != nil {
+		return err
+	}
 
-	return parseRestyResponse(rsp, err)
+	return verifyAccessRights(rsp.String(), permissions)
+}
+
+// verifyAccessRights checks that the access rights returned by Gerrit contain the requested rules,
+// Gerrit silently ignores some invalid rules, e.g. the rules of unknown groups.
+func verifyAccessRights(body string, permissions []AccessInfo) error {
+	applied, err := decodeProjectAccess(body)
+	if err != nil {
+		return err
+	}
+
+	appliedRules := make(map[string]*AccessInfo, len(applied.Permissions))
+	for i := range applied.Permissions {
+		appliedRules[applied.Permissions[i].Key()] = &applied.Permissions[i]
+	}
+
+	var mismatches []string
+
+	for i := range permissions {
+		key := permissions[i].Key()
+
+		appliedRule, ok := appliedRules[key]
+		if !ok {
+			mismatches = append(mismatches, key+": missing")
+			continue
+		}
+
+		for _, d := range permissions[i].Diff(appliedRule) {
+			mismatches = append(mismatches, key+" "+d)
+		}
+	}
+
+	if len(mismatches) > 0 {
+		return errors.Errorf("access rights are not applied as requested: %s", strings.Join(mismatches, "; "))
+	}
+
+	return nil
+}
+
+// ReviewAccessRights creates a change of refs/meta/config that removes the rules of the groups in remove,
+// adds the rules in add and sets the non-empty parent. The access rights are applied when the change is merged.
+func (gc *Client) ReviewAccessRights(projectName, parent string, add, remove []AccessInfo) (*Change, error) {
+	reviewRequest := map[string]interface{}{
+		"add":    generateSetAccessRequest(add, false, true),
+		"remove": generateSetAccessRequest(remove, false, true),
+	}
+
+	if parent != "" {
+		reviewRequest["parent"] = parent
+	}
+
+	rsp, err := gc.request().SetBody(reviewRequest).SetHeader(contentType, applicationJson).
+		SetHeader(acceptHeader, applicationJson).
+		Put(fmt.Sprintf("/projects/%s/access:review", url.PathEscape(projectName)))
+	if err = parseRestyResponse(rsp, err); err != nil {
+		return nil, errors.Wrap(err, "unable to create access review change")
+	}
+
+	var change Change
+	if err := decodeGerritResponse(rsp.String(), &change); err != nil {
+		return nil, errors.Wrap(err, "unable to decode change from body")
+	}
+
+	return &change, nil
 }
 
 func (gc *Client) DeleteAccessRights(projectName string, permissions []AccessInfo) error {
 	accessInfo := generateSetAccessRequest(permissions, false, false)
 	addRequest := map[string]map[string]reference{"remove": accessInfo}
 
-	rsp, err := gc.resty.R().SetBody(addRequest).SetHeader(contentType, applicationJson).
-		Post(fmt.Sprintf("/projects/%s/access", projectName))
+	rsp, err := gc.request().SetBody(addRequest).SetHeader(contentType, applicationJson).
+		Post(fmt.Sprintf("/projects/%s/access", url.PathEscape(projectName)))
 
 	return parseRestyResponse(rsp, err)
 }
@@ -76,12 +283,14 @@ func generateSetAccessRequest(permissions []AccessInfo, added, modified bool) ma
 			refs[perm.RefPattern] = ref
 		}
 
-		_, ok = refs[perm.RefPattern].Permissions[perm.PermissionName]
+		permName, ok := refs[perm.RefPattern].Permissions[perm.PermissionName]
 		if !ok {
-			permName := permission{Rules: make(map[string]groupPermissions), Label: perm.PermissionLabel}
-			refs[perm.RefPattern].Permissions[perm.PermissionName] = permName
+			permName = permission{Rules: make(map[string]groupPermissions), Label: perm.PermissionLabel}
 		}
 
+		permName.Exclusive = permName.Exclusive || perm.Exclusive
+		refs[perm.RefPattern].Permissions[perm.PermissionName] = permName
+
 		_, ok = refs[perm.RefPattern].Permissions[perm.PermissionName].Rules[perm.GroupName]
 		if !ok {
 			groupPerm := groupPermissions{
@@ -101,14 +310,51 @@ func generateSetAccessRequest(permissions []AccessInfo, added, modified bool) ma
 }
 
 func (gc *Client) SetProjectParent(projectName, parentName string) error {
-	rsp, err := gc.resty.R().SetBody(map[string]string{
+	rsp, err := gc.request().SetBody(map[string]string{
 		"parent": parentName,
 	}).SetHeader(contentType, applicationJson).
-		Put(fmt.Sprintf("/projects/%s/parent", projectName))
+		Put(fmt.Sprintf("/projects/%s/parent", url.PathEscape(projectName)))
 
 	return parseRestyResponse(rsp, err)
 }
 
+// AccessCheck is the account, ref and permission of the access check, the empty ref and permission check the read access.
+type AccessCheck struct {
+	Account    string `json:"account"`
+	Ref        string `json:"ref,omitempty"`
+	Permission string `json:"permission,omitempty"`
+}
+
+// AccessCheckInfo is the result of the access check.
+type AccessCheckInfo struct {
+	// Status is 200 if the access is allowed, 403 if it is denied and 404 if the project or the ref is not visible.
+	Status    int      `json:"status"`
+	Message   string   `json:"message,omitempty"`
+	DebugLogs []string `json:"debug_logs,omitempty"`
+}
+
+// Allowed reports whether the access is allowed.
+func (a *AccessCheckInfo) Allowed() bool {
+	return a.Status == http.StatusOK
+}
+
+// CheckAccess checks the effective permission of the account in the project.
+func (gc *Client) CheckAccess(projectName string, check AccessCheck) (*AccessCheckInfo, error) {
+	rsp, err := gc.request().SetBody(check).SetHeader(contentType, applicationJson).
+		SetHeader(acceptHeader, applicationJson).
+		Post(fmt.Sprintf("/projects/%s/check.access", url.PathEscape(projectName)))
+	if err = parseRestyResponse(rsp, err); err != nil {
+		return nil, errors.Wrap(err, "unable to check access")
+	}
+
+	var info AccessCheckInfo
+	if err := decodeGerritResponse(rsp.String(), &info); err != nil {
+		return nil, errors.Wrap(err, "unable to unmarshal access check response")
+	}
+
+	return &info, nil
+}
+
 func parseRestyResponse(rsp *resty.Response, err error) error {
 	if err != nil {
 		return errors.Wrap(err, "error during post request")
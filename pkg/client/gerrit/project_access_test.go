@@ -3,12 +3,25 @@ package gerrit
 import (
 	"encoding/json"
 	"errors"
+	"net/http"
+	"reflect"
 	"testing"
 
 	"github.com/jarcoal/httpmock"
 	"gopkg.in/resty.v1"
 )
 
+const appliedAccessResponse = `)]}'
+{
+  "local": {
+    "refs/heads/*": {
+      "permissions": {
+        "label-Code-Review": {"label": "Code-Review", "rules": {"important-group": {"action": "ALLOW", "min": -2, "max": 2}}}
+      }
+    }
+  }
+}`
+
 func TestClient_AddAccessRights(t *testing.T) {
 	restyClient := resty.New()
 	httpmock.ActivateNonDefault(restyClient.GetClient())
@@ -17,7 +30,7 @@ func TestClient_AddAccessRights(t *testing.T) {
 		resty: restyClient,
 	}
 
-	httpmock.RegisterResponder("POST", "/projects/test/access", httpmock.NewStringResponder(200, ""))
+	httpmock.RegisterResponder("POST", "/projects/test/access", httpmock.NewStringResponder(200, appliedAccessResponse))
 
 	if err := cl.AddAccessRights("test", []AccessInfo{
 		{
@@ -42,7 +55,7 @@ func TestClient_UpdateAccessRights(t *testing.T) {
 		resty: restyClient,
 	}
 
-	httpmock.RegisterResponder("POST", "/projects/test/access", httpmock.NewStringResponder(200, ""))
+	httpmock.RegisterResponder("POST", "/projects/test/access", httpmock.NewStringResponder(200, appliedAccessResponse))
 
 	if err := cl.UpdateAccessRights("test", []AccessInfo{
 		{
@@ -59,6 +72,47 @@ func TestClient_UpdateAccessRights(t *testing.T) {
 	}
 }
 
+func TestClient_AddAccessRights_NotApplied(t *testing.T) {
+	restyClient := resty.New()
+	httpmock.ActivateNonDefault(restyClient.GetClient())
+
+	cl := Client{
+		resty: restyClient,
+	}
+
+	httpmock.RegisterResponder("POST", "/projects/test/access", httpmock.NewStringResponder(200, appliedAccessResponse))
+
+	err := cl.AddAccessRights("test", []AccessInfo{
+		{
+			RefPattern:     "refs/heads/*",
+			PermissionName: "label-Code-Review",
+			GroupName:      "important-group",
+			Min:            -1,
+			Max:            1,
+			Action:         "ALLOW",
+			Exclusive:      true,
+		},
+		{
+			RefPattern:     "refs/heads/release/*",
+			PermissionName: "push",
+			GroupName:      "global:Registered-Users",
+			Force:          true,
+			Action:         "BLOCK",
+		},
+	})
+	if err == nil {
+		t.Fatal("no error returned")
+	}
+
+	want := "access rights are not applied as requested: " +
+		"refs/heads/* label-Code-Review important-group range: -2..2, expected -1..1; " +
+		"refs/heads/* label-Code-Review important-group exclusive: false, expected true; " +
+		"refs/heads/release/* push global:Registered-Users: missing"
+	if err.Error() != want {
+		t.Fatalf("wrong error: %s", err)
+	}
+}
+
 func TestClient_DeleteAccessRights(t *testing.T) {
 	restyClient := resty.New()
 	httpmock.ActivateNonDefault(restyClient.GetClient())
@@ -109,6 +163,165 @@ func TestClient_DeleteAccessRightsFailure(t *testing.T) {
 	}
 }
 
+func TestClient_GetAccessRights(t *testing.T) {
+	restyClient := resty.New()
+	httpmock.ActivateNonDefault(restyClient.GetClient())
+
+	cl := Client{
+		resty: restyClient,
+	}
+
+	httpmock.RegisterResponder("GET", "/projects/team%2Fbackend/access", httpmock.NewStringResponder(200, `)]}'
+{
+  "inherits_from": {"name": "All-Projects"},
+  "local": {
+    "refs/heads/*": {
+      "permissions": {
+        "read": {"rules": {"uuid-devs": {"action": "ALLOW"}}},
+        "push": {"exclusive": true, "rules": {"global:Registered-Users": {"action": "BLOCK", "force": true}}},
+        "label-Code-Review": {"label": "Code-Review", "rules": {"uuid-devs": {"action": "ALLOW", "min": -2, "max": 2}}}
+      }
+    }
+  }
+}`))
+
+	access, err := cl.GetAccessRights("team/backend")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if access.Parent != "All-Projects" {
+		t.Fatalf("wrong parent: %s", access.Parent)
+	}
+
+	want := []AccessInfo{
+		{
+			RefPattern:      "refs/heads/*",
+			PermissionName:  "label-Code-Review",
+			PermissionLabel: "Code-Review",
+			GroupName:       "uuid-devs",
+			Action:          "ALLOW",
+			Min:             -2,
+			Max:             2,
+		},
+		{
+			RefPattern:     "refs/heads/*",
+			PermissionName: "push",
+			GroupName:      "global:Registered-Users",
+			Action:         "BLOCK",
+			Force:          true,
+			Exclusive:      true,
+		},
+		{
+			RefPattern:     "refs/heads/*",
+			PermissionName: "read",
+			GroupName:      "uuid-devs",
+			Action:         "ALLOW",
+		},
+	}
+
+	if !reflect.DeepEqual(want, access.Permissions) {
+		t.Fatalf("wrong permissions: %+v", access.Permissions)
+	}
+}
+
+func TestClient_GetAccessRightsFailure(t *testing.T) {
+	restyClient := resty.New()
+	httpmock.ActivateNonDefault(restyClient.GetClient())
+
+	cl := Client{
+		resty: restyClient,
+	}
+
+	httpmock.RegisterResponder("GET", "/projects/missing/access", httpmock.NewStringResponder(404, "Not found"))
+
+	if _, err := cl.GetAccessRights("missing"); err == nil {
+		t.Fatal("no error returned")
+	}
+}
+
+func TestClient_CheckAccess(t *testing.T) {
+	restyClient := resty.New()
+	httpmock.ActivateNonDefault(restyClient.GetClient())
+
+	cl := Client{
+		resty: restyClient,
+	}
+
+	httpmock.RegisterResponder("POST", "/projects/team%2Fbackend/check.access",
+		func(req *http.Request) (*http.Response, error) {
+			var check AccessCheck
+			if err := json.NewDecoder(req.Body).Decode(&check); err != nil {
+				return nil, err
+			}
+
+			if check != (AccessCheck{Account: "developer", Ref: "refs/heads/release/1.0", Permission: "push"}) {
+				return httpmock.NewStringResponse(400, "wrong check"), nil
+			}
+
+			return httpmock.NewStringResponse(200, `)]}'
+{"status": 403, "message": "developer cannot perform push", "debug_logs": ["push is blocked"]}`), nil
+		})
+
+	info, err := cl.CheckAccess("team/backend", AccessCheck{Account: "developer", Ref: "refs/heads/release/1.0", Permission: "push"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &AccessCheckInfo{Status: 403, Message: "developer cannot perform push", DebugLogs: []string{"push is blocked"}}
+	if !reflect.DeepEqual(want, info) || info.Allowed() {
+		t.Fatalf("wrong access check: %+v", info)
+	}
+
+	if _, err := cl.CheckAccess("missing", AccessCheck{Account: "developer"}); err == nil {
+		t.Fatal("no error returned")
+	}
+}
+
+func TestClient_ReviewAccessRights(t *testing.T) {
+	restyClient := resty.New()
+	httpmock.ActivateNonDefault(restyClient.GetClient())
+
+	cl := Client{
+		resty: restyClient,
+	}
+
+	httpmock.RegisterResponder("PUT", "/projects/All-Projects/access:review",
+		func(req *http.Request) (*http.Response, error) {
+			var body map[string]json.RawMessage
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+
+			if string(body["parent"]) != `"Root"` || len(body["add"]) == 0 || len(body["remove"]) == 0 {
+				return httpmock.NewStringResponse(400, "wrong request"), nil
+			}
+
+			return httpmock.NewStringResponse(201, `)]}'
+{"id": "All-Projects~refs%2Fmeta%2Fconfig~I8473b95934b5732ac55d26311a706c9c2bde9940", "_number": 42, "status": "NEW"}`), nil
+		})
+
+	rules := []AccessInfo{
+		{RefPattern: "refs/heads/release/*", PermissionName: "push", GroupName: "global:Registered-Users", Action: "BLOCK", Force: true},
+	}
+
+	change, err := cl.ReviewAccessRights("All-Projects", "Root", rules, rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &Change{ID: "All-Projects~refs%2Fmeta%2Fconfig~I8473b95934b5732ac55d26311a706c9c2bde9940", Number: 42, Status: "NEW"}
+	if !reflect.DeepEqual(want, change) {
+		t.Fatalf("wrong change: %+v", change)
+	}
+
+	httpmock.RegisterResponder("PUT", "/projects/missing/access:review", httpmock.NewStringResponder(404, "Not found"))
+
+	if _, err := cl.ReviewAccessRights("missing", "", rules, nil); err == nil {
+		t.Fatal("no error returned")
+	}
+}
+
 func TestClient_SetProjectParent(t *testing.T) {
 	restyClient := resty.New()
 	httpmock.ActivateNonDefault(restyClient.GetClient())
@@ -124,6 +337,45 @@ func TestClient_SetProjectParent(t *testing.T) {
 	}
 }
 
+func TestClient_AccessRights_NestedProject(t *testing.T) {
+	restyClient := resty.New()
+	httpmock.ActivateNonDefault(restyClient.GetClient())
+
+	cl := Client{
+		resty: restyClient,
+	}
+
+	httpmock.RegisterResponder("POST", "/projects/team%2Fbackend/access", httpmock.NewStringResponder(200, appliedAccessResponse))
+	httpmock.RegisterResponder("PUT", "/projects/team%2Fbackend/parent", httpmock.NewStringResponder(200, ""))
+
+	rules := []AccessInfo{
+		{
+			RefPattern:     "refs/heads/*",
+			PermissionName: "label-Code-Review",
+			GroupName:      "important-group",
+			Min:            -2,
+			Max:            2,
+			Action:         "ALLOW",
+		},
+	}
+
+	if err := cl.AddAccessRights("team/backend", rules); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cl.UpdateAccessRights("team/backend", rules); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cl.DeleteAccessRights("team/backend", rules); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cl.SetProjectParent("team/backend", "team"); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestGenerateSetAccessRequest(t *testing.T) {
 	rq := generateSetAccessRequest([]AccessInfo{
 		{
@@ -163,6 +415,36 @@ func TestGenerateSetAccessRequest(t *testing.T) {
 	t.Log(string(bts))
 }
 
+func TestPermissionLabel(t *testing.T) {
+	tests := map[string]string{
+		"label-Verified":          "Verified",
+		"labelAs-Code-Review":     "Code-Review",
+		"removeLabel-Custom-Vote": "Custom-Vote",
+		"push":                    "",
+	}
+
+	for permission, want := range tests {
+		if got := PermissionLabel(permission); got != want {
+			t.Fatalf("wrong label of %s: %s", permission, got)
+		}
+	}
+}
+
+func TestAccessInfo_Diff(t *testing.T) {
+	rule := AccessInfo{RefPattern: "refs/heads/release/*", PermissionName: "push", GroupName: "global:Registered-Users", Force: true}
+
+	if diff := rule.Diff(&AccessInfo{Action: "ALLOW", Force: true}); len(diff) != 0 {
+		t.Fatalf("unexpected diff: %v", diff)
+	}
+
+	rule.Action = "block"
+
+	want := []string{"action: ALLOW, expected BLOCK", "force: false, expected true"}
+	if diff := rule.Diff(&AccessInfo{Action: "ALLOW"}); !reflect.DeepEqual(want, diff) {
+		t.Fatalf("wrong diff: %v", diff)
+	}
+}
+
 func TestParseRestyResponse(t *testing.T) {
 	if err := parseRestyResponse(nil, errors.New("fatal")); err == nil {
 		t.Fatal("no error")
@@ -150,7 +150,7 @@ func TestClient_ListProjects(t *testing.T) {
 
 	restyClient := resty.New()
 	httpmock.ActivateNonDefault(restyClient.GetClient())
-	httpmock.RegisterResponder("GET", "/projects/?type=CODE&d=1&t=1",
+	httpmock.RegisterResponder("GET", "/projects/?type=CODE&d=1&t=1&n=500&S=0",
 		httpmock.NewStringResponder(200, `}}}}}{"prf": {"name": "prf"}}`))
 
 	cl := Client{
@@ -161,6 +161,31 @@ func TestClient_ListProjects(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestClient_ListProjects_Pagination(t *testing.T) {
+	httpmock.Reset()
+
+	restyClient := resty.New()
+	httpmock.ActivateNonDefault(restyClient.GetClient())
+	httpmock.RegisterResponder("GET", "/projects/?type=CODE&d=1&t=1&n=500&S=0",
+		httpmock.NewStringResponder(200, `)]}'
+{"b": {"id": "b"}, "a": {"id": "a", "_more_projects": true}}`))
+	httpmock.RegisterResponder("GET", "/projects/?type=CODE&d=1&t=1&n=500&S=500",
+		httpmock.NewStringResponder(200, `)]}'
+{"c": {"id": "c"}}`))
+
+	cl := Client{
+		resty: restyClient,
+	}
+
+	projects, err := cl.ListProjects("CODE")
+	require.NoError(t, err)
+
+	require.Len(t, projects, 3)
+	assert.Equal(t, "a", projects[0].Name)
+	assert.False(t, projects[0].MoreProjects)
+	assert.Equal(t, "c", projects[2].Name)
+}
+
 func TestClient_ListProjects_Failure(t *testing.T) {
 	httpmock.Reset()
 
@@ -179,7 +204,7 @@ func TestClient_ListProjects_Failure(t *testing.T) {
 	}
 
 	httpmock.Reset()
-	httpmock.RegisterResponder("GET", "/projects/?type=CODE&d=1&t=1",
+	httpmock.RegisterResponder("GET", "/projects/?type=CODE&d=1&t=1&n=500&S=0",
 		httpmock.NewStringResponder(500, "500 fatal"))
 
 	_, err = cl.ListProjects("CODE")
@@ -190,7 +215,7 @@ func TestClient_ListProjects_Failure(t *testing.T) {
 	}
 
 	httpmock.Reset()
-	httpmock.RegisterResponder("GET", "/projects/?type=CODE&d=1&t=1",
+	httpmock.RegisterResponder("GET", "/projects/?type=CODE&d=1&t=1&n=500&S=0",
 		httpmock.NewStringResponder(200, `}}}}}zazazaza`))
 
 	_, err = cl.ListProjects("CODE")
@@ -0,0 +1,21 @@
+package gerrit
+
+import (
+	"github.com/pkg/errors"
+)
+
+// GetVersion returns the version of the Gerrit server, e.g. "3.9.1".
+func (gc *Client) GetVersion() (string, error) {
+	rsp, err := gc.request().SetHeader(acceptHeader, applicationJson).
+		Get("config/server/version")
+	if err = parseRestyResponse(rsp, err); err != nil {
+		return "", errors.Wrap(err, "unable to get server version")
+	}
+
+	var version string
+	if err := decodeGerritResponse(rsp.String(), &version); err != nil {
+		return "", errors.Wrap(err, "unable to unmarshal server version response")
+	}
+
+	return version, nil
+}
@@ -0,0 +1,38 @@
+package gerrit
+
+import (
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetVersion(t *testing.T) {
+	restyClient := CreateMockResty()
+	cl := Client{
+		resty: restyClient,
+	}
+
+	httpmock.RegisterResponder("GET", "/config/server/version",
+		httpmock.NewStringResponder(200, `)]}'
+"3.9.1"`))
+
+	version, err := cl.GetVersion()
+	require.NoError(t, err)
+	assert.Equal(t, "3.9.1", version)
+}
+
+func TestClient_GetVersion_Err(t *testing.T) {
+	restyClient := CreateMockResty()
+	cl := Client{
+		resty: restyClient,
+	}
+
+	httpmock.RegisterResponder("GET", "/config/server/version",
+		httpmock.NewStringResponder(403, "forbidden"))
+
+	_, err := cl.GetVersion()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to get server version")
+}
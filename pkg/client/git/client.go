@@ -1,6 +1,7 @@
 package git
 
 import (
+	"context"
 	"crypto/sha1"
 	"fmt"
 	"net/url"
@@ -16,12 +17,17 @@ import (
 	"github.com/go-git/go-git/v5/plumbing/transport/http"
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/epam/edp-gerrit-operator/v2/pkg/tracing"
 )
 
 type Client struct {
 	username, password string
 	workingDir         string
 	gerritBaseURL      string
+	// ctx carries the trace of the caller.
+	ctx context.Context
 }
 
 type User struct {
@@ -38,6 +44,25 @@ func New(gerritBaseURL, workingDir, username, password string) *Client {
 	}
 }
 
+// WithContext returns a copy of the client that traces the operations in the given context.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	cl := *c
+	cl.ctx = ctx
+
+	return &cl
+}
+
+func (c *Client) startSpan(operation, projectName string) trace.Span {
+	ctx := c.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	_, span := tracing.Start(ctx, "git "+operation, tracing.GerritProject.String(projectName))
+
+	return span
+}
+
 func (c *Client) GerritBaseURL() string {
 	return c.gerritBaseURL
 }
@@ -46,7 +71,10 @@ func (c *Client) projectPath(projectName string) string {
 	return path.Join(c.workingDir, projectName)
 }
 
-func (c *Client) SetFileContents(projectName, filePath, contents string) error {
+func (c *Client) SetFileContents(projectName, filePath, contents string) (err error) {
+	span := c.startSpan("set file contents", projectName)
+	defer func() { tracing.End(span, err) }()
+
 	projectPath := c.projectPath(projectName)
 	filePath = path.Join(projectPath, filePath)
 
@@ -66,11 +94,14 @@ func (c *Client) SetFileContents(projectName, filePath, contents string) error {
 	return nil
 }
 
-func (c *Client) RemoveFile(projectName, filePath string) (bool, error) {
+func (c *Client) RemoveFile(projectName, filePath string) (removed bool, err error) {
+	span := c.startSpan("remove file", projectName)
+	defer func() { tracing.End(span, err) }()
+
 	projectPath := c.projectPath(projectName)
 	filePath = path.Join(projectPath, filePath)
 
-	err := os.Remove(filePath)
+	err = os.Remove(filePath)
 	if err != nil {
 		if !os.IsNotExist(err) {
 			return false, errors.Wrapf(err, "unable to remove file: %s", filePath)
@@ -83,6 +114,9 @@ func (c *Client) RemoveFile(projectName, filePath string) (bool, error) {
 }
 
 func (c *Client) Clone(projectName string) (projectPath string, err error) {
+	span := c.startSpan("clone", projectName)
+	defer func() { tracing.End(span, err) }()
+
 	projectPath = c.projectPath(projectName)
 	_, err = git.PlainClone(
 		projectPath, false, &git.CloneOptions{
@@ -100,7 +134,10 @@ func (c *Client) Clone(projectName string) (projectPath string, err error) {
 	return
 }
 
-func (c *Client) Merge(projectName, sourceBranch, targetBranch string, options ...string) error {
+func (c *Client) Merge(projectName, sourceBranch, targetBranch string, options ...string) (err error) {
+	span := c.startSpan("merge", projectName)
+	defer func() { tracing.End(span, err) }()
+
 	projectDir := c.projectPath(projectName)
 
 	cmd := exec.Command("git", "checkout", targetBranch)
@@ -125,7 +162,10 @@ func (c *Client) Merge(projectName, sourceBranch, targetBranch string, options .
 	return nil
 }
 
-func (c *Client) Commit(projectName, message string, files []string, user *User) error {
+func (c *Client) Commit(projectName, message string, files []string, user *User) (err error) {
+	span := c.startSpan("commit", projectName)
+	defer func() { tracing.End(span, err) }()
+
 	projectPath := c.projectPath(projectName)
 
 	r, err := git.PlainOpen(projectPath)
@@ -153,7 +193,10 @@ func (c *Client) Commit(projectName, message string, files []string, user *User)
 	return nil
 }
 
-func (c *Client) SetProjectUser(projectName string, user *User) error {
+func (c *Client) SetProjectUser(projectName string, user *User) (err error) {
+	span := c.startSpan("set project user", projectName)
+	defer func() { tracing.End(span, err) }()
+
 	r, err := git.PlainOpen(c.projectPath(projectName))
 	if err != nil {
 		return errors.Wrap(err, "unable to open repository")
@@ -176,7 +219,10 @@ func (c *Client) SetProjectUser(projectName string, user *User) error {
 	return nil
 }
 
-func (c *Client) CheckoutBranch(projectName, branch string) error {
+func (c *Client) CheckoutBranch(projectName, branch string) (err error) {
+	span := c.startSpan("checkout", projectName)
+	defer func() { tracing.End(span, err) }()
+
 	projectPath := c.projectPath(projectName)
 
 	r, err := git.PlainOpen(projectPath)
@@ -197,6 +243,9 @@ func (c *Client) CheckoutBranch(projectName, branch string) error {
 }
 
 func (c *Client) Push(projectName, remote string, refSpecs ...string) (pushOutput string, retErr error) {
+	span := c.startSpan("push", projectName)
+	defer func() { tracing.End(span, retErr) }()
+
 	projectPath := c.projectPath(projectName)
 
 	r, err := git.PlainOpen(projectPath)
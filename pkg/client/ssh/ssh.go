@@ -1,13 +1,19 @@
 package ssh
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net"
+	"strconv"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	"golang.org/x/crypto/ssh"
+
+	"github.com/epam/edp-gerrit-operator/v2/pkg/metrics"
+	"github.com/epam/edp-gerrit-operator/v2/pkg/tracing"
 )
 
 type SSHCommand struct {
@@ -16,6 +22,8 @@ type SSHCommand struct {
 	Stdin  io.Reader
 	Stdout io.Writer
 	Stderr io.Writer
+	// Context carries the trace of the caller, it is optional.
+	Context context.Context
 }
 
 type SSHClientInterface interface {
@@ -27,10 +35,26 @@ type SSHClient struct {
 	Config *ssh.ClientConfig
 	Host   string
 	Port   int32
+	// Gerrit is the name of the Gerrit instance used in the metrics.
+	Gerrit string
 	log    logr.Logger
 }
 
 func (client *SSHClient) RunCommand(cmd *SSHCommand) (out []byte, err error) {
+	start := time.Now()
+	operation := metrics.SSHOperation(cmd.Path)
+	ctx := cmd.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	_, span := tracing.Start(ctx, "SSH "+operation, tracing.GerritInstance.String(client.Gerrit))
+
+	defer func() {
+		metrics.ObserveGerritRequest(metrics.ProtocolSSH, operation, client.Gerrit, exitCode(err), time.Since(start))
+		tracing.End(span, err)
+	}()
+
 	session, connection, err := client.NewSession()
 	if err != nil {
 		return nil, err
@@ -56,6 +80,20 @@ func (client *SSHClient) RunCommand(cmd *SSHCommand) (out []byte, err error) {
 	return
 }
 
+// exitCode returns the exit status of the remote command or metrics.CodeError if the command has not been run.
+func exitCode(err error) string {
+	if err == nil {
+		return "0"
+	}
+
+	var exitErr *ssh.ExitError
+	if errors.As(err, &exitErr) {
+		return strconv.Itoa(exitErr.ExitStatus())
+	}
+
+	return metrics.CodeError
+}
+
 func (client *SSHClient) NewSession() (*ssh.Session, *ssh.Client, error) {
 	addr := fmt.Sprintf("%s:%d", client.Host, client.Port)
 
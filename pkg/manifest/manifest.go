@@ -0,0 +1,186 @@
+// Package manifest converts the Gerrit projects, groups and access rights into the operator custom resources.
+// It is used by the project syncer to import Gerrit into the cluster and by gerrit-export to write the manifests.
+package manifest
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gosimple/slug"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	gerritApi "github.com/epam/edp-gerrit-operator/v2/api/v1"
+	gerritClient "github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit"
+)
+
+// Project returns the GerritProject of the Gerrit project.
+func Project(gr *gerritApi.Gerrit, backendProject *gerritClient.Project) *gerritApi.GerritProject {
+	return &gerritApi.GerritProject{
+		TypeMeta: metaV1.TypeMeta{Kind: "GerritProject", APIVersion: gerritApi.GroupVersion.String()},
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      strings.ToLower(fmt.Sprintf("%s-%s", gr.Name, backendProject.SlugifyName())),
+			Namespace: gr.Namespace,
+			Labels:    Labels(gr),
+		},
+		Spec: gerritApi.GerritProjectSpec{
+			Name:              backendProject.Name,
+			Parent:            backendProject.Parent,
+			Description:       backendProject.Description,
+			SubmitType:        backendProject.SubmitType,
+			Owners:            backendProject.Owners,
+			RejectEmptyCommit: backendProject.RejectEmptyCommit,
+			PermissionsOnly:   backendProject.PermissionsOnly,
+			CreateEmptyCommit: backendProject.CreateEmptyCommit,
+			Branches:          backendProject.Branches,
+			OwnerName:         gr.Name,
+		},
+	}
+}
+
+// Group returns the GerritGroup of the Gerrit group, the owner group and the subgroups are referenced by UUIDs.
+func Group(gr *gerritApi.Gerrit, backendGroup *gerritClient.Group) *gerritApi.GerritGroup {
+	group := gerritApi.GerritGroup{
+		TypeMeta: metaV1.TypeMeta{Kind: "GerritGroup", APIVersion: gerritApi.GroupVersion.String()},
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      GroupName(gr, backendGroup),
+			Namespace: gr.Namespace,
+			Labels:    Labels(gr),
+		},
+		Spec: gerritApi.GerritGroupSpec{
+			Name:         backendGroup.Name,
+			OwnerName:    gr.Name,
+			Description:  backendGroup.Description,
+			VisibleToAll: backendGroup.Options.VisibleToAll,
+		},
+	}
+
+	if backendGroup.OwnerID != "" && backendGroup.OwnerID != backendGroup.ID {
+		group.Spec.OwnerGroup = backendGroup.OwnerID
+	}
+
+	for i := range backendGroup.Includes {
+		group.Spec.IncludedGroups = append(group.Spec.IncludedGroups, backendGroup.Includes[i].ID)
+	}
+
+	return &group
+}
+
+// GroupMembers returns the GerritGroupMembers of the Gerrit group members.
+func GroupMembers(gr *gerritApi.Gerrit, backendGroup *gerritClient.Group) []gerritApi.GerritGroupMember {
+	members := make([]gerritApi.GerritGroupMember, 0, len(backendGroup.Members))
+
+	for i := range backendGroup.Members {
+		backendMember := &backendGroup.Members[i]
+
+		members = append(members, gerritApi.GerritGroupMember{
+			TypeMeta: metaV1.TypeMeta{Kind: "GerritGroupMember", APIVersion: gerritApi.GroupVersion.String()},
+			ObjectMeta: metaV1.ObjectMeta{
+				Name:      fmt.Sprintf("%s-%d", GroupName(gr, backendGroup), backendMember.AccountID),
+				Namespace: gr.Namespace,
+				Labels:    Labels(gr),
+			},
+			Spec: gerritApi.GerritGroupMemberSpec{
+				GroupID:   backendGroup.ID,
+				AccountID: memberAccount(backendMember),
+				OwnerName: gr.Name,
+			},
+		})
+	}
+
+	return members
+}
+
+// ProjectAccess returns the GerritProjectAccess of the local access sections of the Gerrit project.
+func ProjectAccess(gr *gerritApi.Gerrit, backendProject *gerritClient.Project,
+	access *gerritClient.ProjectAccess,
+) *gerritApi.GerritProjectAccess {
+	prjAccess := gerritApi.GerritProjectAccess{
+		TypeMeta: metaV1.TypeMeta{Kind: "GerritProjectAccess", APIVersion: gerritApi.GroupVersion.String()},
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      strings.ToLower(fmt.Sprintf("%s-%s-access", gr.Name, backendProject.SlugifyName())),
+			Namespace: gr.Namespace,
+			Labels:    Labels(gr),
+		},
+		Spec: gerritApi.GerritProjectAccessSpec{
+			ProjectName: backendProject.Name,
+			OwnerName:   gr.Name,
+			References:  make([]gerritApi.Reference, 0, len(access.Permissions)),
+		},
+	}
+
+	for _, p := range access.Permissions {
+		prjAccess.Spec.References = append(prjAccess.Spec.References, gerritApi.Reference{
+			Pattern:         p.RefPattern,
+			PermissionName:  p.PermissionName,
+			PermissionLabel: p.PermissionLabel,
+			GroupName:       p.GroupName,
+			Action:          p.Action,
+			Force:           p.Force,
+			Min:             p.Min,
+			Max:             p.Max,
+			Exclusive:       p.Exclusive,
+		})
+	}
+
+	return &prjAccess
+}
+
+// GroupName returns the name of the GerritGroup of the Gerrit group.
+func GroupName(gr *gerritApi.Gerrit, backendGroup *gerritClient.Group) string {
+	return strings.ToLower(fmt.Sprintf("%s-%s", gr.Name, backendGroup.SlugifyName()))
+}
+
+// Labels returns the import labels of the Gerrit instance, a new map is returned for every resource.
+func Labels(gr *gerritApi.Gerrit) map[string]string {
+	if len(gr.Spec.Sync.ImportLabels) == 0 {
+		return nil
+	}
+
+	labels := make(map[string]string, len(gr.Spec.Sync.ImportLabels))
+	for k, v := range gr.Spec.Sync.ImportLabels {
+		labels[k] = v
+	}
+
+	return labels
+}
+
+// ProjectLabels returns the labels of the GerritProject imported by the project syncer:
+// the import labels and the parent label, the project selector of the Gerrit instance is matched against them.
+func ProjectLabels(gr *gerritApi.Gerrit, backendProject *gerritClient.Project) map[string]string {
+	labels := Labels(gr)
+
+	parent := strings.Trim(truncate(slug.Make(backendProject.Parent), validation.LabelValueMaxLength), "-")
+	if parent == "" {
+		return labels
+	}
+
+	if labels == nil {
+		labels = make(map[string]string, 1)
+	}
+
+	labels[gerritApi.ProjectParentLabel] = parent
+
+	return labels
+}
+
+func truncate(value string, length int) string {
+	if len(value) > length {
+		return value[:length]
+	}
+
+	return value
+}
+
+func memberAccount(member *gerritClient.GroupMember) string {
+	if member.Username != "" {
+		return member.Username
+	}
+
+	if member.Email != "" {
+		return member.Email
+	}
+
+	return strconv.Itoa(member.AccountID)
+}
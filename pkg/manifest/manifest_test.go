@@ -0,0 +1,76 @@
+package manifest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gerritApi "github.com/epam/edp-gerrit-operator/v2/api/v1"
+	gerritClient "github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit"
+)
+
+func TestManifests(t *testing.T) {
+	t.Parallel()
+
+	gr := &gerritApi.Gerrit{
+		ObjectMeta: metaV1.ObjectMeta{Name: "gerrit", Namespace: "ns"},
+		Spec:       gerritApi.GerritSpec{Sync: gerritApi.GerritSyncSpec{ImportLabels: map[string]string{"app": "gerrit"}}},
+	}
+
+	prj := Project(gr, &gerritClient.Project{Name: "Team/Backend", Parent: "All-Projects"})
+	assert.Equal(t, "gerrit-team-backend", prj.Name)
+	assert.Equal(t, "GerritProject", prj.Kind)
+	assert.Equal(t, "All-Projects", prj.Spec.Parent)
+	assert.Equal(t, "gerrit", prj.Spec.OwnerName)
+	assert.Equal(t, map[string]string{"app": "gerrit"}, prj.Labels)
+
+	backendGroup := &gerritClient.Group{
+		ID:       "devs-uuid",
+		Name:     "Devs",
+		OwnerID:  "admins-uuid",
+		Includes: []gerritClient.Group{{ID: "qa-uuid"}},
+		Members: []gerritClient.GroupMember{
+			{AccountID: 1000, Username: "john"},
+			{AccountID: 1001, Email: "jane@example.com"},
+			{AccountID: 1002},
+		},
+	}
+
+	group := Group(gr, backendGroup)
+	assert.Equal(t, "gerrit-devs", group.Name)
+	assert.Equal(t, "admins-uuid", group.Spec.OwnerGroup)
+	assert.Equal(t, []string{"qa-uuid"}, group.Spec.IncludedGroups)
+
+	members := GroupMembers(gr, backendGroup)
+	assert.Len(t, members, 3)
+	assert.Equal(t, "gerrit-devs-1000", members[0].Name)
+	assert.Equal(t, "devs-uuid", members[0].Spec.GroupID)
+	assert.Equal(t, "john", members[0].Spec.AccountID)
+	assert.Equal(t, "jane@example.com", members[1].Spec.AccountID)
+	assert.Equal(t, "1002", members[2].Spec.AccountID)
+
+	access := ProjectAccess(gr, &gerritClient.Project{Name: "team/backend"}, &gerritClient.ProjectAccess{
+		Permissions: []gerritClient.AccessInfo{{RefPattern: "refs/heads/*", PermissionName: "read", GroupName: "devs-uuid", Action: "ALLOW"}},
+	})
+	assert.Equal(t, "gerrit-team-backend-access", access.Name)
+	assert.Equal(t, []gerritApi.Reference{{Pattern: "refs/heads/*", PermissionName: "read", GroupName: "devs-uuid", Action: "ALLOW"}},
+		access.Spec.References)
+
+	// every resource gets its own labels
+	prj.Labels["app"] = "changed"
+	assert.Equal(t, "gerrit", group.Labels["app"])
+}
+
+func TestProjectLabels(t *testing.T) {
+	t.Parallel()
+
+	gr := &gerritApi.Gerrit{
+		Spec: gerritApi.GerritSpec{Sync: gerritApi.GerritSyncSpec{ImportLabels: map[string]string{"app": "gerrit"}}},
+	}
+
+	assert.Equal(t, map[string]string{"app": "gerrit", gerritApi.ProjectParentLabel: "team-a-backend"},
+		ProjectLabels(gr, &gerritClient.Project{Name: "team-a/backend/api", Parent: "Team-A/Backend"}))
+	assert.Equal(t, map[string]string{"app": "gerrit"}, ProjectLabels(gr, &gerritClient.Project{Name: "All-Projects"}))
+	assert.Nil(t, ProjectLabels(&gerritApi.Gerrit{}, &gerritClient.Project{Name: "All-Projects"}))
+}
@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gerritApi "github.com/epam/edp-gerrit-operator/v2/api/v1"
+)
+
+const (
+	mergeRequestStatePending = "PENDING"
+	mergeRequestStateError   = "ERROR"
+)
+
+var mergeRequestsDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "merge_requests"),
+	"Number of GerritMergeRequests by namespace and change state.",
+	[]string{"namespace", "state"}, nil,
+)
+
+// mergeRequestCollector counts GerritMergeRequests by their state on every scrape.
+type mergeRequestCollector struct {
+	reader client.Reader
+	states map[string]bool
+	log    logr.Logger
+}
+
+// NewMergeRequestCollector returns a collector of the GerritMergeRequest state gauges.
+// The states that are not in the given list, e.g. the error messages, are reported as ERROR
+// and the merge requests without a change yet are reported as PENDING.
+func NewMergeRequestCollector(reader client.Reader, log logr.Logger, states ...string) prometheus.Collector {
+	knownStates := make(map[string]bool, len(states))
+	for _, s := range states {
+		knownStates[s] = true
+	}
+
+	return &mergeRequestCollector{
+		reader: reader,
+		states: knownStates,
+		log:    log,
+	}
+}
+
+func (c *mergeRequestCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- mergeRequestsDesc
+}
+
+func (c *mergeRequestCollector) Collect(ch chan<- prometheus.Metric) {
+	var list gerritApi.GerritMergeRequestList
+	if err := c.reader.List(context.Background(), &list); err != nil {
+		c.log.Error(err, "unable to list gerrit merge requests for metrics")
+		return
+	}
+
+	type key struct{ namespace, state string }
+
+	counts := make(map[key]int)
+
+	for i := range list.Items {
+		counts[key{namespace: list.Items[i].Namespace, state: c.state(&list.Items[i])}]++
+	}
+
+	for k, count := range counts {
+		ch <- prometheus.MustNewConstMetric(mergeRequestsDesc, prometheus.GaugeValue, float64(count), k.namespace, k.state)
+	}
+}
+
+func (c *mergeRequestCollector) state(mr *gerritApi.GerritMergeRequest) string {
+	switch {
+	case c.states[mr.Status.Value]:
+		return mr.Status.Value
+	case mr.Status.ChangeID == "" && mr.Status.Value == "":
+		return mergeRequestStatePending
+	default:
+		return mergeRequestStateError
+	}
+}
@@ -0,0 +1,159 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlMetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	namespace = "gerrit_operator"
+
+	ProtocolREST = "rest"
+	ProtocolSSH  = "ssh"
+
+	// CodeError is used as the code of the requests that failed without a response.
+	CodeError = "error"
+)
+
+var (
+	gerritRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "gerrit_requests_total",
+		Help:      "Number of REST and SSH requests to Gerrit by operation, Gerrit instance and status code.",
+	}, []string{"protocol", "operation", "gerrit", "code"})
+
+	gerritRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "gerrit_request_duration_seconds",
+		Help:      "Latency of REST and SSH requests to Gerrit.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"protocol", "operation", "gerrit"})
+
+	projectSyncDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "project_sync_duration_seconds",
+		Help:      "Duration of the Gerrit projects sync by Gerrit instance and result.",
+		Buckets:   []float64{0.5, 1, 5, 10, 30, 60, 120, 300, 600},
+	}, []string{"gerrit", "result"})
+
+	projectsImportedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "projects_imported_total",
+		Help:      "Number of Gerrit projects imported into GerritProject CRs by the syncer.",
+	}, []string{"gerrit"})
+
+	credentialCheckFailuresTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "credential_check_failures_total",
+		Help:      "Number of failed Gerrit credential checks by Gerrit instance and status code.",
+	}, []string{"gerrit", "code"})
+)
+
+func init() {
+	ctrlMetrics.Registry.MustRegister(
+		gerritRequestsTotal,
+		gerritRequestDuration,
+		projectSyncDuration,
+		projectsImportedTotal,
+		credentialCheckFailuresTotal,
+	)
+}
+
+// ObserveGerritRequest records a REST or SSH request to Gerrit.
+func ObserveGerritRequest(protocol, operation, gerrit, code string, duration time.Duration) {
+	gerritRequestsTotal.WithLabelValues(protocol, operation, gerrit, code).Inc()
+	gerritRequestDuration.WithLabelValues(protocol, operation, gerrit).Observe(duration.Seconds())
+}
+
+// ObserveProjectSync records a sync of Gerrit projects.
+func ObserveProjectSync(gerrit string, duration time.Duration, err error) {
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+
+	projectSyncDuration.WithLabelValues(gerrit, result).Observe(duration.Seconds())
+}
+
+// AddProjectsImported counts the projects imported by the syncer.
+func AddProjectsImported(gerrit string, count int) {
+	projectsImportedTotal.WithLabelValues(gerrit).Add(float64(count))
+}
+
+// IncCredentialCheckFailures counts a failed credential check, code is the HTTP status code or CodeError.
+func IncCredentialCheckFailures(gerrit, code string) {
+	credentialCheckFailuresTotal.WithLabelValues(gerrit, code).Inc()
+}
+
+// roundTripper records the metrics of the Gerrit REST API requests.
+type roundTripper struct {
+	next     http.RoundTripper
+	gerrit   string
+	basePath string
+}
+
+// NewRoundTripper wraps the transport to record the metrics of the requests to the Gerrit instance.
+// The base path is trimmed from the request path to get the operation name.
+func NewRoundTripper(next http.RoundTripper, gerrit, basePath string) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &roundTripper{
+		next:     next,
+		gerrit:   gerrit,
+		basePath: strings.Trim(basePath, "/"),
+	}
+}
+
+func (t *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+
+	resp, err := t.next.RoundTrip(req)
+
+	code := CodeError
+	if err == nil {
+		code = strconv.Itoa(resp.StatusCode)
+	}
+
+	ObserveGerritRequest(ProtocolREST, RESTOperation(req.Method, req.URL.EscapedPath(), t.basePath), t.gerrit, code,
+		time.Since(start))
+
+	return resp, err
+}
+
+// RESTOperation returns the operation name of the REST request without the entity IDs,
+// e.g. "GET projects/branches" for "GET /a/projects/team%2Fbackend/branches/master".
+// Gerrit REST API paths alternate collections and IDs, so only the collections are kept.
+func RESTOperation(method, path, basePath string) string {
+	path = strings.Trim(path, "/")
+	if basePath != "" && (path == basePath || strings.HasPrefix(path, basePath+"/")) {
+		path = strings.TrimPrefix(path, basePath)
+	}
+
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	collections := make([]string, 0, len(segments)/2+1)
+
+	for i := 0; i < len(segments); i += 2 {
+		if segments[i] != "" {
+			collections = append(collections, segments[i])
+		}
+	}
+
+	return method + " " + strings.Join(collections, "/")
+}
+
+// SSHOperation returns the operation name of the SSH command, e.g. "gerrit create-account".
+func SSHOperation(command string) string {
+	fields := strings.Fields(command)
+	if len(fields) > 2 {
+		fields = fields[:2]
+	}
+
+	return strings.Join(fields, " ")
+}
@@ -0,0 +1,114 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilRuntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	commonmock "github.com/epam/edp-common/pkg/mock"
+
+	gerritApi "github.com/epam/edp-gerrit-operator/v2/api/v1"
+)
+
+func TestRESTOperation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		basePath string
+		want     string
+	}{
+		{name: "collection", method: http.MethodGet, path: "/projects/", want: "GET projects"},
+		{name: "entity", method: http.MethodDelete, path: "/projects/team%2Fbackend", want: "DELETE projects"},
+		{name: "sub collection", method: http.MethodGet, path: "/a/projects/team%2Fbackend/branches/master", basePath: "a",
+			want: "GET projects/branches"},
+		{name: "base path is not a prefix of the collection", method: http.MethodGet, path: "/a/accounts/self", basePath: "a",
+			want: "GET accounts"},
+		{name: "collection with the base path name", method: http.MethodGet, path: "/accounts/self", basePath: "a",
+			want: "GET accounts"},
+		{name: "config", method: http.MethodGet, path: "/gerrit/a/config/server/summary", basePath: "gerrit/a",
+			want: "GET config/summary"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, RESTOperation(tt.method, tt.path, tt.basePath))
+		})
+	}
+}
+
+func TestSSHOperation(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "gerrit create-account", SSHOperation("gerrit create-account john --email john@example.com"))
+	assert.Equal(t, "gerrit version", SSHOperation(" gerrit version "))
+}
+
+func TestRoundTripper(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	cl := http.Client{Transport: NewRoundTripper(nil, "round-tripper", "/a/")}
+
+	resp, err := cl.Get(server.URL + "/a/groups/devs/members")
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	assert.Equal(t, float64(1),
+		testutil.ToFloat64(gerritRequestsTotal.WithLabelValues(ProtocolREST, "GET groups/members", "round-tripper", "404")))
+
+	_, err = cl.Get("http://127.0.0.1:1/a/groups/")
+	require.Error(t, err)
+
+	assert.Equal(t, float64(1),
+		testutil.ToFloat64(gerritRequestsTotal.WithLabelValues(ProtocolREST, "GET groups", "round-tripper", CodeError)))
+}
+
+func TestMergeRequestCollector(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilRuntime.Must(gerritApi.AddToScheme(scheme))
+
+	mergeRequest := func(ns, name string, status gerritApi.GerritMergeRequestStatus) *gerritApi.GerritMergeRequest {
+		return &gerritApi.GerritMergeRequest{
+			ObjectMeta: metaV1.ObjectMeta{Namespace: ns, Name: name},
+			Status:     status,
+		}
+	}
+
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(
+		mergeRequest("ns1", "mr1", gerritApi.GerritMergeRequestStatus{Value: "NEW", ChangeID: "1"}),
+		mergeRequest("ns1", "mr2", gerritApi.GerritMergeRequestStatus{Value: "NEW", ChangeID: "2"}),
+		mergeRequest("ns1", "mr3", gerritApi.GerritMergeRequestStatus{Value: "unable to create change"}),
+		mergeRequest("ns2", "mr4", gerritApi.GerritMergeRequestStatus{Value: "MERGED", ChangeID: "4"}),
+		mergeRequest("ns2", "mr5", gerritApi.GerritMergeRequestStatus{}),
+	).Build()
+
+	collector := NewMergeRequestCollector(cl, commonmock.NewLogr(), "NEW", "MERGED", "ABANDONED")
+
+	expected := `
+# HELP gerrit_operator_merge_requests Number of GerritMergeRequests by namespace and change state.
+# TYPE gerrit_operator_merge_requests gauge
+gerrit_operator_merge_requests{namespace="ns1",state="ERROR"} 1
+gerrit_operator_merge_requests{namespace="ns1",state="NEW"} 2
+gerrit_operator_merge_requests{namespace="ns2",state="MERGED"} 1
+gerrit_operator_merge_requests{namespace="ns2",state="PENDING"} 1
+`
+
+	require.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(expected)))
+}
@@ -0,0 +1,84 @@
+package gerrit
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	gerritClient "github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit"
+	"github.com/epam/edp-gerrit-operator/v2/pkg/service/gerrit/spec"
+)
+
+const (
+	allProjectsConfigFile    = "gerrit.config"
+	allProjectsConfigMessage = "Uploaded KRCI Gerrit config"
+
+	webhooksConfig = `[remote "changemerged"]
+  url = http://el-edp-gerrit:8080
+  event = change-merged
+
+[remote "patchsetcreated"]
+  url = http://el-edp-gerrit:8080
+  event = patchset-created
+
+[remote "commentadded"]
+  url = http://el-edp-gerrit:8080
+  event = comment-added
+`
+)
+
+// initAllProjects uploads the default project.config of All-Projects together with the groups it refers to
+// and the webhooks.config. The configuration is pushed to refs/meta/config through the Gerrit receive path.
+func (s ComponentService) initAllProjects(configsPath string) error {
+	projectConfig, err := os.ReadFile(filepath.Join(configsPath, allProjectsConfigFile))
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", allProjectsConfigFile, err)
+	}
+
+	groups := []string{"global:Change-Owner\tChange Owner"}
+
+	for _, name := range []string{
+		spec.GerritCIToolsGroupName,
+		spec.GerritProjectBootstrappersGroupName,
+		spec.GerritProjectDevelopersGroupName,
+		spec.GerritReadOnlyGroupName,
+	} {
+		gr, err := s.gerritClient.GetGroup(name)
+		if err != nil {
+			return fmt.Errorf("failed to get Gerrit group %q: %w", name, err)
+		}
+
+		groups = append(groups, gr.ID+"\t"+name)
+	}
+
+	return s.gerritClient.UpdateMetaConfig(allProjects, allProjectsConfigMessage, map[string]gerritClient.MetaConfigFile{
+		"project.config":  func(string) string { return string(projectConfig) },
+		"groups":          func(current string) string { return appendGroups(current, groups) },
+		"webhooks.config": func(string) string { return webhooksConfig },
+	})
+}
+
+// appendGroups adds the "<UUID>\t<name>" lines of the groups that are missing in the groups file.
+func appendGroups(current string, groups []string) string {
+	known := make(map[string]bool)
+
+	for _, line := range strings.Split(current, "\n") {
+		if uuid, _, found := strings.Cut(line, "\t"); found {
+			known[uuid] = true
+		}
+	}
+
+	if current != "" && !strings.HasSuffix(current, "\n") {
+		current += "\n"
+	}
+
+	for _, group := range groups {
+		uuid, _, _ := strings.Cut(group, "\t")
+		if !known[uuid] {
+			current += group + "\n"
+		}
+	}
+
+	return current
+}
@@ -0,0 +1,61 @@
+package gerrit
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit"
+	gerritClientMocks "github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit/mocks"
+	"github.com/epam/edp-gerrit-operator/v2/pkg/service/gerrit/spec"
+)
+
+func TestComponentService_initAllProjects(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "gerrit.config"), []byte("[receive]\n"), 0o600))
+
+	cl := gerritClientMocks.NewClientInterface(t)
+	cl.On("GetGroup", spec.GerritCIToolsGroupName).Return(&gerrit.Group{ID: "ci"}, nil)
+	cl.On("GetGroup", spec.GerritProjectBootstrappersGroupName).Return(&gerrit.Group{ID: "pb"}, nil)
+	cl.On("GetGroup", spec.GerritProjectDevelopersGroupName).Return(&gerrit.Group{ID: "dev"}, nil)
+	cl.On("GetGroup", spec.GerritReadOnlyGroupName).Return(&gerrit.Group{ID: "ro"}, nil)
+	cl.On("UpdateMetaConfig", "All-Projects", allProjectsConfigMessage, mock.Anything).
+		Run(func(args mock.Arguments) {
+			files, ok := args.Get(2).(map[string]gerrit.MetaConfigFile)
+			require.True(t, ok)
+
+			assert.Equal(t, "[receive]\n", files["project.config"](""))
+			assert.Equal(t, webhooksConfig, files["webhooks.config"](""))
+			assert.Equal(t, "# UUID\tGroup Name\nci\tContinuous Integration Tools\n"+
+				"global:Change-Owner\tChange Owner\npb\tProject Bootstrappers\ndev\tDevelopers\nro\tReadOnly\n",
+				files["groups"]("# UUID\tGroup Name\nci\tContinuous Integration Tools"))
+		}).
+		Return(nil)
+
+	s := ComponentService{gerritClient: cl}
+
+	require.NoError(t, s.initAllProjects(dir))
+}
+
+func TestComponentService_initAllProjects_GetGroupErr(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "gerrit.config"), []byte("[receive]\n"), 0o600))
+
+	cl := gerritClientMocks.NewClientInterface(t)
+	cl.On("GetGroup", spec.GerritCIToolsGroupName).Return(nil, errors.New("forbidden"))
+
+	s := ComponentService{gerritClient: cl}
+
+	err := s.initAllProjects(dir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `failed to get Gerrit group "Continuous Integration Tools"`)
+}
@@ -0,0 +1,131 @@
+package gerrit
+
+import (
+	"fmt"
+
+	gerritApi "github.com/epam/edp-gerrit-operator/v2/api/v1"
+	gerritClient "github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit"
+)
+
+const allProjects = "All-Projects"
+
+// applyBootstrap creates the groups and adds the members and the All-Projects access rights of spec.bootstrap.
+// It is safe to call it on every reconciliation: only the missing or changed entities are written to Gerrit.
+func (s ComponentService) applyBootstrap(instance *gerritApi.Gerrit) error {
+	bootstrap := instance.Spec.Bootstrap
+	if bootstrap == nil {
+		return nil
+	}
+
+	log.Info("applying bootstrap configuration", "gerrit", instance.Name)
+
+	for i := range bootstrap.Groups {
+		if err := s.applyBootstrapGroup(&bootstrap.Groups[i]); err != nil {
+			return err
+		}
+	}
+
+	return s.applyAllProjectsAccess(bootstrap.AllProjectsAccess)
+}
+
+func (s ComponentService) applyBootstrapGroup(group *gerritApi.GerritBootstrapGroup) error {
+	gr, err := s.gerritClient.GetGroup(group.Name)
+
+	switch {
+	case gerritClient.IsErrDoesNotExist(err):
+		gr, err = s.gerritClient.CreateGroup(group.Name, group.Description, group.VisibleToAll)
+		if err != nil {
+			return fmt.Errorf("failed to create Gerrit group %q: %w", group.Name, err)
+		}
+	case err != nil:
+		return fmt.Errorf("failed to get Gerrit group %q: %w", group.Name, err)
+	case gr.Description != group.Description || gr.Options.VisibleToAll != group.VisibleToAll:
+		if err = s.gerritClient.UpdateGroup(gr.ID, group.Description, group.VisibleToAll); err != nil {
+			return fmt.Errorf("failed to update Gerrit group %q: %w", group.Name, err)
+		}
+	}
+
+	for _, member := range group.Members {
+		if err = s.gerritClient.AddUserToGroup(gr.ID, member); err != nil {
+			return fmt.Errorf("failed to add %q to Gerrit group %q: %w", member, group.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// applyAllProjectsAccess adds the access rules that are missing in All-Projects.
+// The group names are resolved to UUIDs, the names of the groups that are not found are used as UUIDs,
+// so the system groups can be set by their UUIDs, e.g. global:Project-Owners.
+func (s ComponentService) applyAllProjectsAccess(refs []gerritApi.Reference) error {
+	if len(refs) == 0 {
+		return nil
+	}
+
+	live, err := s.gerritClient.GetAccessRights(allProjects)
+	if err != nil {
+		return fmt.Errorf("failed to get %s access rights: %w", allProjects, err)
+	}
+
+	liveRules := make(map[string]gerritClient.AccessInfo, len(live.Permissions))
+	for _, rule := range live.Permissions {
+		liveRules[rule.Key()] = rule
+	}
+
+	groupIDs := make(map[string]string)
+
+	var missing []gerritClient.AccessInfo
+
+	for i := range refs {
+		groupID, ok := groupIDs[refs[i].GroupName]
+		if !ok {
+			groupID, err = s.bootstrapGroupID(refs[i].GroupName)
+			if err != nil {
+				return err
+			}
+
+			groupIDs[refs[i].GroupName] = groupID
+		}
+
+		rule := gerritClient.AccessInfo{
+			RefPattern:      refs[i].Pattern,
+			PermissionName:  refs[i].PermissionName,
+			PermissionLabel: refs[i].PermissionLabel,
+			GroupName:       groupID,
+			Action:          refs[i].Action,
+			Force:           refs[i].Force,
+			Min:             refs[i].Min,
+			Max:             refs[i].Max,
+			Exclusive:       refs[i].Exclusive,
+		}
+
+		if liveRule, ok := liveRules[rule.Key()]; ok && len(rule.Diff(&liveRule)) == 0 {
+			continue
+		}
+
+		missing = append(missing, rule)
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	if err = s.gerritClient.AddAccessRights(allProjects, missing); err != nil {
+		return fmt.Errorf("failed to add %s access rights: %w", allProjects, err)
+	}
+
+	return nil
+}
+
+func (s ComponentService) bootstrapGroupID(name string) (string, error) {
+	id, err := gerritClient.ResolveGroupUUID(s.gerritClient, name)
+	if gerritClient.IsErrDoesNotExist(err) {
+		return name, nil
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("failed to get Gerrit group %q: %w", name, err)
+	}
+
+	return id, nil
+}
@@ -0,0 +1,96 @@
+package gerrit
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gerritApi "github.com/epam/edp-gerrit-operator/v2/api/v1"
+	"github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit"
+	gerritClientMocks "github.com/epam/edp-gerrit-operator/v2/pkg/client/gerrit/mocks"
+)
+
+func TestComponentService_applyBootstrap(t *testing.T) {
+	t.Parallel()
+
+	instance := CreateGerritInstance()
+	instance.Spec.Bootstrap = &gerritApi.GerritBootstrap{
+		Groups: []gerritApi.GerritBootstrapGroup{
+			{Name: "Developers", Description: "Grant access to all projects in Gerrit", VisibleToAll: true, Members: []string{"edp-ci"}},
+			{Name: "ReadOnly", VisibleToAll: true},
+			{Name: "Reviewers", Description: "new", VisibleToAll: true},
+		},
+		AllProjectsAccess: []gerritApi.Reference{
+			{Pattern: "refs/*", PermissionName: "read", GroupName: "Developers", Action: "ALLOW"},
+			{Pattern: "refs/*", PermissionName: "read", GroupName: "ReadOnly", Action: "ALLOW"},
+			{Pattern: "refs/heads/*", PermissionName: "label-Code-Review", PermissionLabel: "Code-Review",
+				GroupName: "global:Project-Owners", Action: "ALLOW", Min: -2, Max: 2},
+		},
+	}
+
+	cl := gerritClientMocks.NewClientInterface(t)
+
+	cl.On("GetGroup", "Developers").Return(&gerrit.Group{ID: "dev-id", Description: "Grant access to all projects in Gerrit",
+		Options: gerrit.GroupOptions{VisibleToAll: true}}, nil)
+	cl.On("AddUserToGroup", "dev-id", "edp-ci").Return(nil)
+	cl.On("GetGroup", "ReadOnly").Return(&gerrit.Group{ID: "ro-id", Description: "old"}, nil)
+	cl.On("UpdateGroup", "ro-id", "", true).Return(nil)
+	cl.On("GetGroup", "Reviewers").Return(nil, gerrit.DoesNotExistError("group does not exist")).Once()
+	cl.On("CreateGroup", "Reviewers", "new", true).Return(&gerrit.Group{ID: "rev-id"}, nil)
+
+	cl.On("GetAccessRights", "All-Projects").Return(&gerrit.ProjectAccess{Permissions: []gerrit.AccessInfo{
+		{RefPattern: "refs/*", PermissionName: "read", GroupName: "dev-id", Action: "ALLOW"},
+		{RefPattern: "refs/*", PermissionName: "read", GroupName: "ro-id", Action: "DENY"},
+	}}, nil)
+	cl.On("AddAccessRights", "All-Projects", []gerrit.AccessInfo{
+		{RefPattern: "refs/*", PermissionName: "read", GroupName: "ro-id", Action: "ALLOW"},
+		{RefPattern: "refs/heads/*", PermissionName: "label-Code-Review", PermissionLabel: "Code-Review",
+			GroupName: "global:Project-Owners", Action: "ALLOW", Min: -2, Max: 2},
+	}).Return(nil)
+
+	s := ComponentService{gerritClient: cl}
+
+	require.NoError(t, s.applyBootstrap(instance))
+}
+
+func TestComponentService_applyBootstrap_AccessUpToDate(t *testing.T) {
+	t.Parallel()
+
+	instance := CreateGerritInstance()
+	instance.Spec.Bootstrap = &gerritApi.GerritBootstrap{
+		AllProjectsAccess: []gerritApi.Reference{
+			{Pattern: "refs/*", PermissionName: "read", GroupName: "Developers", Action: "ALLOW"},
+		},
+	}
+
+	cl := gerritClientMocks.NewClientInterface(t)
+	cl.On("GetGroup", "Developers").Return(&gerrit.Group{ID: "dev-id"}, nil)
+	cl.On("GetAccessRights", "All-Projects").Return(&gerrit.ProjectAccess{Permissions: []gerrit.AccessInfo{
+		{RefPattern: "refs/*", PermissionName: "read", GroupName: "dev-id", Action: "ALLOW"},
+	}}, nil)
+
+	s := ComponentService{gerritClient: cl}
+
+	require.NoError(t, s.applyBootstrap(instance))
+}
+
+func TestComponentService_applyBootstrap_CreateGroupErr(t *testing.T) {
+	t.Parallel()
+
+	instance := CreateGerritInstance()
+	instance.Spec.Bootstrap = &gerritApi.GerritBootstrap{
+		Groups: []gerritApi.GerritBootstrapGroup{{Name: "Developers"}},
+	}
+
+	cl := gerritClientMocks.NewClientInterface(t)
+	cl.On("GetGroup", "Developers").Return(nil, gerrit.DoesNotExistError("group does not exist"))
+	cl.On("CreateGroup", "Developers", "", false).Return(nil, errors.New("forbidden"))
+
+	s := ComponentService{gerritClient: cl}
+
+	err := s.applyBootstrap(instance)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `failed to create Gerrit group "Developers"`)
+}
@@ -6,14 +6,14 @@ import (
 	"net/http"
 	"path/filepath"
 	"reflect"
-	"strings"
+	"sync"
 
 	"github.com/dchest/uniuri"
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	coreV1Api "k8s.io/api/core/v1"
-	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -41,9 +41,11 @@ type Interface interface {
 	IsDeploymentReady(instance *gerritApi.Gerrit) (bool, error)
 	Configure(instance *gerritApi.Gerrit) (*gerritApi.Gerrit, bool, error)
 	ExposeConfiguration(ctx context.Context, instance *gerritApi.Gerrit) (*gerritApi.Gerrit, error)
+	ApplySiteConfig(ctx context.Context, instance *gerritApi.Gerrit) (bool, error)
 	GetGerritSSHUrl(instance *gerritApi.Gerrit) (string, error)
 	GetServicePort(instance *gerritApi.Gerrit) (int32, error)
 	GetRestClient(gerritInstance *gerritApi.Gerrit) (gerritClient.ClientInterface, error)
+	NewAdminClient(gerritInstance *gerritApi.Gerrit) (gerritClient.ClientInterface, error)
 	GetGitClient(ctx context.Context, child Child, workDir string) (*git.Client, error)
 }
 
@@ -58,6 +60,18 @@ func IsErrUserNotFound(err error) bool {
 	return errors.As(err, &notFoundError)
 }
 
+// UnavailableError means that Gerrit does not respond yet, e.g. it is restarted or rolled out.
+type UnavailableError string
+
+func (e UnavailableError) Error() string {
+	return string(e)
+}
+
+func IsErrUnavailable(err error) bool {
+	var unavailableError UnavailableError
+	return errors.As(err, &unavailableError)
+}
+
 // ComponentService implements gerrit.Interface.
 type ComponentService struct {
 	// Providing Gerrit EDP component implementation through the interface (platform abstract)
@@ -65,9 +79,16 @@ type ComponentService struct {
 	client               client.Client
 	k8sScheme            *runtime.Scheme
 	gerritClient         gerritClient.ClientInterface
+	restClients          *restClients
 	runningInClusterFunc func() bool
 }
 
+// restClients caches the REST clients of the Gerrit instances, a client is never shared between the instances.
+type restClients struct {
+	mu      sync.Mutex
+	clients map[types.NamespacedName]gerritClient.ClientInterface
+}
+
 // NewComponentService returns a new instance of a gerrit.Service type.
 func NewComponentService(ps platform.PlatformService, kc client.Client, ks *runtime.Scheme) Interface {
 	return ComponentService{
@@ -76,6 +97,7 @@ func NewComponentService(ps platform.PlatformService, kc client.Client, ks *runt
 		k8sScheme:            ks,
 		runningInClusterFunc: platformHelper.RunningInCluster,
 		gerritClient:         &gerritClient.Client{},
+		restClients:          &restClients{clients: make(map[types.NamespacedName]gerritClient.ClientInterface)},
 	}
 }
 
@@ -87,6 +109,25 @@ func (s ComponentService) runningInCluster() bool {
 	return false
 }
 
+// checkCredentials returns the status code of the Gerrit credentials check,
+// an UnavailableError is returned if Gerrit is not reachable or answers with a server error.
+func (s ComponentService) checkCredentials() (int, error) {
+	status, err := s.gerritClient.CheckCredentials()
+	if err != nil {
+		return 0, UnavailableError(fmt.Sprintf("Gerrit is unavailable: %v", err))
+	}
+
+	if status >= http.StatusInternalServerError {
+		return 0, UnavailableError(fmt.Sprintf("Gerrit is unavailable: status code %d", status))
+	}
+
+	if status != http.StatusUnauthorized && status >= http.StatusBadRequest {
+		return 0, fmt.Errorf("failed to check credentials in Gerrit: status code %d", status)
+	}
+
+	return status, nil
+}
+
 // IsDeploymentReady check if DC for Gerrit is ready.
 func (s ComponentService) IsDeploymentReady(instance *gerritApi.Gerrit) (bool, error) {
 	isReady, err := s.PlatformService.IsDeploymentReady(instance)
@@ -109,9 +150,9 @@ func (s ComponentService) Configure(instance *gerritApi.Gerrit) (*gerritApi.Gerr
 		return instance, false, errors.Wrap(err, "unable to get executable file path")
 	}
 
-	gerritScriptsPath := platformHelper.LocalScriptsRelativePath
+	gerritConfigsPath := platformHelper.LocalConfigsAbsolutePath
 	if !s.runningInCluster() {
-		gerritScriptsPath = filepath.FromSlash(fmt.Sprintf("%v/../%v/%v", executableFilePath, platformHelper.LocalConfigsRelativePath, platformHelper.DefaultScriptsDirectory))
+		gerritConfigsPath = filepath.FromSlash(fmt.Sprintf("%v/../%v", executableFilePath, platformHelper.LocalConfigsRelativePath))
 	}
 
 	err = s.PlatformService.CreateSecret(
@@ -166,11 +207,6 @@ func (s ComponentService) Configure(instance *gerritApi.Gerrit) (*gerritApi.Gerr
 		return instance, false, errors.Wrapf(err, "failed to get Gerrit admin password from secret for %s/%s", instance.Namespace, instance.Name)
 	}
 
-	podList, err := s.PlatformService.GetPods(instance.Namespace, &metaV1.ListOptions{LabelSelector: "app=" + instance.Name})
-	if err != nil || len(podList.Items) != 1 {
-		return instance, false, errors.Wrapf(err, "unable to determine Gerrit pod name: %v", len(podList.Items))
-	}
-
 	_, gerritAdminPublicKey, err := s.createSSHKeyPairs(instance, instance.Name+admin)
 	if err != nil {
 		return instance, false, errors.Wrapf(err, "failed to create Gerrit admin SSH keypair %v/%v", instance.Namespace, instance.Name)
@@ -181,9 +217,9 @@ func (s ComponentService) Configure(instance *gerritApi.Gerrit) (*gerritApi.Gerr
 		return instance, false, errors.Wrapf(err, "failed to initialize Gerrit REST client for %v/%v", instance.Namespace, instance.Name)
 	}
 
-	status, err := s.gerritClient.CheckCredentials()
-	if (status != http.StatusUnauthorized && status >= http.StatusBadRequest && status <= http.StatusHTTPVersionNotSupported) || err != nil {
-		return instance, false, errors.Wrap(err, "failed to check credentials in Gerrit")
+	status, err := s.checkCredentials()
+	if err != nil {
+		return instance, false, err
 	}
 
 	if status == http.StatusUnauthorized {
@@ -193,22 +229,17 @@ func (s ComponentService) Configure(instance *gerritApi.Gerrit) (*gerritApi.Gerr
 			return instance, false, errors.Wrapf(err, "Failed to initialize Gerrit REST client for %v/%v", instance.Namespace, instance.Name)
 		}
 
-		status, err = s.gerritClient.CheckCredentials()
-		if (status != http.StatusUnauthorized && status >= http.StatusBadRequest && status <= http.StatusHTTPVersionNotSupported) || err != nil {
-			return instance, false, errors.Wrap(err, "failed to check credentials in Gerrit")
+		status, err = s.checkCredentials()
+		if err != nil {
+			return instance, false, err
 		}
 
 		if status == http.StatusUnauthorized {
-			var adminInstance *gerritApi.Gerrit
-
-			adminInstance, err = s.gerritClient.InitAdminUser(instance, s.PlatformService, gerritScriptsPath, podList.Items[0].Name,
-				string(gerritAdminPublicKey))
-			if err != nil {
-				return adminInstance, false, errors.Wrap(err, "failed to initialize Gerrit Admin User")
-			}
+			// the admin account is created with the default password by the bootstrap hook when Gerrit starts
+			return instance, false, errors.New("Gerrit admin user is not initialized")
 		}
 
-		err = s.setGerritAdminUserPassword(instance, gerritUrl, gerritAdminPassword, gerritApiUrl, sshPortService)
+		err = s.setGerritAdminUserPassword(instance, string(gerritAdminPublicKey), gerritAdminPassword, gerritApiUrl)
 		if err != nil {
 			return instance, false, err
 		}
@@ -221,19 +252,16 @@ func (s ComponentService) Configure(instance *gerritApi.Gerrit) (*gerritApi.Gerr
 		return instance, false, fmt.Errorf("failed to get secret %q for gerrit: %w", gerritSecretName, err)
 	}
 
-	podName := podList.Items[0].Name
-	command := []string{"/bin/sh", "-c", "chown -R gerrit2:gerrit2 /var/gerrit/review_site"}
-
-	_, _, err = s.PlatformService.ExecInPod(instance.Namespace, podName, command)
-	if err != nil {
-		return instance, false, fmt.Errorf("failed to exec command %q in a pod %q: %w", strings.Join(command, " "), podName, err)
-	}
-
 	err = s.gerritClient.InitNewSshClient(spec.GerritDefaultAdminUser, gerritAdminSshKeys[rsaID], gerritUrl, sshPortService)
 	if err != nil {
 		return instance, false, fmt.Errorf("failed to init ssh client for gerrit: %w", err)
 	}
 
+	// the declared bootstrap configuration is applied with the REST API in ExposeConfiguration
+	if instance.Spec.Bootstrap != nil {
+		return instance, false, nil
+	}
+
 	ciToolsStatus, err := s.gerritClient.CheckGroup(spec.GerritCIToolsGroupName)
 	if err != nil {
 		return instance, false, fmt.Errorf("failed to check Gerrit group %q: %w", spec.GerritCIToolsGroupName, err)
@@ -245,11 +273,6 @@ func (s ComponentService) Configure(instance *gerritApi.Gerrit) (*gerritApi.Gerr
 	}
 
 	if *ciToolsStatus == http.StatusNotFound || *projectBootstrappersStatus == http.StatusNotFound {
-		err = s.gerritClient.InitNewSshClient(spec.GerritDefaultAdminUser, gerritAdminSshKeys[rsaID], gerritUrl, sshPortService)
-		if err != nil {
-			return instance, false, fmt.Errorf("failed to init ssh client for gerrit: %w", err)
-		}
-
 		const errTemplate = "failed to create Gerrit group %q: %w"
 
 		_, err = s.gerritClient.CreateGroup(spec.GerritCIToolsGroupName, spec.GerritCIToolsGroupDescription,
@@ -275,8 +298,7 @@ func (s ComponentService) Configure(instance *gerritApi.Gerrit) (*gerritApi.Gerr
 			return instance, false, fmt.Errorf(errTemplate, spec.GerritReadOnlyGroupName, err)
 		}
 
-		err = s.gerritClient.InitAllProjects(instance, s.PlatformService, gerritScriptsPath, podList.Items[0].Name,
-			string(gerritAdminPublicKey))
+		err = s.initAllProjects(gerritConfigsPath)
 		if err != nil {
 			return instance, false, errors.Wrapf(err, "failed to initialize Gerrit All-Projects project")
 		}
@@ -372,6 +394,10 @@ func (s ComponentService) ExposeConfiguration(ctx context.Context, instance *ger
 		return nil, err
 	}
 
+	if err = s.applyBootstrap(instance); err != nil {
+		return instance, err
+	}
+
 	err = s.client.Update(ctx, instance)
 	if err != nil {
 		return nil, errors.Wrap(err, "couldn't update project")
@@ -414,16 +440,49 @@ func (s ComponentService) ExposeConfiguration(ctx context.Context, instance *ger
 	return instance, nil
 }
 
+// GetRestClient returns the REST client of the Gerrit admin user for the Gerrit instance.
+// The client is initialized once per instance and is reused by the next calls for the same instance.
 func (s ComponentService) GetRestClient(gerritInstance *gerritApi.Gerrit) (gerritClient.ClientInterface, error) {
-	if s.gerritClient.Resty() != nil {
-		return s.gerritClient, nil
+	key := types.NamespacedName{Namespace: gerritInstance.Namespace, Name: gerritInstance.Name}
+
+	if s.restClients != nil {
+		s.restClients.mu.Lock()
+		defer s.restClients.mu.Unlock()
+
+		if cl, ok := s.restClients.clients[key]; ok {
+			return cl, nil
+		}
+	}
+
+	cs := s
+	cs.gerritClient = &gerritClient.Client{}
+
+	if err := cs.initRestClient(gerritInstance); err != nil {
+		return nil, errors.Wrap(err, "unable to init gerrit rest client")
+	}
+
+	if s.restClients != nil {
+		s.restClients.clients[key] = cs.gerritClient
 	}
 
-	if err := s.initRestClient(gerritInstance); err != nil {
+	return cs.gerritClient, nil
+}
+
+// NewAdminClient returns a new client with the REST and SSH connections of the Gerrit admin user.
+// Unlike GetRestClient, the client is not cached, so it can be used concurrently with the reconciliations.
+func (s ComponentService) NewAdminClient(gerritInstance *gerritApi.Gerrit) (gerritClient.ClientInterface, error) {
+	cs := s
+	cs.gerritClient = &gerritClient.Client{}
+
+	if err := cs.initRestClient(gerritInstance); err != nil {
 		return nil, errors.Wrap(err, "unable to init gerrit rest client")
 	}
 
-	return s.gerritClient, nil
+	if err := cs.initSSHClient(gerritInstance); err != nil {
+		return nil, errors.Wrap(err, "unable to init gerrit ssh client")
+	}
+
+	return cs.gerritClient, nil
 }
 
 func (s *ComponentService) initRestClient(instance *gerritApi.Gerrit) error {
@@ -561,22 +620,18 @@ func (s ComponentService) createSSHKeyPairs(instance *gerritApi.Gerrit, secretNa
 	return
 }
 
+// setGerritAdminUserPassword adds the admin SSH key and replaces the default admin password
+// with the generated one, the REST client must be authenticated with the default password.
 func (s ComponentService) setGerritAdminUserPassword(
 	instance *gerritApi.Gerrit,
-	gerritUrl, gerritAdminPassword, gerritApiUrl string,
-	sshPortService int32,
+	gerritAdminPublicKey, gerritAdminPassword, gerritApiUrl string,
 ) error {
-	gerritAdminSshKeys, err := s.PlatformService.GetSecret(instance.Namespace, instance.Name+admin)
-	if err != nil {
-		return errors.Wrapf(err, "Failed to get Gerrit admin secret for %s/%s", instance.Namespace, instance.Name)
-	}
-
-	err = s.gerritClient.InitNewSshClient(spec.GerritDefaultAdminUser, gerritAdminSshKeys[rsaID], gerritUrl, sshPortService)
+	err := s.gerritClient.AddSSHKey("self", gerritAdminPublicKey)
 	if err != nil {
-		return errors.Wrapf(err, "Failed to initialize Gerrit SSH client for %s/%s", instance.Namespace, instance.Name)
+		return errors.Wrapf(err, "Failed to add Gerrit admin SSH key for %s/%s", instance.Namespace, instance.Name)
 	}
 
-	err = s.gerritClient.ChangePassword("admin", gerritAdminPassword)
+	err = s.gerritClient.ChangePassword("self", gerritAdminPassword)
 	if err != nil {
 		return errors.Wrapf(err, "Failed to set Gerrit admin password for %s/%s", instance.Namespace, instance.Name)
 	}
@@ -7,6 +7,8 @@ import (
 	"crypto/x509"
 	"encoding/pem"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
@@ -21,6 +23,7 @@ import (
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	ctrlMetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 
 	gerritApi "github.com/epam/edp-gerrit-operator/v2/api/v1"
 	pmock "github.com/epam/edp-gerrit-operator/v2/mock/platform"
@@ -385,36 +388,6 @@ func TestComponentService_Configure_updateDeploymentConfigPortTrue(t *testing.T)
 	assert.True(t, b)
 }
 
-func TestComponentService_Configure_GetPodsErr(t *testing.T) {
-	instance := CreateGerritInstance()
-	ps := &pmock.PlatformService{}
-	kc := fake.NewClientBuilder().Build()
-	ks := &runtime.Scheme{}
-	CS := ComponentService{PlatformService: ps, client: kc, k8sScheme: ks}
-	service := CreateService(port)
-	secretName := fmt.Sprintf("%v-admin-password", instance.Name)
-	secretData := map[string][]byte{
-		"password": {'o'},
-	}
-	podList := &coreV1Api.PodList{}
-	errTest := errors.New("test")
-
-	ps.On("GetExternalEndpoint", instance.Namespace, instance.Name).Return("", "", nil)
-	ps.On("CreateSecret", instance, instance.Name+"-admin-password", mock.Anything, mock.Anything).Return(nil)
-	ps.On("GetService", instance.Namespace, instance.Name).Return(service, nil)
-	ps.On("GetDeploymentSSHPort", instance).Return(port, nil)
-	ps.On("UpdateService", service, port).Return(nil)
-	ps.On("GetSecretData", instance.Namespace, secretName).Return(secretData, nil)
-	ps.On("GetPods", instance.Namespace, &metaV1.ListOptions{LabelSelector: "app=" + instance.Name}).Return(podList, errTest)
-
-	configure, b, err := CS.Configure(instance)
-	assert.Error(t, err)
-	assert.True(t, strings.Contains(err.Error(), errTest.Error()))
-	assert.Contains(t, err.Error(), "unable to determine Gerrit pod name")
-	assert.Equal(t, instance, configure)
-	assert.False(t, b)
-}
-
 func TestComponentService_Configure_createSSHKeyPairsAdminErr(t *testing.T) {
 	instance := CreateGerritInstance()
 	ps := &pmock.PlatformService{}
@@ -428,11 +401,6 @@ func TestComponentService_Configure_createSSHKeyPairsAdminErr(t *testing.T) {
 		"id_rsa":     {'a'},
 		"id_rsa.pub": {'k'},
 	}
-	podList := &coreV1Api.PodList{
-		Items: []coreV1Api.Pod{{
-			TypeMeta: metaV1.TypeMeta{},
-		}},
-	}
 
 	errTest := errors.New("test")
 
@@ -443,7 +411,6 @@ func TestComponentService_Configure_createSSHKeyPairsAdminErr(t *testing.T) {
 	ps.On("UpdateService", service, port).Return(nil)
 	ps.On("GetSecretData", instance.Namespace, secretName).Return(secretData, nil)
 	ps.On("GetSecretData", instance.Namespace, instance.Name+"-admin").Return(secretData, errTest)
-	ps.On("GetPods", instance.Namespace, &metaV1.ListOptions{LabelSelector: "app=" + instance.Name}).Return(podList, nil)
 
 	configure, b, err := CS.Configure(instance)
 	assert.Error(t, err)
@@ -467,11 +434,6 @@ func TestComponentService_Configure_CheckCredentialsErr(t *testing.T) {
 		"id_rsa":     {'a'},
 		"id_rsa.pub": {'k'},
 	}
-	podList := &coreV1Api.PodList{
-		Items: []coreV1Api.Pod{{
-			TypeMeta: metaV1.TypeMeta{},
-		}},
-	}
 
 	ps.On("GetExternalEndpoint", instance.Namespace, instance.Name).Return("", "", nil)
 	ps.On("CreateSecret", instance, instance.Name+"-admin-password", mock.Anything, mock.Anything).Return(nil)
@@ -480,11 +442,11 @@ func TestComponentService_Configure_CheckCredentialsErr(t *testing.T) {
 	ps.On("UpdateService", service, port).Return(nil)
 	ps.On("GetSecretData", instance.Namespace, secretName).Return(secretData, nil)
 	ps.On("GetSecretData", instance.Namespace, instance.Name+"-admin").Return(secretData, nil)
-	ps.On("GetPods", instance.Namespace, &metaV1.ListOptions{LabelSelector: "app=" + instance.Name}).Return(podList, nil)
 
 	configure, b, err := CS.Configure(instance)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "Unable to verify Gerrit credentials")
+	assert.True(t, IsErrUnavailable(err))
 	assert.Equal(t, instance, configure)
 	assert.False(t, b)
 }
@@ -506,16 +468,122 @@ func TestComponentService_GetRestClient(t *testing.T) {
 	assert.NoError(t, err)
 }
 
-func TestComponentService_GetRestClientNotNilResty(t *testing.T) {
+func TestComponentService_NewAdminClient(t *testing.T) {
 	instance := CreateGerritInstance()
+	instance.Spec.SSHUrl = "gerrit.example.com"
 
-	gc := gerrit.NewClient(nil, resty.New(), nil)
+	pkey, err := GenPkey()
+	require.NoError(t, err)
 
 	ps := &pmock.PlatformService{}
-	CS := ComponentService{gerritClient: &gc, PlatformService: ps}
+	shared := &gerrit.Client{}
+	CS := ComponentService{gerritClient: shared, PlatformService: ps}
 
-	_, err := CS.GetRestClient(instance)
-	assert.NoError(t, err)
+	ps.On("GetSecretData", instance.Namespace, instance.Name+"-admin-password").Return(map[string][]byte{"password": {'o'}}, nil)
+	ps.On("GetExternalEndpoint", instance.Namespace, instance.Name).Return("", "", nil)
+	ps.On("GetService", instance.Namespace, instance.Name).Return(CreateService(port), nil)
+	ps.On("GetSecret", instance.Namespace, instance.Name+"-admin").Return(map[string][]byte{"id_rsa": pkey}, nil)
+
+	cl, err := CS.NewAdminClient(instance)
+	require.NoError(t, err)
+	assert.NotSame(t, shared, cl)
+	assert.Nil(t, shared.Resty())
+}
+
+func TestComponentService_NewAdminClient_SSHErr(t *testing.T) {
+	instance := CreateGerritInstance()
+	instance.Spec.SSHUrl = "gerrit.example.com"
+
+	ps := &pmock.PlatformService{}
+	CS := ComponentService{gerritClient: &gerrit.Client{}, PlatformService: ps}
+
+	ps.On("GetSecretData", instance.Namespace, instance.Name+"-admin-password").Return(map[string][]byte{"password": {'o'}}, nil)
+	ps.On("GetExternalEndpoint", instance.Namespace, instance.Name).Return("", "", nil)
+	ps.On("GetService", instance.Namespace, instance.Name).Return(CreateService(port), nil)
+	ps.On("GetSecret", instance.Namespace, instance.Name+"-admin").Return(map[string][]byte{"id_rsa": []byte("invalid")}, nil)
+
+	_, err := CS.NewAdminClient(instance)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to init gerrit ssh client")
+}
+
+func TestComponentService_GetRestClient_PerInstance(t *testing.T) {
+	first := CreateGerritInstance()
+	first.Spec.RestAPIUrl = "http://first.example.com"
+	second := CreateGerritInstance()
+	second.Name = "second"
+	second.Spec.RestAPIUrl = "http://second.example.com"
+
+	ps := &pmock.PlatformService{}
+	CS := NewComponentService(ps, nil, nil)
+
+	ps.On("GetSecretData", first.Namespace, first.Name+"-admin-password").
+		Return(map[string][]byte{"password": {'o'}}, nil).Once()
+	ps.On("GetSecretData", second.Namespace, second.Name+"-admin-password").
+		Return(map[string][]byte{"password": {'o'}}, nil).Once()
+
+	firstClient, err := CS.GetRestClient(first)
+	require.NoError(t, err)
+
+	secondClient, err := CS.GetRestClient(second)
+	require.NoError(t, err)
+
+	assert.NotSame(t, firstClient, secondClient)
+	assert.Equal(t, "http://first.example.com", firstClient.Resty().HostURL)
+	assert.Equal(t, "http://second.example.com", secondClient.Resty().HostURL)
+
+	cachedClient, err := CS.GetRestClient(first)
+	require.NoError(t, err)
+	assert.Same(t, firstClient, cachedClient)
+
+	ps.AssertExpectations(t)
+}
+
+func TestComponentService_GetRestClient_MetricsLabel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ps := &pmock.PlatformService{}
+	CS := NewComponentService(ps, nil, nil)
+
+	for _, name := range []string{"metrics-first", "metrics-second"} {
+		instance := CreateGerritInstance()
+		instance.Name = name
+		instance.Spec.RestAPIUrl = server.URL
+
+		ps.On("GetSecretData", instance.Namespace, name+"-admin-password").
+			Return(map[string][]byte{"password": {'o'}}, nil)
+
+		cl, err := CS.GetRestClient(instance)
+		require.NoError(t, err)
+
+		_, err = cl.CheckCredentials()
+		require.NoError(t, err)
+	}
+
+	families, err := ctrlMetrics.Registry.Gather()
+	require.NoError(t, err)
+
+	requests := make(map[string]bool)
+
+	for _, family := range families {
+		if family.GetName() != "gerrit_operator_gerrit_requests_total" {
+			continue
+		}
+
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "gerrit" {
+					requests[label.GetValue()] = true
+				}
+			}
+		}
+	}
+
+	assert.True(t, requests["metrics-first"])
+	assert.True(t, requests["metrics-second"])
 }
 
 func TestComponentService_ExposeConfiguration_CreateUserErr(t *testing.T) {
@@ -701,12 +769,6 @@ func TestComponentService_Configure_CreateGroups(t *testing.T) {
 		"id_rsa.pub": {'k'},
 	}
 
-	podList := &coreV1Api.PodList{
-		Items: []coreV1Api.Pod{{
-			TypeMeta: metaV1.TypeMeta{},
-		}},
-	}
-
 	ps.On("GetExternalEndpoint", instance.Namespace, instance.Name).Return("", "", nil)
 	ps.On("CreateSecret", instance, instance.Name+"-admin-password", mock.Anything, mock.Anything).Return(nil)
 	ps.On("GetService", instance.Namespace, instance.Name).Return(service, nil)
@@ -714,11 +776,7 @@ func TestComponentService_Configure_CreateGroups(t *testing.T) {
 	ps.On("UpdateService", service, port).Return(nil)
 	ps.On("GetSecretData", instance.Namespace, secretName).Return(secretData, nil)
 	ps.On("GetSecretData", instance.Namespace, instance.Name+"-admin").Return(secretData, nil)
-	ps.On("GetPods", instance.Namespace, &metaV1.ListOptions{LabelSelector: "app=" + instance.Name}).Return(podList, nil)
 	ps.On("GetSecret", instance.Namespace, "name-admin").Return(map[string][]byte{}, nil)
-	ps.On("ExecInPod", instance.Namespace, "",
-		[]string{"/bin/sh", "-c", "chown -R gerrit2:gerrit2 /var/gerrit/review_site"}).
-		Return(nil, nil, nil)
 
 	var emptyByte []byte
 
@@ -729,16 +787,109 @@ func TestComponentService_Configure_CreateGroups(t *testing.T) {
 	gerritClient.On("InitNewSshClient", "admin", emptyByte, "", int32(80)).Return(nil)
 	gerritClient.On("CheckGroup", mock.Anything).Return(&statusOk, nil)
 	gerritClient.On("CreateGroup", mock.Anything, mock.Anything, mock.Anything).Return(&gerrit.Group{}, nil)
-	gerritClient.On("InitAllProjects",
-		mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
+	// All-Projects is initialized with gerrit.config from the operator image
 	_, _, err := CS.Configure(instance)
-	assert.NoError(t, err)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to initialize Gerrit All-Projects project")
 
 	ps.AssertExpectations(t)
 	gerritClient.AssertExpectations(t)
 }
 
+func TestComponentService_Configure_DefaultAdminPassword(t *testing.T) {
+	instance := CreateGerritInstance()
+	instance.Spec.Bootstrap = &gerritApi.GerritBootstrap{}
+	gerritClient := gerritClientMocks.ClientInterface{}
+	ps := &pmock.PlatformService{}
+	CS := ComponentService{PlatformService: ps, client: fake.NewClientBuilder().Build(), k8sScheme: &runtime.Scheme{}, gerritClient: &gerritClient}
+	service := CreateService(port)
+	secretData := map[string][]byte{
+		"password":   {'o'},
+		"id_rsa":     {'a'},
+		"id_rsa.pub": {'k'},
+	}
+
+	ps.On("GetExternalEndpoint", instance.Namespace, instance.Name).Return("", "", nil)
+	ps.On("CreateSecret", instance, instance.Name+"-admin-password", mock.Anything, mock.Anything).Return(nil)
+	ps.On("GetService", instance.Namespace, instance.Name).Return(service, nil)
+	ps.On("GetDeploymentSSHPort", instance).Return(port, nil)
+	ps.On("UpdateService", service, port).Return(nil)
+	ps.On("GetSecretData", instance.Namespace, instance.Name+"-admin-password").Return(secretData, nil)
+	ps.On("GetSecretData", instance.Namespace, instance.Name+"-admin").Return(secretData, nil)
+	ps.On("GetSecret", instance.Namespace, "name-admin").Return(secretData, nil)
+
+	gerritClient.On("InitNewRestClient", instance, ":///a/", "admin", "o").Return(nil)
+	gerritClient.On("CheckCredentials").Return(401, nil).Once()
+	gerritClient.On("InitNewRestClient", instance, ":///a/", "admin", spec.GerritDefaultAdminPassword).Return(nil)
+	gerritClient.On("CheckCredentials").Return(200, nil).Once()
+	gerritClient.On("AddSSHKey", "self", "k").Return(nil)
+	gerritClient.On("ChangePassword", "self", "o").Return(nil)
+	gerritClient.On("InitNewSshClient", "admin", []byte{'a'}, "", int32(80)).Return(nil)
+
+	_, _, err := CS.Configure(instance)
+	require.NoError(t, err)
+
+	gerritClient.AssertExpectations(t)
+}
+
+func TestComponentService_Configure_AdminNotInitialized(t *testing.T) {
+	instance := CreateGerritInstance()
+	gerritClient := gerritClientMocks.ClientInterface{}
+	ps := &pmock.PlatformService{}
+	CS := ComponentService{PlatformService: ps, client: fake.NewClientBuilder().Build(), k8sScheme: &runtime.Scheme{}, gerritClient: &gerritClient}
+	service := CreateService(port)
+	secretData := map[string][]byte{
+		"password":   {'o'},
+		"id_rsa":     {'a'},
+		"id_rsa.pub": {'k'},
+	}
+
+	ps.On("GetExternalEndpoint", instance.Namespace, instance.Name).Return("", "", nil)
+	ps.On("CreateSecret", instance, instance.Name+"-admin-password", mock.Anything, mock.Anything).Return(nil)
+	ps.On("GetService", instance.Namespace, instance.Name).Return(service, nil)
+	ps.On("GetDeploymentSSHPort", instance).Return(port, nil)
+	ps.On("UpdateService", service, port).Return(nil)
+	ps.On("GetSecretData", instance.Namespace, instance.Name+"-admin-password").Return(secretData, nil)
+	ps.On("GetSecretData", instance.Namespace, instance.Name+"-admin").Return(secretData, nil)
+
+	gerritClient.On("InitNewRestClient", instance, ":///a/", "admin", mock.Anything).Return(nil)
+	gerritClient.On("CheckCredentials").Return(401, nil)
+
+	_, _, err := CS.Configure(instance)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Gerrit admin user is not initialized")
+}
+
+func TestComponentService_Configure_Unavailable(t *testing.T) {
+	instance := CreateGerritInstance()
+	gerritClient := gerritClientMocks.ClientInterface{}
+	ps := &pmock.PlatformService{}
+	CS := ComponentService{PlatformService: ps, client: fake.NewClientBuilder().Build(), k8sScheme: &runtime.Scheme{}, gerritClient: &gerritClient}
+	service := CreateService(port)
+	secretData := map[string][]byte{
+		"password":   {'o'},
+		"id_rsa":     {'a'},
+		"id_rsa.pub": {'k'},
+	}
+
+	ps.On("GetExternalEndpoint", instance.Namespace, instance.Name).Return("", "", nil)
+	ps.On("CreateSecret", instance, instance.Name+"-admin-password", mock.Anything, mock.Anything).Return(nil)
+	ps.On("GetService", instance.Namespace, instance.Name).Return(service, nil)
+	ps.On("GetDeploymentSSHPort", instance).Return(port, nil)
+	ps.On("UpdateService", service, port).Return(nil)
+	ps.On("GetSecretData", instance.Namespace, instance.Name+"-admin-password").Return(secretData, nil)
+	ps.On("GetSecretData", instance.Namespace, instance.Name+"-admin").Return(secretData, nil)
+
+	gerritClient.On("InitNewRestClient", instance, ":///a/", "admin", mock.Anything).Return(nil)
+	gerritClient.On("CheckCredentials").Return(503, nil)
+
+	_, _, err := CS.Configure(instance)
+	require.Error(t, err)
+	assert.True(t, IsErrUnavailable(err))
+	assert.Contains(t, err.Error(), "status code 503")
+}
+
 func TestNewComponentService(t *testing.T) {
 	svc := NewComponentService(nil, nil, nil)
 	_, ok := svc.(ComponentService)
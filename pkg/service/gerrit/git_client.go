@@ -35,5 +35,5 @@ func (s ComponentService) GetGitClient(ctx context.Context, child Child, workDir
 		return nil, errors.Wrapf(err, "Failed to get Gerrit REST API URL %s/%s", g.Namespace, g.Name)
 	}
 
-	return git.New(gerritApiUrl, workDir, spec.GerritDefaultAdminUser, gerritAdminPassword), nil
+	return git.New(gerritApiUrl, workDir, spec.GerritDefaultAdminUser, gerritAdminPassword).WithContext(ctx), nil
 }
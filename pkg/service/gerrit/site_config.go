@@ -0,0 +1,201 @@
+package gerrit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	coreV1Api "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	gerritApi "github.com/epam/edp-gerrit-operator/v2/api/v1"
+	"github.com/epam/edp-gerrit-operator/v2/pkg/service/gerrit/spec"
+)
+
+const (
+	// SiteConfigSuffix is the suffix of the ConfigMap and the Secret with the managed site configuration.
+	// They are mounted into the Gerrit pod and merged into the site configuration by the entrypoint hook.
+	SiteConfigSuffix = "site-config"
+
+	// SiteConfigHashAnnotation is the pod template annotation with the hash of the managed site configuration.
+	SiteConfigHashAnnotation = spec.EdpAnnotationsPrefix + "/site-config-hash"
+
+	gerritConfigKey = "gerrit.config"
+	secureConfigKey = "secure.config"
+)
+
+// ApplySiteConfig writes the site configuration of spec.config into the <name>-site-config ConfigMap and Secret
+// and restarts Gerrit when the configuration has changed. It returns true if Gerrit has been restarted.
+// The Gerrit instance without spec.config is left as it is.
+func (s ComponentService) ApplySiteConfig(ctx context.Context, instance *gerritApi.Gerrit) (bool, error) {
+	if instance.Spec.Config == nil {
+		return false, nil
+	}
+
+	gerritConfig := RenderConfig(configValues(instance.Spec.Config))
+
+	secureValues, err := s.secureConfigValues(ctx, instance)
+	if err != nil {
+		return false, err
+	}
+
+	secureConfig := RenderConfig(secureValues)
+	name := formatSecretName(instance.Name, SiteConfigSuffix)
+
+	cm := &coreV1Api.ConfigMap{ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: instance.Namespace}}
+	if err = s.createOrUpdate(ctx, instance, cm, func() {
+		cm.Data = map[string]string{gerritConfigKey: gerritConfig}
+	}); err != nil {
+		return false, err
+	}
+
+	secret := &coreV1Api.Secret{ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: instance.Namespace}}
+	if err = s.createOrUpdate(ctx, instance, secret, func() {
+		secret.Data = map[string][]byte{secureConfigKey: []byte(secureConfig)}
+	}); err != nil {
+		return false, err
+	}
+
+	hash := sha256.Sum256([]byte(gerritConfig + "\x00" + secureConfig))
+	configHash := hex.EncodeToString(hash[:])
+
+	if instance.Status.ConfigHash == configHash {
+		return false, nil
+	}
+
+	if err = s.PlatformService.PatchDeploymentTemplateAnnotations(instance,
+		map[string]string{SiteConfigHashAnnotation: configHash}); err != nil {
+		return false, fmt.Errorf("failed to restart Gerrit with the changed site configuration: %w", err)
+	}
+
+	instance.Status.ConfigHash = configHash
+
+	return true, nil
+}
+
+func (s ComponentService) createOrUpdate(ctx context.Context, instance *gerritApi.Gerrit, obj client.Object, mutate func()) error {
+	_, err := controllerutil.CreateOrUpdate(ctx, s.client, obj, func() error {
+		mutate()
+
+		return controllerutil.SetControllerReference(instance, obj, s.k8sScheme)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write site configuration %s: %w", obj.GetName(), err)
+	}
+
+	return nil
+}
+
+// configValues returns the gerrit.config values, the single values are converted to the lists with one value.
+func configValues(config *gerritApi.GerritSiteConfig) map[string]map[string][]string {
+	values := make(map[string]map[string][]string, len(config.Values)+len(config.ListValues))
+
+	for section, keys := range config.Values {
+		for key, value := range keys {
+			if values[section] == nil {
+				values[section] = make(map[string][]string)
+			}
+
+			values[section][key] = []string{value}
+		}
+	}
+
+	for section, keys := range config.ListValues {
+		for key, list := range keys {
+			if values[section] == nil {
+				values[section] = make(map[string][]string)
+			}
+
+			values[section][key] = list
+		}
+	}
+
+	return values
+}
+
+// secureConfigValues reads the secure.config values from the Secrets.
+func (s ComponentService) secureConfigValues(ctx context.Context, instance *gerritApi.Gerrit) (map[string]map[string][]string, error) {
+	values := make(map[string]map[string][]string, len(instance.Spec.Config.SecureValues))
+
+	for section, keys := range instance.Spec.Config.SecureValues {
+		for key, ref := range keys {
+			var secret coreV1Api.Secret
+
+			err := s.client.Get(ctx, types.NamespacedName{Namespace: instance.Namespace, Name: ref.Name}, &secret)
+			if err != nil && !(k8sErrors.IsNotFound(err) && ref.Optional != nil && *ref.Optional) {
+				return nil, fmt.Errorf("failed to get secret %q of %s.%s: %w", ref.Name, section, key, err)
+			}
+
+			value, ok := secret.Data[ref.Key]
+			if !ok {
+				if ref.Optional != nil && *ref.Optional {
+					continue
+				}
+
+				return nil, fmt.Errorf("secret %q does not contain key %q of %s.%s", ref.Name, ref.Key, section, key)
+			}
+
+			if values[section] == nil {
+				values[section] = make(map[string][]string)
+			}
+
+			values[section][key] = []string{string(value)}
+		}
+	}
+
+	return values, nil
+}
+
+// RenderConfig renders the values in the git config format used by Gerrit.
+// The sections are keyed by the section name or by the section and the subsection separated by a dot.
+// The multi-valued keys are rendered once per value in the order of the values.
+// The sections and the keys are sorted, so the same values are always rendered into the same content.
+func RenderConfig(values map[string]map[string][]string) string {
+	sections := make([]string, 0, len(values))
+	for section := range values {
+		sections = append(sections, section)
+	}
+
+	sort.Strings(sections)
+
+	var b strings.Builder
+
+	for _, section := range sections {
+		if len(values[section]) == 0 {
+			continue
+		}
+
+		name, subsection, found := strings.Cut(section, ".")
+		if found {
+			fmt.Fprintf(&b, "[%s \"%s\"]\n", name, escapeConfigValue(subsection))
+		} else {
+			fmt.Fprintf(&b, "[%s]\n", name)
+		}
+
+		keys := make([]string, 0, len(values[section]))
+		for key := range values[section] {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			for _, value := range values[section][key] {
+				fmt.Fprintf(&b, "\t%s = \"%s\"\n", key, escapeConfigValue(value))
+			}
+		}
+	}
+
+	return b.String()
+}
+
+func escapeConfigValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`).Replace(value)
+}
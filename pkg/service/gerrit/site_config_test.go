@@ -0,0 +1,125 @@
+package gerrit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	coreV1Api "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilRuntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gerritApi "github.com/epam/edp-gerrit-operator/v2/api/v1"
+	pmock "github.com/epam/edp-gerrit-operator/v2/mock/platform"
+)
+
+func TestRenderConfig(t *testing.T) {
+	t.Parallel()
+
+	got := RenderConfig(map[string]map[string][]string{
+		"sshd":             {"threads": {"8"}},
+		"commentlink.jira": {"match": {`(JIRA-\d+)`}, "link": {"https://jira.example.com/browse/$1"}},
+		"download":         {"scheme": {"ssh", "http"}},
+		"receive":          {"timeout": {"4min"}},
+		"empty":            {},
+	})
+
+	assert.Equal(t, `[commentlink "jira"]
+	link = "https://jira.example.com/browse/$1"
+	match = "(JIRA-\\d+)"
+[download]
+	scheme = "ssh"
+	scheme = "http"
+[receive]
+	timeout = "4min"
+[sshd]
+	threads = "8"
+`, got)
+}
+
+func TestComponentService_ApplySiteConfig(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilRuntime.Must(gerritApi.AddToScheme(scheme))
+	utilRuntime.Must(coreV1Api.AddToScheme(scheme))
+
+	instance := CreateGerritInstance()
+	instance.Spec.Config = &gerritApi.GerritSiteConfig{
+		Values: map[string]map[string]string{
+			"receive":  {"timeout": "4min"},
+			"download": {"scheme": "ssh"},
+		},
+		ListValues: map[string]map[string][]string{
+			"download": {"scheme": {"ssh", "http"}},
+		},
+		SecureValues: map[string]map[string]coreV1Api.SecretKeySelector{
+			"database": {
+				"password": {LocalObjectReference: coreV1Api.LocalObjectReference{Name: "db"}, Key: "password"},
+			},
+		},
+	}
+
+	dbSecret := &coreV1Api.Secret{
+		ObjectMeta: metaV1.ObjectMeta{Name: "db", Namespace: namespace},
+		Data:       map[string][]byte{"password": []byte("secret")},
+	}
+
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(instance, dbSecret).Build()
+	ps := &pmock.PlatformService{}
+	ps.On("PatchDeploymentTemplateAnnotations", instance, mock.Anything).Return(nil).Once()
+
+	s := ComponentService{PlatformService: ps, client: k8sClient, k8sScheme: scheme}
+
+	restarted, err := s.ApplySiteConfig(context.Background(), instance)
+	require.NoError(t, err)
+	assert.True(t, restarted)
+	assert.NotEmpty(t, instance.Status.ConfigHash)
+
+	nn := types.NamespacedName{Namespace: namespace, Name: name + "-" + SiteConfigSuffix}
+
+	var cm coreV1Api.ConfigMap
+	require.NoError(t, k8sClient.Get(context.Background(), nn, &cm))
+	assert.Equal(t, "[download]\n\tscheme = \"ssh\"\n\tscheme = \"http\"\n[receive]\n\ttimeout = \"4min\"\n",
+		cm.Data[gerritConfigKey])
+	require.Len(t, cm.OwnerReferences, 1)
+
+	var secret coreV1Api.Secret
+	require.NoError(t, k8sClient.Get(context.Background(), nn, &secret))
+	assert.Equal(t, "[database]\n\tpassword = \"secret\"\n", string(secret.Data[secureConfigKey]))
+
+	// the unchanged configuration does not restart Gerrit
+	restarted, err = s.ApplySiteConfig(context.Background(), instance)
+	require.NoError(t, err)
+	assert.False(t, restarted)
+
+	ps.AssertExpectations(t)
+}
+
+func TestComponentService_ApplySiteConfig_SecretErr(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilRuntime.Must(gerritApi.AddToScheme(scheme))
+	utilRuntime.Must(coreV1Api.AddToScheme(scheme))
+
+	instance := CreateGerritInstance()
+	instance.Spec.Config = &gerritApi.GerritSiteConfig{
+		SecureValues: map[string]map[string]coreV1Api.SecretKeySelector{
+			"database": {
+				"password": {LocalObjectReference: coreV1Api.LocalObjectReference{Name: "db"}, Key: "password"},
+			},
+		},
+	}
+
+	s := ComponentService{
+		PlatformService: &pmock.PlatformService{},
+		client:          fake.NewClientBuilder().WithScheme(scheme).WithObjects(instance).Build(),
+		k8sScheme:       scheme,
+	}
+
+	_, err := s.ApplySiteConfig(context.Background(), instance)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `failed to get secret "db" of database.password`)
+}
@@ -18,11 +18,9 @@ const (
 
 	DefaultTemplatesDirectory = "templates"
 
-	DefaultScriptsDirectory = "scripts"
-
 	LocalTemplatesRelativePath = DefaultConfigFilesAbsolutePath + LocalConfigsRelativePath + "/" + DefaultTemplatesDirectory
 
-	LocalScriptsRelativePath = DefaultConfigFilesAbsolutePath + LocalConfigsRelativePath + "/" + DefaultScriptsDirectory
+	LocalConfigsAbsolutePath = DefaultConfigFilesAbsolutePath + LocalConfigsRelativePath
 
 	RouteHTTPSScheme = "https"
 
@@ -108,3 +106,29 @@ func findEnv(env []coreV1Api.EnvVar, name string) (coreV1Api.EnvVar, bool) {
 
 	return coreV1Api.EnvVar{}, false
 }
+
+// ReadyPods returns the pods that are ready and are not being deleted, e.g. the old pods of a rollout are skipped.
+func ReadyPods(pods []coreV1Api.Pod) []coreV1Api.Pod {
+	ready := make([]coreV1Api.Pod, 0, len(pods))
+
+	for i := range pods {
+		if pods[i].DeletionTimestamp != nil {
+			continue
+		}
+
+		for _, c := range pods[i].Status.Conditions {
+			if c.Type == coreV1Api.PodReady && c.Status == coreV1Api.ConditionTrue {
+				ready = append(ready, pods[i])
+
+				break
+			}
+		}
+	}
+
+	return ready
+}
+
+// IsRolledOut checks that all desired replicas of a deployment are updated and available.
+func IsRolledOut(desired, updated, available int32) bool {
+	return desired > 0 && updated >= desired && available >= desired
+}
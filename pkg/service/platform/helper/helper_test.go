@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	coreV1Api "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const name = "name"
@@ -87,3 +88,30 @@ func Test_UpdateEnv(t *testing.T) {
 	sum := UpdateEnv(env1, env2)
 	assert.Equal(t, env1, sum)
 }
+
+func TestReadyPods(t *testing.T) {
+	now := metaV1.Now()
+	readyStatus := coreV1Api.PodStatus{Conditions: []coreV1Api.PodCondition{
+		{Type: coreV1Api.PodReady, Status: coreV1Api.ConditionTrue},
+	}}
+
+	pods := []coreV1Api.Pod{
+		{ObjectMeta: metaV1.ObjectMeta{Name: "ready"}, Status: readyStatus},
+		{ObjectMeta: metaV1.ObjectMeta{Name: "starting"}, Status: coreV1Api.PodStatus{Conditions: []coreV1Api.PodCondition{
+			{Type: coreV1Api.PodReady, Status: coreV1Api.ConditionFalse},
+		}}},
+		{ObjectMeta: metaV1.ObjectMeta{Name: "terminating", DeletionTimestamp: &now}, Status: readyStatus},
+	}
+
+	ready := ReadyPods(pods)
+	require.Len(t, ready, 1)
+	assert.Equal(t, "ready", ready[0].Name)
+}
+
+func TestIsRolledOut(t *testing.T) {
+	assert.True(t, IsRolledOut(1, 1, 1))
+	assert.True(t, IsRolledOut(2, 2, 2))
+	assert.False(t, IsRolledOut(2, 1, 2))
+	assert.False(t, IsRolledOut(2, 2, 1))
+	assert.False(t, IsRolledOut(0, 0, 0))
+}
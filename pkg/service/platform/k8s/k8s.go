@@ -76,11 +76,12 @@ func (s *K8SService) IsDeploymentReady(gerrit *gerritApi.Gerrit) (bool, error) {
 		return false, fmt.Errorf("failed to Get Deployment %q: %w", gerrit.Name, err)
 	}
 
-	if deployment.Status.UpdatedReplicas == 1 && deployment.Status.AvailableReplicas == 1 {
-		return true, nil
+	desired := int32(1)
+	if deployment.Spec.Replicas != nil {
+		desired = *deployment.Spec.Replicas
 	}
 
-	return false, nil
+	return platformHelper.IsRolledOut(desired, deployment.Status.UpdatedReplicas, deployment.Status.AvailableReplicas), nil
 }
 
 func (s *K8SService) PatchDeploymentEnv(gerrit *gerritApi.Gerrit, env []coreV1Api.EnvVar) error {
@@ -117,6 +118,38 @@ func (s *K8SService) PatchDeploymentEnv(gerrit *gerritApi.Gerrit, env []coreV1Ap
 	return nil
 }
 
+func (s *K8SService) PatchDeploymentTemplateAnnotations(gerrit *gerritApi.Gerrit, annotations map[string]string) error {
+	patch, err := TemplateAnnotationsPatch(annotations)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.appsV1Client.Deployments(gerrit.Namespace).Patch(context.Background(), gerrit.Name, types.MergePatchType, patch, metaV1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to Patch Deployment %q: %w", gerrit.Name, err)
+	}
+
+	return nil
+}
+
+// TemplateAnnotationsPatch returns the merge patch that sets the pod template annotations.
+func TemplateAnnotationsPatch(annotations map[string]string) ([]byte, error) {
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": annotations,
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode pod template annotations: %w", err)
+	}
+
+	return patch, nil
+}
+
 // Init process with K8SService instance initialization actions.
 func (s *K8SService) Init(config *rest.Config, scheme *runtime.Scheme) error {
 	s.Scheme = scheme
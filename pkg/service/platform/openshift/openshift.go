@@ -169,7 +169,7 @@ func (s *OpenshiftService) IsDeploymentReady(instance *gerritApi.Gerrit) (bool,
 			return false, fmt.Errorf("failed to Get Gerrit Deployment Config %q: %w", instance.Name, err)
 		}
 
-		return dc.Status.UpdatedReplicas == 1 && dc.Status.AvailableReplicas == 1, nil
+		return platformHelper.IsRolledOut(dc.Spec.Replicas, dc.Status.UpdatedReplicas, dc.Status.AvailableReplicas), nil
 	}
 
 	ready, err := s.K8SService.IsDeploymentReady(instance)
@@ -223,6 +223,28 @@ func (s *OpenshiftService) PatchDeploymentEnv(gerrit *gerritApi.Gerrit, env []co
 	return nil
 }
 
+func (s *OpenshiftService) PatchDeploymentTemplateAnnotations(gerrit *gerritApi.Gerrit, annotations map[string]string) error {
+	if os.Getenv(deploymentTypeEnvName) != deploymentConfigsDeploymentType {
+		if err := s.K8SService.PatchDeploymentTemplateAnnotations(gerrit, annotations); err != nil {
+			return fmt.Errorf("fail to update k8s deployment annotations: %w", err)
+		}
+
+		return nil
+	}
+
+	patch, err := k8s.TemplateAnnotationsPatch(annotations)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.appClient.DeploymentConfigs(gerrit.Namespace).Patch(context.Background(), gerrit.Name, types.MergePatchType, patch, metaV1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to patch OpenShift Deployment Config %q: %w", gerrit.Name, err)
+	}
+
+	return nil
+}
+
 func getPort(value string) (int32, error) {
 	re := regexp.MustCompile(`\d+`)
 	if re.MatchString(value) {
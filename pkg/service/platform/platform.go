@@ -42,6 +42,9 @@ type PlatformService interface {
 
 	PatchDeploymentEnv(gerrit *gerritApi.Gerrit, env []coreV1Api.EnvVar) error
 
+	// PatchDeploymentTemplateAnnotations sets the pod template annotations, so the changed annotations restart Gerrit.
+	PatchDeploymentTemplateAnnotations(gerrit *gerritApi.Gerrit, annotations map[string]string) error
+
 	GetDeploymentSSHPort(gerrit *gerritApi.Gerrit) (int32, error)
 
 	GetService(namespace, name string) (*coreV1Api.Service, error)
@@ -0,0 +1,159 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/epam/edp-gerrit-operator/v2/pkg/metrics"
+)
+
+const (
+	instrumentationName = "github.com/epam/edp-gerrit-operator"
+	serviceName         = "gerrit-operator"
+
+	endpointEnv       = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	tracesEndpointEnv = "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"
+)
+
+// Span attributes of the Gerrit entities.
+const (
+	GerritInstance = attribute.Key("gerrit.instance")
+	GerritProject  = attribute.Key("gerrit.project")
+)
+
+// Setup configures the OTLP trace exporter if OTEL_EXPORTER_OTLP_ENDPOINT or OTEL_EXPORTER_OTLP_TRACES_ENDPOINT is set.
+// The exporter is configured with the standard OTEL_EXPORTER_OTLP_* environment variables.
+// The returned function flushes the spans and must be called on shutdown.
+func Setup(ctx context.Context) (shutdown func(context.Context) error, err error) {
+	if os.Getenv(endpointEnv) == "" && os.Getenv(tracesEndpointEnv) == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+
+	res, err := resource.Merge(
+		resource.Default(),
+		resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace resource: %w", err)
+	}
+
+	provider := sdkTrace.NewTracerProvider(
+		sdkTrace.WithBatcher(exporter),
+		sdkTrace.WithResource(res),
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return provider.Shutdown, nil
+}
+
+// Start starts a span with the global tracer provider, it is a no-op span if tracing is not configured.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records the error if any and ends the span.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+// SetAttributes adds the attributes to the span of the context.
+func SetAttributes(ctx context.Context, attrs ...attribute.KeyValue) {
+	trace.SpanFromContext(ctx).SetAttributes(attrs...)
+}
+
+type reconciler struct {
+	kind string
+	next reconcile.Reconciler
+}
+
+// NewReconciler wraps the reconciler to run every reconciliation in a span.
+func NewReconciler(kind string, next reconcile.Reconciler) reconcile.Reconciler {
+	return &reconciler{kind: kind, next: next}
+}
+
+func (r *reconciler) Reconcile(ctx context.Context, request ctrl.Request) (result ctrl.Result, err error) {
+	ctx, span := Start(ctx, "Reconcile "+r.kind,
+		attribute.String("k8s.namespace.name", request.Namespace),
+		attribute.String("k8s.object.name", request.Name),
+	)
+	defer func() {
+		End(span, err)
+	}()
+
+	return r.next.Reconcile(ctx, request)
+}
+
+type roundTripper struct {
+	next     http.RoundTripper
+	gerrit   string
+	basePath string
+}
+
+// NewRoundTripper wraps the transport to run every Gerrit REST API request in a span.
+// The trace context is propagated to Gerrit in the request headers.
+func NewRoundTripper(next http.RoundTripper, gerrit, basePath string) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &roundTripper{next: next, gerrit: gerrit, basePath: strings.Trim(basePath, "/")}
+}
+
+func (t *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := otel.Tracer(instrumentationName).Start(req.Context(),
+		metrics.RESTOperation(req.Method, req.URL.EscapedPath(), t.basePath),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			GerritInstance.String(t.gerrit),
+			semconv.HTTPRequestMethodKey.String(req.Method),
+			semconv.URLPath(req.URL.EscapedPath()),
+		),
+	)
+
+	req = req.Clone(ctx)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		End(span, err)
+
+		return nil, err
+	}
+
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+
+	span.End()
+
+	return resp, nil
+}
@@ -0,0 +1,106 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func setupRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdkTrace.NewTracerProvider(sdkTrace.WithSpanProcessor(recorder))
+
+	prevProvider, prevPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+	})
+
+	return recorder
+}
+
+func TestSetup_Disabled(t *testing.T) {
+	t.Setenv(endpointEnv, "")
+	t.Setenv(tracesEndpointEnv, "")
+
+	shutdown, err := Setup(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, shutdown(context.Background()))
+}
+
+func TestReconciler(t *testing.T) {
+	recorder := setupRecorder(t)
+
+	rec := NewReconciler("GerritProject", reconcile.Func(func(ctx context.Context, _ ctrl.Request) (ctrl.Result, error) {
+		SetAttributes(ctx, GerritProject.String("team/backend"))
+
+		_, span := Start(ctx, "child")
+		span.End()
+
+		return ctrl.Result{}, errors.New("reconcile fatal")
+	}))
+
+	_, err := rec.Reconcile(context.Background(), ctrl.Request{})
+	require.Error(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	child, parent := spans[0], spans[1]
+	assert.Equal(t, "Reconcile GerritProject", parent.Name())
+	assert.Equal(t, codes.Error, parent.Status().Code)
+	assert.Contains(t, parent.Attributes(), GerritProject.String("team/backend"))
+	assert.Equal(t, parent.SpanContext().SpanID(), child.Parent().SpanID())
+}
+
+func TestRoundTripper(t *testing.T) {
+	recorder := setupRecorder(t)
+
+	var traceparent string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+
+		w.WriteHeader(http.StatusConflict)
+	}))
+	defer server.Close()
+
+	ctx, parent := Start(context.Background(), "parent")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/a/groups/devs/members", http.NoBody)
+	require.NoError(t, err)
+
+	resp, err := NewRoundTripper(nil, "ger1", "/a").RoundTrip(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	span := spans[0]
+	assert.Equal(t, "POST groups/members", span.Name())
+	assert.Equal(t, codes.Error, span.Status().Code)
+	assert.Contains(t, span.Attributes(), GerritInstance.String("ger1"))
+	assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+	assert.Contains(t, traceparent, span.SpanContext().TraceID().String())
+}